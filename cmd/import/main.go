@@ -0,0 +1,106 @@
+// Command import creates members from a CSV or XLSX file.
+//
+// Run it from the cmd directory, like the server, so that it uses the same
+// database:
+//
+//	go run ./import -file members.xlsx -dry-run
+//	go run ./import -file members.csv -columns "name=Nome,phone=Cellulare"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/configs"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/goccy/go-json"
+)
+
+func main() {
+	path := flag.String("file", "", "CSV or XLSX file to import")
+	mapping := flag.String("columns", "", "column headers of the fields, as field=header separated by commas")
+	dryRun := flag.Bool("dry-run", false, "only validate the rows")
+	flag.Parse()
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	columns, err := parseColumns(*mapping)
+	if err != nil {
+		exit(err)
+	}
+
+	// Initialize config
+	config, err := configs.InitializeConfig()
+	if err != nil {
+		exit(err)
+	}
+
+	// Initialize logs
+	closeLogs, err := configs.InitLogs(config.Logs)
+	if err != nil {
+		exit(fmt.Errorf("failed to initialize logs: %w", err))
+	}
+
+	// Initialize database
+	db, err := configs.InitializeSQLite(config.Database)
+	if err != nil {
+		exit(fmt.Errorf("failed to initialize database: %w", err))
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		exit(err)
+	}
+	defer file.Close()
+
+	rows, err := utils.ReadTable(*path, file)
+	if err != nil {
+		exit(err)
+	}
+
+	report, err := services.NewImportServices(db, configs.InitializeEventBus(db, nil)).ImportMembers(context.Background(), rows, columns, *dryRun)
+	if err != nil {
+		exit(err)
+	}
+	closeLogs()
+
+	// Print the report without the members
+	report.Members = nil
+	output, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(output))
+
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+// parseColumns parses the column mapping given as field=header pairs.
+func parseColumns(mapping string) (entities.ImportColumns, error) {
+	custom := entities.ImportColumns{}
+	if mapping == "" {
+		return entities.DefaultImportColumns(), nil
+	}
+
+	for _, pair := range strings.Split(mapping, ",") {
+		field, header, ok := strings.Cut(pair, "=")
+		if !ok || field == "" || header == "" {
+			return nil, fmt.Errorf("invalid column mapping %q", pair)
+		}
+		custom[strings.TrimSpace(field)] = strings.TrimSpace(header)
+	}
+
+	return entities.DefaultImportColumns().Merge(custom), nil
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
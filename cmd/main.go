@@ -5,24 +5,30 @@ import (
 )
 
 func main() {
-	// Initialize logs
-	configs.InitLogs()
+	// Initialize config
+	config, err := configs.InitializeConfig()
+	if err != nil {
+		panic("Failed to load configuration: " + err.Error())
+	}
 
-	// Initialize env
-	configs.InitializeEnv()
+	// Initialize logs
+	closeLogs, err := configs.InitLogs(config.Logs)
+	if err != nil {
+		panic("Failed to initialize logs: " + err.Error())
+	}
 
 	// Initialize database
-	db, err := configs.InitializeSQLite()
+	db, err := configs.InitializeSQLite(config.Database)
 	if err != nil {
 		panic("Failed to initialize database: " + err.Error())
 	}
 
 	// Initialize redis
-	redis, err := configs.InitializeRedisClient()
+	redis, err := configs.InitializeRedisClient(config.Redis)
 	if err != nil {
 		panic("Failed to initialize redis: " + err.Error())
 	}
 	
 	// Initialize server
-	configs.Initialize(db, redis)
+	configs.Initialize(config, db, redis, closeLogs)
 }
\ No newline at end of file
@@ -1,8 +1,10 @@
 package adapters
 
 import (
-	"log"
+	"log/slog"
 
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
 )
@@ -16,8 +18,8 @@ func NewErrorHandler() *ErrorHandler {
 func (h *ErrorHandler) ParseData(c *fiber.Ctx, target interface{}) error {
 	err := json.Unmarshal(c.Body(), target)
 	if err != nil {
-		log.Println("Errore nella gestione dei dati: ", err)
-		return err
+		slog.DebugContext(c.UserContext(), "Error parsing request body", "error", err)
+		return entities.NewValidationError(i18n.MsgDataInvalid).Wrap(err)
 	}
 	return nil
 }
\ No newline at end of file
@@ -1,13 +1,27 @@
 package adapters
 
 import (
+	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
+	"time"
 
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
 	"github.com/go-redis/redis/v8"
 )
 
+// incrementScript increments a counter and sets its expiration when it has
+// none, returning the counter and the milliseconds left.
+var incrementScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {count, ttl}
+`)
+
 type CacheServices struct {
 	CacheClient *redis.Client
 }
@@ -22,7 +36,7 @@ func (c *CacheServices) SetCache(data ports.CachePort) error {
 	// Marshal the data
 	bytes, err := json.Marshal(data)
 	if err != nil {
-		log.Printf("@SetCache: Error marshaling data: %v", err)
+		slog.Error("Error marshaling cache data", "error", err)
 		return err
 	}
 
@@ -41,6 +55,15 @@ func (c *CacheServices) GetCacheKeys(data ports.CachePort) ([]string, error) {
 	return c.CacheClient.Keys(c.CacheClient.Context(), key).Result()
 }
 
+func (c *CacheServices) CountCacheKeys(data ports.CachePort) (int, error) {
+	count := 0
+	iter := c.CacheClient.Scan(c.CacheClient.Context(), 0, data.GetCacheKey(), 1000).Iterator()
+	for iter.Next(c.CacheClient.Context()) {
+		count++
+	}
+	return count, iter.Err()
+}
+
 func (c *CacheServices) GetCacheFromKey(key string, data ports.CachePort) error {
 	return c.CacheClient.Get(c.CacheClient.Context(), key).Scan(data)
 }
@@ -65,7 +88,7 @@ func (c *CacheServices) DelCacheMultiple(data ports.CachePort) error {
 	// Get the key
 	key, err := c.GetCacheKeys(data)
 	if err != nil {
-		log.Printf("@DelCacheMultiple: Error getting keys: %v", err)
+		slog.Error("Error getting cache keys", "error", err)
 		return err
 	}
 
@@ -76,3 +99,21 @@ func (c *CacheServices) DelCacheMultiple(data ports.CachePort) error {
 	
 	return c.CacheClient.Del(c.CacheClient.Context(), key...).Err()
 }
+
+func (c *CacheServices) Increment(key string, expiration time.Duration) (int64, time.Duration, error) {
+	result, err := incrementScript.Run(c.CacheClient.Context(), c.CacheClient, []string{key}, expiration.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+	return result[0], time.Duration(result[1]) * time.Millisecond, nil
+}
+
+func (c *CacheServices) Ping() error {
+	return c.CacheClient.Ping(c.CacheClient.Context()).Err()
+}
+
+func (c *CacheServices) WithContext(ctx context.Context) ports.CacheAdapters {
+	return &CacheServices{
+		CacheClient: c.CacheClient.WithContext(ctx),
+	}
+}
@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+)
+
+// EventBus dispatches the domain events to their handlers in the same process.
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]ports.EventHandler
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		handlers: make(map[string][]ports.EventHandler),
+	}
+}
+
+func (b *EventBus) Subscribe(name string, handler ports.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+func (b *EventBus) Publish(ctx context.Context, event entities.Event) {
+	b.mu.RLock()
+	handlers := b.handlers[event.EventName()]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		b.dispatch(ctx, event, handler)
+	}
+}
+
+// dispatch calls a handler, its errors and panics are logged.
+func (b *EventBus) dispatch(ctx context.Context, event entities.Event, handler ports.EventHandler) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "Event handler panicked", "event", event.EventName(), "panic", r)
+		}
+	}()
+
+	if err := handler(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "Error handling event", "event", event.EventName(), "error", err)
+	}
+}
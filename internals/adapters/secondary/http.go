@@ -1,14 +1,50 @@
 package adapters
 
 import (
+	"bufio"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 type HttpServices struct{}
 
 type Response struct {
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	Message   string      `json:"message"`
+	Code      string      `json:"code,omitempty"`       // Code of the error, e.g. not_found
+	RequestID string      `json:"request_id,omitempty"` // ID of the request, in the errors
+	Data      interface{} `json:"data,omitempty"`
+	Errors    interface{} `json:"errors,omitempty"`
+}
+
+// Statuses and codes of the kinds of domain errors
+var domainErrors = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{entities.ErrNotFound, fiber.StatusNotFound, entities.ErrorCodeNotFound},
+	{entities.ErrConflict, fiber.StatusConflict, entities.ErrorCodeConflict},
+	{entities.ErrValidation, fiber.StatusBadRequest, entities.ErrorCodeValidation},
+	{entities.ErrForbidden, fiber.StatusForbidden, entities.ErrorCodeForbidden},
+}
+
+// Codes and messages of the errors returned by Fiber, e.g. routes not found
+var statusErrors = map[int]struct {
+	code    string
+	message string
+}{
+	fiber.StatusBadRequest:       {entities.ErrorCodeBadRequest, i18n.MsgBadRequest},
+	fiber.StatusUnauthorized:     {entities.ErrorCodeUnauthorized, i18n.MsgUnauthorized},
+	fiber.StatusForbidden:        {entities.ErrorCodeForbidden, i18n.MsgForbidden},
+	fiber.StatusNotFound:         {entities.ErrorCodeNotFound, i18n.MsgResourceNotFound},
+	fiber.StatusMethodNotAllowed: {entities.ErrorCodeMethodNotAllowed, i18n.MsgMethodNotAllowed},
+	fiber.StatusTooManyRequests:  {entities.ErrorCodeTooManyRequests, i18n.MsgTooManyRequests},
 }
 
 func NewHttpServices() *HttpServices {
@@ -17,51 +53,100 @@ func NewHttpServices() *HttpServices {
 
 // 200 OK
 func (h *HttpServices) Success(c *fiber.Ctx, data interface{}, message string) error {
+	lang := language(c)
+	localize(lang, data)
 	return c.Status(fiber.StatusOK).JSON(Response{
 		Data:    data,
-		Message: message,
+		Message: i18n.Translate(lang, message),
 	})
 }
 
 // 400 Bad Request
 func (h *HttpServices) BadRequest(c *fiber.Ctx, message string) error {
-	return c.Status(fiber.StatusBadRequest).JSON(Response{
-		Message: message,
-	})
+	return h.failure(c, fiber.StatusBadRequest, entities.ErrorCodeBadRequest, message, nil)
+}
+
+// 400 Bad Request with validation errors
+func (h *HttpServices) ValidationFailed(c *fiber.Ctx, message string, errors interface{}) error {
+	return h.failure(c, fiber.StatusBadRequest, entities.ErrorCodeValidation, message, errors)
 }
 
 // 401 Unauthorized
 func (h *HttpServices) Unauthorized(c *fiber.Ctx, text string) error {
-	message := "Unauthorized, please login first"
+	message := i18n.MsgUnauthorized
 
 	// Set custom message
 	if text != "" {
 		message = text
 	}
 
-	return c.Status(fiber.StatusUnauthorized).JSON(Response{
-		Message: message,
-	})
+	return h.failure(c, fiber.StatusUnauthorized, entities.ErrorCodeUnauthorized, message, nil)
 }
 
 // 403 Forbidden
 func (h *HttpServices) Forbidden(c *fiber.Ctx) error {
-	return c.Status(fiber.StatusForbidden).JSON(Response{
-		Message: "Forbidden, you don't have permission to access this resource",
-	})
+	return h.failure(c, fiber.StatusForbidden, entities.ErrorCodeForbidden, i18n.MsgForbidden, nil)
 }
 
 // 404 Not Found
 func (h *HttpServices) NotFound(c *fiber.Ctx, message string) error {
-	return c.Status(fiber.StatusNotFound).JSON(Response{
-		Message: message,
-	})
+	return h.failure(c, fiber.StatusNotFound, entities.ErrorCodeNotFound, message, nil)
+}
+
+// 409 Conflict
+func (h *HttpServices) Conflict(c *fiber.Ctx, message string) error {
+	return h.failure(c, fiber.StatusConflict, entities.ErrorCodeConflict, message, nil)
 }
 
 // 500 Internal Server Error
 func (h *HttpServices) InternalServerError(c *fiber.Ctx, message string) error {
-	return c.Status(fiber.StatusInternalServerError).JSON(Response{
-		Message: message,
+	return h.failure(c, fiber.StatusInternalServerError, entities.ErrorCodeInternal, message, nil)
+}
+
+// Error responds with the status of an error. It's the ErrorHandler of the app,
+// the unexpected errors are logged and their details never sent to the client.
+func (h *HttpServices) Error(c *fiber.Ctx, err error) error {
+	var validationErrors entities.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return h.ValidationFailed(c, i18n.MsgBadRequest, validationErrors)
+	}
+
+	var domainError *entities.DomainError
+	if errors.As(err, &domainError) {
+		for _, e := range domainErrors {
+			if errors.Is(domainError.Kind, e.kind) {
+				return h.failure(c, e.status, e.code, domainError.Message, nil, domainError.Args...)
+			}
+		}
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return h.NotFound(c, i18n.MsgResourceNotFound)
+	}
+
+	var fiberError *fiber.Error
+	if errors.As(err, &fiberError) {
+		if status, ok := statusErrors[fiberError.Code]; ok {
+			return h.failure(c, fiberError.Code, status.code, status.message, nil)
+		}
+		if fiberError.Code < fiber.StatusInternalServerError {
+			return h.failure(c, fiberError.Code, entities.ErrorCodeBadRequest, i18n.MsgBadRequest, nil)
+		}
+	}
+
+	slog.ErrorContext(c.UserContext(), "Request failed", "method", c.Method(), "path", c.Path(), "error", err)
+	return h.InternalServerError(c, i18n.MsgInternalError)
+}
+
+// failure responds with an error, its message is translated in the language of the request.
+func (h *HttpServices) failure(c *fiber.Ctx, status int, code string, message string, errors interface{}, args ...interface{}) error {
+	lang := language(c)
+	localize(lang, errors)
+	return c.Status(status).JSON(Response{
+		Message:   i18n.Translate(lang, message, args...),
+		Code:      code,
+		RequestID: requestID(c),
+		Errors:    errors,
 	})
 }
 
@@ -69,3 +154,44 @@ func (h *HttpServices) InternalServerError(c *fiber.Ctx, message string) error {
 func (h *HttpServices) WithFile(c *fiber.Ctx, pathToFile string) error {
 	return c.SendFile(pathToFile)
 }
+
+// Response with data downloaded as a file
+func (h *HttpServices) Attachment(c *fiber.Ctx, filename string, data []byte) error {
+	c.Attachment(filename)
+	return c.Status(fiber.StatusOK).Send(data)
+}
+
+// Response downloaded as a file, streamed while it is written
+func (h *HttpServices) Stream(c *fiber.Ctx, filename string, write func(w io.Writer) error) error {
+	c.Attachment(filename)
+
+	// The context of the request is released before the body is written
+	ctx := c.UserContext()
+	c.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := write(w); err != nil {
+			slog.ErrorContext(ctx, "Error streaming file", "file", filename, "error", err)
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// requestID returns the ID set by the requestid middleware.
+func requestID(c *fiber.Ctx) string {
+	return c.GetRespHeader(fiber.HeaderXRequestID)
+}
+
+// language returns the language negotiated with the Accept-Language header
+// and sets the Content-Language of the response.
+func language(c *fiber.Ctx) string {
+	lang := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+	c.Set(fiber.HeaderContentLanguage, lang)
+	return lang
+}
+
+// localize translates the messages held by the data of a response.
+func localize(lang string, data interface{}) {
+	if localizable, ok := data.(i18n.Localizable); ok {
+		localizable.Localize(lang)
+	}
+}
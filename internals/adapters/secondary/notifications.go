@@ -0,0 +1,173 @@
+package adapters
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/goccy/go-json"
+)
+
+// Timeout of the requests to the SMS gateway and to the webhooks
+const notificationTimeout = 10 * time.Second
+
+// EmailChannel sends the notifications by email through an SMTP server.
+type EmailChannel struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewEmailChannel creates an email channel, without authentication if user is empty.
+func NewEmailChannel(host string, port string, user string, password string, from string) *EmailChannel {
+	var auth smtp.Auth
+	if user != "" {
+		auth = smtp.PlainAuth("", user, password, host)
+	}
+
+	return &EmailChannel{
+		addr: host + ":" + port,
+		auth: auth,
+		from: from,
+	}
+}
+
+func (e *EmailChannel) Name() string {
+	return entities.ChannelEmail
+}
+
+func (e *EmailChannel) Send(notification *entities.Notification) error {
+	var message strings.Builder
+	fmt.Fprintf(&message, "From: %s\r\n", e.from)
+	fmt.Fprintf(&message, "To: %s\r\n", notification.Recipient)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(notification.Body)
+
+	return smtp.SendMail(e.addr, e.auth, e.from, []string{notification.Recipient}, []byte(message.String()))
+}
+
+// SMSChannel sends the notifications through an SMS gateway accepting
+// {"to", "from", "text"} as JSON with a bearer token.
+type SMSChannel struct {
+	url    string
+	token  string
+	sender string
+	client *http.Client
+}
+
+func NewSMSChannel(url string, token string, sender string) *SMSChannel {
+	return &SMSChannel{
+		url:    url,
+		token:  token,
+		sender: sender,
+		client: &http.Client{Timeout: notificationTimeout},
+	}
+}
+
+func (s *SMSChannel) Name() string {
+	return entities.ChannelSMS
+}
+
+func (s *SMSChannel) Send(notification *entities.Notification) error {
+	return postJSON(s.client, s.url, s.token, map[string]string{
+		"to":   notification.Recipient,
+		"from": s.sender,
+		"text": notification.Body,
+	})
+}
+
+// WebhookChannel posts the notifications as JSON to a URL.
+type WebhookChannel struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookChannel(url string) *WebhookChannel {
+	return &WebhookChannel{
+		url:    url,
+		client: &http.Client{Timeout: notificationTimeout},
+	}
+}
+
+func (w *WebhookChannel) Name() string {
+	return entities.ChannelWebhook
+}
+
+func (w *WebhookChannel) Send(notification *entities.Notification) error {
+	return postJSON(w.client, w.url, "", notification)
+}
+
+// FileChannel appends the notifications as JSON lines to a file, to try the
+// rules without contacting the members.
+type FileChannel struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileChannel(path string) *FileChannel {
+	return &FileChannel{
+		path: path,
+	}
+}
+
+func (f *FileChannel) Name() string {
+	return entities.ChannelFile
+}
+
+func (f *FileChannel) Send(notification *entities.Notification) error {
+	line, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// postJSON posts the data as JSON, failing on a response other than 2xx.
+func postJSON(client *http.Client, url string, token string, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s from %s", response.Status, url)
+	}
+	return nil
+}
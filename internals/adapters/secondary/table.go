@@ -0,0 +1,284 @@
+package adapters
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	tableSheet      = "Sheet1"
+	tableDateLayout = "02/01/2006"
+)
+
+type TableServices struct{}
+
+func NewTableServices() *TableServices {
+	return &TableServices{}
+}
+
+func (t *TableServices) NewTableWriter(format string, title string, w io.Writer) (ports.TableWriter, error) {
+	switch format {
+	case "csv":
+		return newCSVTableWriter(w)
+	case "xlsx":
+		return newXLSXTableWriter(w)
+	case "pdf":
+		return newPDFTableWriter(title, w), nil
+	default:
+		return nil, entities.NewValidationError(i18n.MsgExportFileFormatInvalid)
+	}
+}
+
+// formatCell formats a value as read in Italy.
+func formatCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float32:
+		return strings.Replace(strconv.FormatFloat(float64(v), 'f', 2, 32), ".", ",", 1)
+	case float64:
+		return strings.Replace(strconv.FormatFloat(v, 'f', 2, 64), ".", ",", 1)
+	case bool:
+		if v {
+			return "sì"
+		}
+		return "no"
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(tableDateLayout)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return formatCell(*v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// csvTableWriter writes a CSV file separated by semicolons, as opened by
+// the Italian versions of Excel.
+type csvTableWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVTableWriter(w io.Writer) (*csvTableWriter, error) {
+	// The BOM tells Excel the file is UTF-8
+	if _, err := io.WriteString(w, "\xef\xbb\xbf"); err != nil {
+		return nil, err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+	return &csvTableWriter{writer: writer}, nil
+}
+
+func (t *csvTableWriter) WriteHeader(columns []string) error {
+	return t.writer.Write(columns)
+}
+
+func (t *csvTableWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatCell(value)
+	}
+
+	if err := t.writer.Write(record); err != nil {
+		return err
+	}
+
+	// Send the rows as they are written
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+func (t *csvTableWriter) Close() error {
+	t.writer.Flush()
+	return t.writer.Error()
+}
+
+// xlsxTableWriter writes the rows to the first sheet of an XLSX file, the
+// rows are kept on disk by excelize until the file is written on Close.
+type xlsxTableWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+	date   int
+}
+
+func newXLSXTableWriter(w io.Writer) (*xlsxTableWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter(tableSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	dateFormat := "dd/mm/yyyy"
+	date, err := file.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	if err != nil {
+		return nil, err
+	}
+
+	return &xlsxTableWriter{w: w, file: file, stream: stream, date: date}, nil
+}
+
+func (t *xlsxTableWriter) WriteHeader(columns []string) error {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = column
+	}
+	return t.writeRow(values)
+}
+
+func (t *xlsxTableWriter) WriteRow(values []interface{}) error {
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case float32:
+			cells[i] = excelize.Cell{Value: float64(v)}
+		case time.Time:
+			cells[i] = excelize.Cell{Value: v, StyleID: t.date}
+		case *time.Time:
+			if v != nil {
+				cells[i] = excelize.Cell{Value: *v, StyleID: t.date}
+			}
+		case string, int, int64, uint, float64:
+			cells[i] = v
+		default:
+			cells[i] = formatCell(v)
+		}
+	}
+	return t.writeRow(cells)
+}
+
+func (t *xlsxTableWriter) writeRow(values []interface{}) error {
+	t.row++
+	cell, err := excelize.CoordinatesToCellName(1, t.row)
+	if err != nil {
+		return err
+	}
+	return t.stream.SetRow(cell, values)
+}
+
+func (t *xlsxTableWriter) Close() error {
+	defer t.file.Close()
+
+	if err := t.stream.Flush(); err != nil {
+		return err
+	}
+	return t.file.Write(t.w)
+}
+
+// pdfTableWriter writes a printable table on landscape A4 pages, repeating
+// the header on each page. The document is written on Close.
+type pdfTableWriter struct {
+	w         io.Writer
+	pdf       *fpdf.Fpdf
+	translate func(string) string
+	columns   []string
+	width     float64
+}
+
+const (
+	pdfRowHeight = 6
+	pdfFontSize  = 8
+)
+
+func newPDFTableWriter(title string, w io.Writer) *pdfTableWriter {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(true, 10)
+
+	t := &pdfTableWriter{
+		w:         w,
+		pdf:       pdf,
+		translate: pdf.UnicodeTranslatorFromDescriptor(""),
+	}
+
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, 8, t.translate(title), "", 1, "L", false, 0, "")
+		t.writeHeader()
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-10)
+		pdf.SetFont("Helvetica", "", pdfFontSize)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%s - pagina %d", time.Now().Format(tableDateLayout), pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	return t
+}
+
+func (t *pdfTableWriter) WriteHeader(columns []string) error {
+	t.columns = columns
+	width, _ := t.pdf.GetPageSize()
+	left, _, right, _ := t.pdf.GetMargins()
+	t.width = (width - left - right) / float64(len(columns))
+
+	t.pdf.AddPage()
+	return t.pdf.Error()
+}
+
+func (t *pdfTableWriter) writeHeader() {
+	if len(t.columns) == 0 {
+		return
+	}
+
+	t.pdf.SetFont("Helvetica", "B", pdfFontSize)
+	t.pdf.SetFillColor(230, 230, 230)
+	for _, column := range t.columns {
+		t.pdf.CellFormat(t.width, pdfRowHeight, t.translate(column), "1", 0, "L", true, 0, "")
+	}
+	t.pdf.Ln(-1)
+}
+
+func (t *pdfTableWriter) WriteRow(values []interface{}) error {
+	t.pdf.SetFont("Helvetica", "", pdfFontSize)
+	for _, value := range values {
+		align := "L"
+		switch value.(type) {
+		case float32, float64, int, int64, uint:
+			align = "R"
+		}
+
+		text := t.fit(t.translate(formatCell(value)))
+		t.pdf.CellFormat(t.width, pdfRowHeight, text, "1", 0, align, false, 0, "")
+	}
+	t.pdf.Ln(-1)
+	return t.pdf.Error()
+}
+
+// fit truncates a text longer than the column.
+func (t *pdfTableWriter) fit(text string) string {
+	const padding = 2
+	if t.pdf.GetStringWidth(text) <= t.width-padding {
+		return text
+	}
+
+	for len(text) > 0 && t.pdf.GetStringWidth(text+"...") > t.width-padding {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
+
+func (t *pdfTableWriter) Close() error {
+	if t.pdf.PageCount() == 0 {
+		t.pdf.AddPage()
+	}
+	return t.pdf.Output(t.w)
+}
@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type WebhookClient struct {
+	client *http.Client
+}
+
+func NewWebhookClient() *WebhookClient {
+	return &WebhookClient{
+		client: &http.Client{Timeout: notificationTimeout},
+	}
+}
+
+func (w *WebhookClient) Post(url string, headers map[string]string, payload []byte) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	// Drain the body so that the connection is reused
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
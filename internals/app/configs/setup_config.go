@@ -0,0 +1,107 @@
+package configs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"strconv"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Paths relative to the cmd directory, where the server is run
+	defaultEnvFile    = "../.env"
+	defaultConfigFile = "../config.yaml"
+)
+
+// InitializeConfig loads the configuration from the defaults, the YAML file
+// in CONFIG_FILE and the environment, in order of precedence. The .env file is
+// loaded in the environment without overriding the variables already set.
+// Both files are optional, unless CONFIG_FILE is set.
+func InitializeConfig() (*entities.Config, error) {
+	slog.Info("Loading configuration...")
+	config := entities.DefaultConfig()
+
+	// .env
+	if err := godotenv.Load(defaultEnvFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load %s: %w", defaultEnvFile, err)
+	}
+
+	// Configuration file
+	path, required := os.LookupEnv("CONFIG_FILE")
+	if !required {
+		path = defaultConfigFile
+	}
+	if err := loadConfigFile(path, config); err != nil {
+		if required || !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	// Environment
+	if err := loadConfigEnv(reflect.ValueOf(config).Elem()); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return config, nil
+}
+
+func loadConfigFile(path string, config *entities.Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	// Unknown keys are rejected, they are likely typos
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	slog.Info("Configuration loaded", "path", path)
+	return nil
+}
+
+// loadConfigEnv sets the fields with an env tag whose variable is set.
+func loadConfigEnv(v reflect.Value) error {
+	var errs []error
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			if err := loadConfigEnv(field); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		key := v.Type().Field(i).Tag.Get("env")
+		value, ok := os.LookupEnv(key)
+		if key == "" || !ok {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Int:
+			number, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: must be a number, got %q", key, value))
+				continue
+			}
+			field.SetInt(int64(number))
+		}
+	}
+	return errors.Join(errs...)
+}
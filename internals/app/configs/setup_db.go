@@ -1,9 +1,10 @@
 package configs
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -12,11 +13,12 @@ var (
 	DB *gorm.DB
 )
 
-func InitializeSQLite() (*gorm.DB, error) {
-	log.Println("Connecting to SQLite database")
+func InitializeSQLite(config entities.DatabaseConfig) (*gorm.DB, error) {
+	slog.Info("Connecting to SQLite database", "path", config.Path)
 	var err error
-	if DB, err = gorm.Open(sqlite.Open("test.db"), &gorm.Config{
+	if DB, err = gorm.Open(sqlite.Open(config.Path), &gorm.Config{
 		DisableForeignKeyConstraintWhenMigrating: false,
+		TranslateError:                           true,
 	}); err != nil {
 		return nil, err
 	}
@@ -30,8 +32,30 @@ func InitializeSQLite() (*gorm.DB, error) {
 		&entities.Subscription{},
 		&entities.Roles{},
 		&entities.Permissions{},
+		&entities.Household{},
+		&entities.Promotion{},
+		&entities.PromotionRedemption{},
+		&entities.Guest{},
+		&entities.GuestPass{},
+		&entities.Audit{},
+		&entities.Consent{},
+		&entities.Notification{},
+		&entities.Webhook{},
+		&entities.WebhookDelivery{},
 	); err != nil {
-		log.Println(err)
+		slog.Error("Error migrating the database", "error", err)
+		return nil, err
+	}
+
+	// Replaced by the unique index of the renewals
+	if DB.Migrator().HasIndex(&entities.Subscription{}, "idx_subscriptions_previous_id") {
+		if err = DB.Migrator().DropIndex(&entities.Subscription{}, "idx_subscriptions_previous_id"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Queries traced as children of the context given to WithContext
+	if err = DB.Use(tracing.NewGormPlugin()); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,61 @@
+package configs
+
+import (
+	"context"
+	"log/slog"
+
+	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+// InitializeEventBus creates the event bus and subscribes the services reacting to the events.
+// Without a cache client the cached data is not invalidated.
+func InitializeEventBus(db *gorm.DB, cache *redis.Client) ports.EventBus {
+	slog.Info("Setting up event bus...")
+	events := secondary.NewEventBus()
+
+	// Webhooks
+	webhookServices := services.NewWebhookServices(db, secondary.NewWebhookClient())
+	for _, name := range entities.WebhookEvents {
+		events.Subscribe(name, func(ctx context.Context, event entities.Event) error {
+			return webhookServices.Publish(ctx, event.EventName(), event)
+		})
+	}
+
+	// Audit of the changes made by the scheduler
+	auditServices := services.NewAuditServices(db)
+	events.Subscribe(entities.EventSubscriptionCreated, func(ctx context.Context, event entities.Event) error {
+		if created := event.(*entities.SubscriptionCreated); created.AutoRenewal {
+			auditServices.LogSystemChange(entities.AuditCreate, "subscriptions", created.Subscription.ID, nil, created.Subscription)
+		}
+		return nil
+	})
+	events.Subscribe(entities.EventSubscriptionExpired, func(ctx context.Context, event entities.Event) error {
+		expired := event.(*entities.SubscriptionExpired)
+		auditServices.LogSystemChange(entities.AuditUpdate, "subscriptions", expired.Subscription.ID, map[string]bool{"is_active": true}, map[string]bool{"is_active": false})
+		return nil
+	})
+
+	if cache == nil {
+		return events
+	}
+	cacheAdapters := secondary.NewCacheServices(cache)
+
+	// Reports are computed from the members and their subscriptions
+	for _, name := range []string{entities.EventMemberCreated, entities.EventMemberDeleted, entities.EventSubscriptionCreated, entities.EventSubscriptionExpired} {
+		events.Subscribe(name, func(ctx context.Context, event entities.Event) error {
+			return cacheAdapters.WithContext(ctx).DelCacheMultiple(&entities.Report{})
+		})
+	}
+
+	// Sessions of deleted users
+	events.Subscribe(entities.EventUserDeleted, func(ctx context.Context, event entities.Event) error {
+		return cacheAdapters.WithContext(ctx).DelCacheMultiple(&entities.Session{UserID: event.(*entities.UserDeleted).UserID})
+	})
+
+	return events
+}
@@ -1,45 +1,53 @@
 package configs
 
 import (
-	"log"
-	"os"
-
-	"time"
+	"log/slog"
+	"strings"
 
+	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/metrics"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/middlewares"
 	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 )
 
-func setupFiberApp() *fiber.App {
-	log.Println("Setting up Fiber app")
-	return fiber.New(fiber.Config{
-		JSONEncoder: json.Marshal,
-		JSONDecoder: json.Unmarshal,
+func setupFiberApp(registry *metrics.Registry) *fiber.App {
+	slog.Info("Setting up Fiber app")
+	app := fiber.New(fiber.Config{
+		JSONEncoder:  json.Marshal,
+		JSONDecoder:  json.Unmarshal,
+		ErrorHandler: secondary.NewHttpServices().Error,
 	})
+
+	// ID of the request, sent in the X-Request-ID header and in the errors
+	app.Use(requestid.New())
+
+	// Span of the request, parent of the spans of the handlers
+	app.Use(middlewares.NewTracingMiddlewares().TraceRequest)
+
+	// Requests logged, with their ID, once answered
+	app.Use(middlewares.NewLogsMiddlewares(slog.Default()).LogRequest)
+
+	// Requests counted with their final status
+	app.Use(middlewares.NewMetricsMiddlewares(registry).RecordRequest)
+
+	// Panics are answered by the ErrorHandler
+	app.Use(recover.New())
+	return app
 }
 
-func newFiberCors(app *fiber.App) {
-	log.Println("Setting up CORS...")
+func newFiberCors(app *fiber.App, config entities.ServerConfig) {
+	slog.Info("Setting up CORS...")
 
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: os.Getenv("ALLOW_ORIGINS"),
+		AllowOrigins: config.AllowOrigins,
 		AllowHeaders: "Origin, Content-Type, Accept",
 		AllowMethods: "GET, POST, HEAD, PUT, DELETE, PATCH",
+		ExposeHeaders:    strings.Join(append([]string{"X-Request-ID"}, middlewares.RateLimitHeaders...), ", "),
 		AllowCredentials: true,
 	}))
 }
-
-func newFiberLimiter(app *fiber.App) {
-	log.Println("Setting up Fiber limiter...")
-	app.Use("/api/v1/*", limiter.New(limiter.Config{
-		Max:        100,
-		Expiration: 60 * time.Second,
-		LimitReached: func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-				"message": "Too many requests",
-			})
-		},
-	}))
-}
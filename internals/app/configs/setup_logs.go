@@ -1,26 +1,61 @@
 package configs
 
 import (
-	"log"
+	"errors"
+	"io"
+	"log/slog"
 	"os"
-	"path/filepath"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/logging"
 )
 
-func InitLogs() {
-	// Define the log directory and file
-	logDir := "./logs"
-	logFile := filepath.Join(logDir, "app.log")
+// InitLogs sets the default logger, writing JSON records to the outputs of the
+// configuration. The standard logger, left to the fatal errors at startup,
+// writes through it at the error level. It returns the function closing the
+// log files, once the rest of the application is stopped.
+func InitLogs(config entities.LogsConfig) (func() error, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(config.Level)); err != nil {
+		return nil, err
+	}
 
-	// Create the log directory if it does not exist
-	if err := os.MkdirAll(logDir, os.ModePerm); err != nil {
-		log.Fatalf("failed to create log directory: %v", err)
+	var writers []io.Writer
+	var files []io.Closer
+	for _, output := range config.OutputList() {
+		switch output {
+		case entities.LogOutputStdout:
+			writers = append(writers, os.Stdout)
+		case entities.LogOutputStderr:
+			writers = append(writers, os.Stderr)
+		case entities.LogOutputFile:
+			file, err := logging.NewRotatingFile(
+				config.File,
+				int64(config.MaxSize)*1024*1024,
+				time.Duration(config.MaxAge)*24*time.Hour,
+				config.MaxBackups,
+			)
+			if err != nil {
+				return nil, err
+			}
+			writers = append(writers, file)
+			files = append(files, file)
+		}
 	}
 
+	handler := slog.NewJSONHandler(io.MultiWriter(writers...), &slog.HandlerOptions{
+		AddSource: true,
+		Level:     level,
+	})
+	slog.SetDefault(slog.New(logging.NewContextHandler(handler)))
+	slog.SetLogLoggerLevel(slog.LevelError)
 
-	file, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
-	if err != nil {
-		log.Fatalf("failed to open log file: %v", err)
-	}
-	log.SetOutput(file)
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	return func() error {
+		var errs []error
+		for _, file := range files {
+			errs = append(errs, file.Close())
+		}
+		return errors.Join(errs...)
+	}, nil
 }
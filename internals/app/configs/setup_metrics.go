@@ -0,0 +1,59 @@
+package configs
+
+import (
+	"context"
+	"log/slog"
+
+	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/metrics"
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+// newMetricsRegistry creates the registry of the metrics of the sessions, the
+// logins and the database pool. The HTTP metrics are added by the middleware.
+func newMetricsRegistry(db *gorm.DB, cache *redis.Client, events ports.EventBus, config *entities.Config) *metrics.Registry {
+	slog.Info("Setting up metrics...")
+	registry := metrics.NewRegistry("gym")
+
+	// Sessions
+	userServices := services.NewUserServices(db, secondary.NewCacheServices(cache), events, config.System)
+	registry.GaugeFunc("active_sessions", "Number of sessions not expired.", func() (float64, error) {
+		count, err := userServices.CountSessions(context.Background())
+		return float64(count), err
+	})
+
+	// Logins
+	loginFailures := registry.Counter("login_failures_total", "Number of logins with a wrong email or password.")
+	events.Subscribe(entities.EventUserLoginFailed, func(ctx context.Context, event entities.Event) error {
+		loginFailures.Inc()
+		return nil
+	})
+
+	// Database pool
+	sqlDB, err := db.DB()
+	if err != nil {
+		slog.Warn("Database pool not available for the metrics", "error", err)
+		return registry
+	}
+	registry.GaugeFunc("db_open_connections", "Number of open connections to the database.", func() (float64, error) {
+		return float64(sqlDB.Stats().OpenConnections), nil
+	})
+	registry.GaugeFunc("db_in_use_connections", "Number of connections to the database in use.", func() (float64, error) {
+		return float64(sqlDB.Stats().InUse), nil
+	})
+	registry.GaugeFunc("db_idle_connections", "Number of idle connections to the database.", func() (float64, error) {
+		return float64(sqlDB.Stats().Idle), nil
+	})
+	registry.GaugeFunc("db_wait_count", "Number of connections waited for.", func() (float64, error) {
+		return float64(sqlDB.Stats().WaitCount), nil
+	})
+	registry.GaugeFunc("db_wait_duration_seconds", "Time spent waiting for a connection.", func() (float64, error) {
+		return sqlDB.Stats().WaitDuration.Seconds(), nil
+	})
+
+	return registry
+}
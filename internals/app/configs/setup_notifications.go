@@ -0,0 +1,35 @@
+package configs
+
+import (
+	"log/slog"
+
+	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+)
+
+// newNotificationChannels creates the channels enabled in the configuration.
+func newNotificationChannels(config entities.NotificationsConfig) []ports.NotificationChannel {
+	var channels []ports.NotificationChannel
+
+	if smtp := config.SMTP; smtp.Host != "" {
+		channels = append(channels, secondary.NewEmailChannel(smtp.Host, smtp.Port, smtp.User, smtp.Password, smtp.From))
+	}
+
+	if sms := config.SMS; sms.GatewayURL != "" {
+		channels = append(channels, secondary.NewSMSChannel(sms.GatewayURL, sms.GatewayToken, sms.Sender))
+	}
+
+	if config.WebhookURL != "" {
+		channels = append(channels, secondary.NewWebhookChannel(config.WebhookURL))
+	}
+
+	if config.File != "" {
+		channels = append(channels, secondary.NewFileChannel(config.File))
+	}
+
+	for _, channel := range channels {
+		slog.Info("Notification channel enabled", "channel", channel.Name())
+	}
+	return channels
+}
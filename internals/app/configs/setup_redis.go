@@ -3,19 +3,20 @@ package configs
 import (
 	"context"
 	"fmt"
-	"log"
-	"os"
+	"log/slog"
 
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
 	"github.com/go-redis/redis/v8"
 )
 
-func InitializeRedisClient() (*redis.Client, error) {
-	log.Println("Setting up Redis client...")
+func InitializeRedisClient(config entities.RedisConfig) (*redis.Client, error) {
+	slog.Info("Setting up Redis client...")
 	// Create a new Redis client with the specified options
 	client := redis.NewClient(&redis.Options{
-		Addr:     os.Getenv("REDIS_HOST") + ":" + os.Getenv("REDIS_PORT"),
-		Password: os.Getenv("REDIS_PWD"), 
-		DB:       0,
+		Addr:     config.Host + ":" + config.Port,
+		Password: config.Password, 
+		DB:       config.DB,
 	})
 
 	// Ping the Redis server to check if the connection is successful
@@ -26,6 +27,9 @@ func InitializeRedisClient() (*redis.Client, error) {
 		return nil, fmt.Errorf("failed to connect to Redis: %v", err)
 	}
 
+	// Commands traced as children of the context given to the client
+	client.AddHook(tracing.NewRedisHook())
+
 	// Return the initialized client and nil error if connection is successful
 	return client, nil
 }
\ No newline at end of file
@@ -0,0 +1,80 @@
+package configs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/scheduler"
+	"gorm.io/gorm"
+)
+
+const (
+	// Auto-renew subscriptions ending in the next days
+	autoRenewBefore = 3 * 24 * time.Hour
+	// How often subscriptions are checked
+	subscriptionsInterval = time.Hour
+	// How often the deleted records older than the retention are purged
+	purgeInterval = 24 * time.Hour
+	// How often the notification rules are checked
+	notificationsInterval = time.Hour
+	// How often the pending webhook deliveries are sent
+	webhookDeliveriesInterval = 15 * time.Second
+)
+
+func newScheduler(db *gorm.DB, events ports.EventBus, config *entities.Config) *scheduler.Scheduler {
+	slog.Info("Setting up scheduler...")
+	s := scheduler.NewScheduler()
+
+	memberServices := services.NewMemberServices(db, events)
+	notificationServices := services.NewNotificationServices(db, services.NewPrivacyServices(db))
+	webhookServices := services.NewWebhookServices(db, secondary.NewWebhookClient())
+
+	s.Every("subscriptions-auto-renew", subscriptionsInterval, func(ctx context.Context) error {
+		renewed, err := memberServices.ProcessAutoRenewals(ctx, time.Now().Add(autoRenewBefore))
+		if len(renewed) > 0 {
+			slog.InfoContext(ctx, "Subscriptions renewed", "count", len(renewed))
+		}
+		return err
+	})
+
+	s.Every("subscriptions-status", subscriptionsInterval, func(ctx context.Context) error {
+		_, err := memberServices.RefreshSubscriptionsStatus(ctx, time.Now())
+		return err
+	})
+
+	s.Every("webhook-deliveries", webhookDeliveriesInterval, func(ctx context.Context) error {
+		delivered, err := webhookServices.ProcessDeliveries(ctx, time.Now())
+		if delivered > 0 {
+			slog.InfoContext(ctx, "Webhook events delivered", "count", delivered)
+		}
+		return err
+	})
+
+	if retention := time.Duration(config.Scheduler.TrashRetentionDays) * 24 * time.Hour; retention > 0 {
+		s.Every("trash-purge", purgeInterval, func(ctx context.Context) error {
+			purged, err := memberServices.PurgeDeleted(ctx, time.Now().Add(-retention))
+			if purged > 0 {
+				slog.InfoContext(ctx, "Deleted records purged", "count", purged)
+			}
+			return err
+		})
+	}
+
+	if channels := newNotificationChannels(config.Notifications); len(channels) > 0 {
+		rules := entities.DefaultNotificationRules(config.Notifications.ExpiringDays, config.Notifications.ExpiredDays)
+		s.Every("notifications", notificationsInterval, func(ctx context.Context) error {
+			sent, err := notificationServices.SendNotifications(ctx, rules, channels, time.Now())
+			if sent > 0 {
+				slog.InfoContext(ctx, "Notifications sent", "count", sent)
+			}
+			return err
+		})
+	}
+
+	return s
+}
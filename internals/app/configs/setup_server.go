@@ -1,32 +1,119 @@
 package configs
 
 import (
+	"context"
 	"log"
-	"os"
+	"log/slog"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/lifecycle"
 	"github.com/Erodot0/gym-memeber-management/internals/routes"
 	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
-// Initialize sets up and starts the Fiber server.
-func Initialize(db *gorm.DB, redis *redis.Client) {
-	log.Println("Setting up server...")
-	app := setupFiberApp()
+// Initialize sets up the Fiber server and runs it, with the scheduler, until
+// SIGINT or SIGTERM. The server drains the requests in flight, then the admin
+// server, the scheduler, the cache and the database are stopped in this order,
+// the remaining spans are exported and the log files closed.
+func Initialize(config *entities.Config, db *gorm.DB, redis *redis.Client, closeLogs func() error) {
+	slog.Info("Setting up server...")
+	shutdownTracing, err := InitializeTracing(config.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
 
-	newFiberCors(app)
-	newFiberLimiter(app)
+	events := InitializeEventBus(db, redis)
+	registry := newMetricsRegistry(db, redis, events, config)
 
-	routes := routes.NewRoutes(app, db, redis)
+	app := setupFiberApp(registry)
 
-	routes.RegisterMemberRoutes()
-	routes.RegisterUserRoutes()
-	routes.RegisterRolesRoutes()
-	routes.RegisterPermissionsRoutes()
+	newFiberCors(app, config.Server)
 
-	if err := app.Listen(":" + os.Getenv("SERVER_PORT")); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
-	}
+	routes := routes.NewRoutes(app, db, redis, events, config, registry)
+
+	routes.RegisterRoutes()
+
+	// Health checks and metrics, on their own address
+	admin := fiber.New(fiber.Config{DisableStartupMessage: true})
+	routes.RegisterAdminRoutes(admin)
+
+	app.Hooks().OnListen(func(fiber.ListenData) error {
+		slog.Info("Server started", "port", config.Server.Port, "admin_address", config.Server.AdminAddress)
+		return nil
+	})
 
-	log.Printf("Server started on port %s", os.Getenv("SERVER_PORT"))
+	scheduler := newScheduler(db, events, config)
+
+	// Stopped in the reverse order
+	manager := lifecycle.NewLifecycle(time.Duration(config.Server.ShutdownTimeout) * time.Second)
+	manager.Append(lifecycle.Component{
+		Name: "logs",
+		Stop: func(ctx context.Context) error {
+			return closeLogs()
+		},
+	})
+	manager.Append(lifecycle.Component{
+		Name: "tracing",
+		Stop: shutdownTracing,
+	})
+	manager.Append(lifecycle.Component{
+		Name: "database",
+		Stop: func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.Close()
+		},
+	})
+	manager.Append(lifecycle.Component{
+		Name: "cache",
+		Stop: func(ctx context.Context) error {
+			return redis.Close()
+		},
+	})
+	manager.Append(lifecycle.Component{
+		Name: "scheduler",
+		Start: func() error {
+			scheduler.Start()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			scheduler.Stop()
+			return nil
+		},
+	})
+	manager.Append(lifecycle.Component{
+		Name: "admin server",
+		Start: func() error {
+			return admin.Listen(config.Server.AdminAddress)
+		},
+		Stop: admin.ShutdownWithContext,
+	})
+	manager.Append(lifecycle.Component{
+		Name: "server",
+		Start: func() error {
+			return app.Listen(":" + config.Server.Port)
+		},
+		Stop: app.ShutdownWithContext,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// A second signal kills the server without waiting
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if err := manager.Run(ctx); err != nil {
+		log.Fatalf("Server stopped with errors: %v", err)
+	}
+	slog.Info("Server stopped")
 }
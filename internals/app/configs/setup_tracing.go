@@ -0,0 +1,58 @@
+package configs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// InitializeTracing sets the global tracer provider, exporting the spans to
+// the exporter of the configuration, and the W3C trace context propagator.
+// Without exporter the spans are dropped, the incoming trace context is still
+// passed on.
+//
+// Returns the function flushing the spans and stopping the provider.
+func InitializeTracing(config entities.TracingConfig) (func(ctx context.Context) error, error) {
+	slog.Info("Setting up tracing...", "exporter", config.Exporter)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch config.Exporter {
+	case entities.TracingExporterStdout:
+		exporter, err = stdouttrace.New()
+	case entities.TracingExporterOTLP:
+		exporter, err = otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(config.Endpoint))
+	default:
+		return func(context.Context) error { return nil }, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the %s exporter: %w", config.Exporter, err)
+	}
+
+	res, err := resource.New(context.Background(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(config.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the tracing resource: %w", err)
+	}
+
+	// The traces started by a caller keep its sampling decision
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(config.SampleRate)/100))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+)
+
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+// Audit records a change made to the data and who made it.
+type Audit struct {
+	ID        uint                   `json:"ID" gorm:"primaryKey;autoIncrement;unique;not null"`
+	CreatedAt time.Time              `json:"created_at" gorm:"index"`
+	UserID    uint                   `json:"user_id" gorm:"index"`
+	IPAddress string                 `json:"ip_address"`
+	Action    string                 `json:"action" gorm:"not null;index"`
+	Entity    string                 `json:"entity" gorm:"not null;index"`
+	EntityID  uint                   `json:"entity_id" gorm:"index"`
+	Changes   map[string]AuditChange `json:"changes" gorm:"serializer:json"`
+}
+
+// AuditChange is the value of a field before and after a change.
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditFilter filters the audit logs, zero values are ignored.
+type AuditFilter struct {
+	UserID   uint
+	Action   string
+	Entity   string
+	EntityID uint
+	From     time.Time
+	To       time.Time
+	Limit    int
+	Offset   int
+}
+
+// AuditLogs is a page of audit logs and the number of logs matching the filter.
+type AuditLogs struct {
+	Total int64   `json:"total"`
+	Logs  []Audit `json:"logs"`
+}
@@ -0,0 +1,397 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config is the configuration of the application. Every field can be set in
+// the configuration file, by its yaml key, or in the environment, by its env
+// key, the environment taking precedence.
+type Config struct {
+	Server        ServerConfig        `yaml:"server"`
+	Database      DatabaseConfig      `yaml:"database"`
+	Redis         RedisConfig         `yaml:"redis"`
+	System        SystemConfig        `yaml:"system"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Scheduler     SchedulerConfig     `yaml:"scheduler"`
+	Logs          LogsConfig          `yaml:"logs"`
+	Tracing       TracingConfig       `yaml:"tracing"`
+	RateLimit     RateLimitConfig     `yaml:"rate_limit"`
+}
+
+type ServerConfig struct {
+	Port            string `yaml:"port" env:"SERVER_PORT"`
+	AllowOrigins    string `yaml:"allow_origins" env:"ALLOW_ORIGINS"`       // Comma separated
+	ShutdownTimeout int    `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"` // Seconds given to the requests and the jobs to end
+	AdminAddress    string `yaml:"admin_address" env:"ADMIN_ADDRESS"`       // Health checks and metrics, kept off the public port
+}
+
+type DatabaseConfig struct {
+	Path string `yaml:"path" env:"DB_PATH"` // SQLite file
+}
+
+type RedisConfig struct {
+	Host     string `yaml:"host" env:"REDIS_HOST"`
+	Port     string `yaml:"port" env:"REDIS_PORT"`
+	Password string `yaml:"password" env:"REDIS_PWD"`
+	DB       int    `yaml:"db" env:"REDIS_DB"`
+}
+
+// SystemConfig is the user, and its role, created at startup with every permission.
+type SystemConfig struct {
+	UserEmail    string `yaml:"user_email" env:"SYS_USER_EMAIL"`
+	UserPassword string `yaml:"user_password" env:"SYS_USER_PWD"`
+	RoleName     string `yaml:"role_name" env:"SYS_ROLE_NAME"`
+}
+
+// NotificationsConfig enables the channels with an host, URL or path set.
+type NotificationsConfig struct {
+	SMTP         SMTPConfig `yaml:"smtp"`
+	SMS          SMSConfig  `yaml:"sms"`
+	WebhookURL   string     `yaml:"webhook_url" env:"NOTIFICATION_WEBHOOK_URL"`
+	File         string     `yaml:"file" env:"NOTIFICATION_FILE"`
+	ExpiringDays int        `yaml:"expiring_days" env:"NOTIFY_EXPIRING_DAYS"` // Reminder sent before the end of the subscription
+	ExpiredDays  int        `yaml:"expired_days" env:"NOTIFY_EXPIRED_DAYS"`   // Notice sent after the end of the subscription
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     string `yaml:"port" env:"SMTP_PORT"`
+	User     string `yaml:"user" env:"SMTP_USER"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+	From     string `yaml:"from" env:"SMTP_FROM"`
+}
+
+type SMSConfig struct {
+	GatewayURL   string `yaml:"gateway_url" env:"SMS_GATEWAY_URL"`
+	GatewayToken string `yaml:"gateway_token" env:"SMS_GATEWAY_TOKEN"`
+	Sender       string `yaml:"sender" env:"SMS_SENDER"`
+}
+
+type SchedulerConfig struct {
+	TrashRetentionDays int `yaml:"trash_retention_days" env:"TRASH_RETENTION_DAYS"` // 0 keeps the deleted records forever
+}
+
+// Destinations of the logs
+const (
+	LogOutputStdout = "stdout"
+	LogOutputStderr = "stderr"
+	LogOutputFile   = "file"
+)
+
+type LogsConfig struct {
+	Level      string `yaml:"level" env:"LOG_LEVEL"`             // debug, info, warn or error
+	Outputs    string `yaml:"outputs" env:"LOG_OUTPUTS"`         // Comma separated: stdout, stderr or file
+	File       string `yaml:"file" env:"LOG_FILE"`               // Used by the file output
+	MaxSize    int    `yaml:"max_size" env:"LOG_MAX_SIZE"`       // Megabytes after which the file is rotated
+	MaxAge     int    `yaml:"max_age" env:"LOG_MAX_AGE"`         // Days the rotated files are kept, 0 keeps them forever
+	MaxBackups int    `yaml:"max_backups" env:"LOG_MAX_BACKUPS"` // Rotated files kept, 0 keeps them all
+}
+
+// OutputList returns the outputs of the logs, without spaces.
+func (l LogsConfig) OutputList() []string {
+	var outputs []string
+	for _, output := range strings.Split(l.Outputs, ",") {
+		if output = strings.TrimSpace(output); output != "" {
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs
+}
+
+// Exporters of the traces
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+type TracingConfig struct {
+	Exporter    string `yaml:"exporter" env:"TRACING_EXPORTER"`         // none, stdout or otlp
+	Endpoint    string `yaml:"endpoint" env:"TRACING_ENDPOINT"`         // OTLP over HTTP, with the path of the traces
+	ServiceName string `yaml:"service_name" env:"TRACING_SERVICE_NAME"` // Name of the service in the traces
+	SampleRate  int    `yaml:"sample_rate" env:"TRACING_SAMPLE_RATE"`   // Percentage of the new traces recorded
+}
+
+// RateLimitConfig is the number of requests allowed to each client in a
+// window of seconds, by route group. A max of 0 disables the limit of the group.
+type RateLimitConfig struct {
+	LoginMax      int `yaml:"login_max" env:"RATE_LIMIT_LOGIN_MAX"`             // Login attempts of each email from an IP address
+	LoginWindow   int `yaml:"login_window" env:"RATE_LIMIT_LOGIN_WINDOW"`       // Seconds
+	LoginIPMax    int `yaml:"login_ip_max" env:"RATE_LIMIT_LOGIN_IP_MAX"`       // Login attempts of each IP address, whatever the email
+	LoginIPWindow int `yaml:"login_ip_window" env:"RATE_LIMIT_LOGIN_IP_WINDOW"` // Seconds
+	PublicMax     int `yaml:"public_max" env:"RATE_LIMIT_PUBLIC_MAX"`           // Requests without session of each IP address
+	PublicWindow  int `yaml:"public_window" env:"RATE_LIMIT_PUBLIC_WINDOW"`     // Seconds
+	SessionMax    int `yaml:"session_max" env:"RATE_LIMIT_SESSION_MAX"`         // Requests of each IP address checked for a session, valid or not
+	SessionWindow int `yaml:"session_window" env:"RATE_LIMIT_SESSION_WINDOW"`   // Seconds
+	ReadMax       int `yaml:"read_max" env:"RATE_LIMIT_READ_MAX"`               // GET requests of each user
+	ReadWindow    int `yaml:"read_window" env:"RATE_LIMIT_READ_WINDOW"`         // Seconds
+	WriteMax      int `yaml:"write_max" env:"RATE_LIMIT_WRITE_MAX"`             // Other requests of each user
+	WriteWindow   int `yaml:"write_window" env:"RATE_LIMIT_WRITE_WINDOW"`       // Seconds
+}
+
+// RateLimitPolicy is the limit of the requests of a route group.
+type RateLimitPolicy struct {
+	Name   string
+	Max    int
+	Window time.Duration
+}
+
+// CacheKey returns the key of the counter of the client, e.g. its IP address
+// or its user.
+func (p RateLimitPolicy) CacheKey(client string) string {
+	return fmt.Sprintf("rate_limit:%s:%s", p.Name, client)
+}
+
+// Login returns the policy of the login attempts of an email from an IP address.
+func (r RateLimitConfig) Login() RateLimitPolicy {
+	return RateLimitPolicy{Name: "login", Max: r.LoginMax, Window: time.Duration(r.LoginWindow) * time.Second}
+}
+
+// LoginIP returns the policy of the login attempts of an IP address.
+func (r RateLimitConfig) LoginIP() RateLimitPolicy {
+	return RateLimitPolicy{Name: "login_ip", Max: r.LoginIPMax, Window: time.Duration(r.LoginIPWindow) * time.Second}
+}
+
+// Public returns the policy of the requests without session.
+func (r RateLimitConfig) Public() RateLimitPolicy {
+	return RateLimitPolicy{Name: "public", Max: r.PublicMax, Window: time.Duration(r.PublicWindow) * time.Second}
+}
+
+// Session returns the policy of the requests checked for a session.
+func (r RateLimitConfig) Session() RateLimitPolicy {
+	return RateLimitPolicy{Name: "session", Max: r.SessionMax, Window: time.Duration(r.SessionWindow) * time.Second}
+}
+
+// Read returns the policy of the GET requests of the users.
+func (r RateLimitConfig) Read() RateLimitPolicy {
+	return RateLimitPolicy{Name: "read", Max: r.ReadMax, Window: time.Duration(r.ReadWindow) * time.Second}
+}
+
+// Write returns the policy of the other requests of the users.
+func (r RateLimitConfig) Write() RateLimitPolicy {
+	return RateLimitPolicy{Name: "write", Max: r.WriteMax, Window: time.Duration(r.WriteWindow) * time.Second}
+}
+
+// DefaultConfig returns the configuration used for the values not set.
+func DefaultConfig() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:            "3000",
+			ShutdownTimeout: 15,
+			AdminAddress:    "127.0.0.1:9100",
+		},
+		Database: DatabaseConfig{
+			Path: "test.db",
+		},
+		Redis: RedisConfig{
+			Host: "localhost",
+			Port: "6379",
+		},
+		Notifications: NotificationsConfig{
+			SMTP:         SMTPConfig{Port: "587"},
+			ExpiringDays: 7,
+			ExpiredDays:  1,
+		},
+		Scheduler: SchedulerConfig{
+			TrashRetentionDays: 30,
+		},
+		Logs: LogsConfig{
+			Level:      "info",
+			Outputs:    LogOutputFile,
+			File:       "./logs/app.log",
+			MaxSize:    100,
+			MaxAge:     30,
+			MaxBackups: 10,
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			Endpoint:    "http://localhost:4318/v1/traces",
+			ServiceName: "gym-member-management",
+			SampleRate:  100,
+		},
+		RateLimit: RateLimitConfig{
+			LoginMax:      5,
+			LoginWindow:   60,
+			LoginIPMax:    50,
+			LoginIPWindow: 60,
+			PublicMax:     100,
+			PublicWindow:  60,
+			SessionMax:    1200,
+			SessionWindow: 60,
+			ReadMax:       600,
+			ReadWindow:    60,
+			WriteMax:      120,
+			WriteWindow:   60,
+		},
+	}
+}
+
+// Validate returns every invalid value of the configuration, named by its env key.
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(key string, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	// Server
+	if !isPort(c.Server.Port) {
+		invalid("SERVER_PORT", "must be a port between 1 and 65535, got %q", c.Server.Port)
+	}
+	if c.Server.AllowOrigins == "" {
+		invalid("ALLOW_ORIGINS", "is required")
+	} else if c.Server.AllowOrigins == "*" {
+		// The session cookie is sent to the allowed origins only
+		invalid("ALLOW_ORIGINS", "must list the origins, * is not allowed with credentials")
+	}
+	if _, port, err := net.SplitHostPort(c.Server.AdminAddress); err != nil || !isPort(port) {
+		invalid("ADMIN_ADDRESS", "must be a host and a port, e.g. 127.0.0.1:9100, got %q", c.Server.AdminAddress)
+	} else if port == c.Server.Port {
+		invalid("ADMIN_ADDRESS", "must not use SERVER_PORT %s", port)
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		invalid("SHUTDOWN_TIMEOUT", "must be a positive number of seconds, got %d", c.Server.ShutdownTimeout)
+	}
+
+	// Database
+	if c.Database.Path == "" {
+		invalid("DB_PATH", "is required")
+	}
+
+	// Redis
+	if c.Redis.Host == "" {
+		invalid("REDIS_HOST", "is required")
+	}
+	if !isPort(c.Redis.Port) {
+		invalid("REDIS_PORT", "must be a port between 1 and 65535, got %q", c.Redis.Port)
+	}
+	if c.Redis.DB < 0 {
+		invalid("REDIS_DB", "must not be negative, got %d", c.Redis.DB)
+	}
+
+	// System user
+	if _, err := mail.ParseAddress(c.System.UserEmail); err != nil {
+		invalid("SYS_USER_EMAIL", "must be an email address, got %q", c.System.UserEmail)
+	}
+	if c.System.UserPassword == "" {
+		invalid("SYS_USER_PWD", "is required")
+	}
+	if c.System.RoleName == "" {
+		invalid("SYS_ROLE_NAME", "is required")
+	}
+
+	// Notifications
+	if smtp := c.Notifications.SMTP; smtp.Host != "" {
+		if !isPort(smtp.Port) {
+			invalid("SMTP_PORT", "must be a port between 1 and 65535, got %q", smtp.Port)
+		}
+		if _, err := mail.ParseAddress(smtp.From); err != nil {
+			invalid("SMTP_FROM", "must be an email address when SMTP_HOST is set, got %q", smtp.From)
+		}
+	}
+	if c.Notifications.SMS.GatewayURL != "" && !isURL(c.Notifications.SMS.GatewayURL) {
+		invalid("SMS_GATEWAY_URL", "must be an http or https URL, got %q", c.Notifications.SMS.GatewayURL)
+	}
+	if c.Notifications.WebhookURL != "" && !isURL(c.Notifications.WebhookURL) {
+		invalid("NOTIFICATION_WEBHOOK_URL", "must be an http or https URL, got %q", c.Notifications.WebhookURL)
+	}
+	if c.Notifications.ExpiringDays < 0 {
+		invalid("NOTIFY_EXPIRING_DAYS", "must be a positive number of days, got %d", c.Notifications.ExpiringDays)
+	}
+	if c.Notifications.ExpiredDays < 0 {
+		invalid("NOTIFY_EXPIRED_DAYS", "must be a positive number of days, got %d", c.Notifications.ExpiredDays)
+	}
+
+	// Scheduler
+	if c.Scheduler.TrashRetentionDays < 0 {
+		invalid("TRASH_RETENTION_DAYS", "must be a positive number of days, got %d", c.Scheduler.TrashRetentionDays)
+	}
+
+	// Logs
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Logs.Level)); err != nil {
+		invalid("LOG_LEVEL", "must be debug, info, warn or error, got %q", c.Logs.Level)
+	}
+	outputs := c.Logs.OutputList()
+	if len(outputs) == 0 {
+		invalid("LOG_OUTPUTS", "is required")
+	}
+	for _, output := range outputs {
+		switch output {
+		case LogOutputStdout, LogOutputStderr:
+		case LogOutputFile:
+			if c.Logs.File == "" {
+				invalid("LOG_FILE", "is required with the file output")
+			}
+		default:
+			invalid("LOG_OUTPUTS", "must be stdout, stderr or file, got %q", output)
+		}
+	}
+	if c.Logs.MaxSize <= 0 {
+		invalid("LOG_MAX_SIZE", "must be a positive number of megabytes, got %d", c.Logs.MaxSize)
+	}
+	if c.Logs.MaxAge < 0 {
+		invalid("LOG_MAX_AGE", "must be a positive number of days, got %d", c.Logs.MaxAge)
+	}
+	if c.Logs.MaxBackups < 0 {
+		invalid("LOG_MAX_BACKUPS", "must not be negative, got %d", c.Logs.MaxBackups)
+	}
+
+	// Tracing
+	switch c.Tracing.Exporter {
+	case TracingExporterNone, TracingExporterStdout:
+	case TracingExporterOTLP:
+		if !isURL(c.Tracing.Endpoint) {
+			invalid("TRACING_ENDPOINT", "must be an http or https URL with the otlp exporter, got %q", c.Tracing.Endpoint)
+		}
+	default:
+		invalid("TRACING_EXPORTER", "must be none, stdout or otlp, got %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.ServiceName == "" {
+		invalid("TRACING_SERVICE_NAME", "is required")
+	}
+	if c.Tracing.SampleRate < 0 || c.Tracing.SampleRate > 100 {
+		invalid("TRACING_SAMPLE_RATE", "must be a percentage between 0 and 100, got %d", c.Tracing.SampleRate)
+	}
+
+	// Rate limit
+	for _, limit := range []struct {
+		key    string
+		max    int
+		window int
+	}{
+		{"RATE_LIMIT_LOGIN", c.RateLimit.LoginMax, c.RateLimit.LoginWindow},
+		{"RATE_LIMIT_LOGIN_IP", c.RateLimit.LoginIPMax, c.RateLimit.LoginIPWindow},
+		{"RATE_LIMIT_PUBLIC", c.RateLimit.PublicMax, c.RateLimit.PublicWindow},
+		{"RATE_LIMIT_SESSION", c.RateLimit.SessionMax, c.RateLimit.SessionWindow},
+		{"RATE_LIMIT_READ", c.RateLimit.ReadMax, c.RateLimit.ReadWindow},
+		{"RATE_LIMIT_WRITE", c.RateLimit.WriteMax, c.RateLimit.WriteWindow},
+	} {
+		if limit.max < 0 {
+			invalid(limit.key+"_MAX", "must not be negative, got %d", limit.max)
+		}
+		if limit.window <= 0 {
+			invalid(limit.key+"_WINDOW", "must be a positive number of seconds, got %d", limit.window)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func isPort(value string) bool {
+	port, err := strconv.Atoi(value)
+	return err == nil && port > 0 && port <= 65535
+}
+
+func isURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"errors"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+)
+
+// Kinds of the domain errors, each is returned to the client with its own status
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrValidation = errors.New("validation")
+	ErrForbidden  = errors.New("forbidden")
+)
+
+// Codes of the errors in the responses, stable across languages
+const (
+	ErrorCodeBadRequest       = "bad_request"
+	ErrorCodeValidation       = "validation_failed"
+	ErrorCodeUnauthorized     = "unauthorized"
+	ErrorCodeForbidden        = "forbidden"
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeMethodNotAllowed = "method_not_allowed"
+	ErrorCodeConflict         = "conflict"
+	ErrorCodeTooManyRequests  = "too_many_requests"
+	ErrorCodeInternal         = "internal_error"
+)
+
+// DomainError is an error of the domain rules. Its message is an i18n code
+// shown to the client, while the cause is only logged.
+type DomainError struct {
+	Kind    error
+	Message string
+	Args    []interface{}
+	Err     error
+}
+
+// NewNotFoundError returns an error of a missing resource, with the message of the given i18n code.
+func NewNotFoundError(message string, args ...interface{}) *DomainError {
+	return &DomainError{Kind: ErrNotFound, Message: message, Args: args}
+}
+
+// NewConflictError returns an error of a change clashing with the current state, e.g. a consent already revoked.
+func NewConflictError(message string, args ...interface{}) *DomainError {
+	return &DomainError{Kind: ErrConflict, Message: message, Args: args}
+}
+
+// NewValidationError returns an error of a request breaking a domain rule,
+// ValidationErrors are used instead when the rule concerns specific fields.
+func NewValidationError(message string, args ...interface{}) *DomainError {
+	return &DomainError{Kind: ErrValidation, Message: message, Args: args}
+}
+
+// NewForbiddenError returns an error of an action the user is not allowed to do.
+func NewForbiddenError(message string, args ...interface{}) *DomainError {
+	return &DomainError{Kind: ErrForbidden, Message: message, Args: args}
+}
+
+// Wrap sets the cause of the error.
+func (e *DomainError) Wrap(err error) *DomainError {
+	e.Err = err
+	return e
+}
+
+// Error returns the message in the default language, followed by the cause.
+func (e *DomainError) Error() string {
+	message := i18n.Translate(i18n.DefaultLanguage, e.Message, e.Args...)
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap allows errors.Is to match both the kind and the cause.
+func (e *DomainError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+// Translate returns the message in the given language, without the cause.
+func (e *DomainError) Translate(lang string) string {
+	return i18n.Translate(lang, e.Message, e.Args...)
+}
@@ -0,0 +1,103 @@
+package entities
+
+// Names of the domain events
+const (
+	EventMemberCreated       = "member.created"
+	EventMemberDeleted       = "member.deleted"
+	EventSubscriptionCreated = "subscription.created"
+	EventSubscriptionExpired = "subscription.expired"
+	EventUserCreated         = "user.created"
+	EventUserDeleted         = "user.deleted"
+	EventUserLogin           = "user.login"
+	EventUserLoginFailed     = "user.login_failed"
+	EventRoleCreated         = "role.created"
+	EventRoleUpdated         = "role.updated"
+	EventRoleDeleted         = "role.deleted"
+	EventPermissionsChanged  = "permissions.changed"
+)
+
+// Event is a change of the domain, published once it is committed.
+type Event interface {
+	EventName() string
+}
+
+// MemberEvent is an event holding the personal data of a member, that is
+// erased with the member.
+type MemberEvent interface {
+	Event
+	EventMemberID() uint
+}
+
+type MemberCreated struct {
+	Member *Member `json:"member"`
+}
+
+type MemberDeleted struct {
+	MemberID uint `json:"member_id"`
+}
+
+type SubscriptionCreated struct {
+	Subscription *Subscription `json:"subscription"`
+	// AutoRenewal is true when the subscription was renewed by the scheduler
+	AutoRenewal bool `json:"auto_renewal"`
+}
+
+type SubscriptionExpired struct {
+	Subscription *Subscription `json:"subscription"`
+}
+
+type UserCreated struct {
+	UserID uint `json:"user_id"`
+	RoleID uint `json:"role_id"`
+}
+
+type UserDeleted struct {
+	UserID uint `json:"user_id"`
+}
+
+type UserLoggedIn struct {
+	UserID    uint   `json:"user_id"`
+	Email     string `json:"email"`
+	IPAddress string `json:"ip_address"`
+}
+
+// UserLoginFailed is published when the email or the password of a login is wrong.
+type UserLoginFailed struct {
+	Email     string `json:"email"`
+	IPAddress string `json:"ip_address"`
+}
+
+type RoleCreated struct {
+	Role *Roles `json:"role"`
+}
+
+type RoleUpdated struct {
+	RoleID uint `json:"role_id"`
+}
+
+type RoleDeleted struct {
+	RoleID uint `json:"role_id"`
+}
+
+// PermissionsChanged is published when a permission of a role is created, updated or deleted.
+type PermissionsChanged struct {
+	PermissionID uint `json:"permission_id"`
+}
+
+func (e *MemberCreated) EventName() string       { return EventMemberCreated }
+func (e *MemberDeleted) EventName() string       { return EventMemberDeleted }
+func (e *SubscriptionCreated) EventName() string { return EventSubscriptionCreated }
+func (e *SubscriptionExpired) EventName() string { return EventSubscriptionExpired }
+func (e *UserCreated) EventName() string         { return EventUserCreated }
+func (e *UserDeleted) EventName() string         { return EventUserDeleted }
+func (e *UserLoggedIn) EventName() string        { return EventUserLogin }
+func (e *UserLoginFailed) EventName() string     { return EventUserLoginFailed }
+func (e *RoleCreated) EventName() string         { return EventRoleCreated }
+func (e *RoleUpdated) EventName() string         { return EventRoleUpdated }
+func (e *RoleDeleted) EventName() string         { return EventRoleDeleted }
+func (e *PermissionsChanged) EventName() string  { return EventPermissionsChanged }
+
+func (e *MemberCreated) EventMemberID() uint       { return e.Member.ID }
+func (e *MemberDeleted) EventMemberID() uint       { return e.MemberID }
+func (e *SubscriptionCreated) EventMemberID() uint { return e.Subscription.UserID }
+func (e *SubscriptionExpired) EventMemberID() uint { return e.Subscription.UserID }
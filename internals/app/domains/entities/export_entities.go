@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+)
+
+// Formats of the exported files
+const (
+	ExportCSV  = "csv"
+	ExportXLSX = "xlsx"
+	ExportPDF  = "pdf"
+)
+
+var exportFormats = []string{ExportCSV, ExportXLSX, ExportPDF}
+
+// MemberFilter filters the exported members, zero values are ignored.
+type MemberFilter struct {
+	Search           string // Part of the name or of the surname
+	Active           *bool  // With or without an active subscription
+	SubscriptionType string // With an active subscription of the type
+	HouseholdID      uint
+}
+
+// Revenue is the revenue of the subscriptions of a type started in a month.
+type Revenue struct {
+	Month         time.Time
+	Type          string
+	Subscriptions int
+	OriginalPrice float32
+	Discount      float32
+	Price         float32
+}
+
+func ValidateExportFormat(format string) error {
+	if !slices.Contains(exportFormats, format) {
+		return NewValidationError(i18n.MsgExportFileFormatInvalid)
+	}
+	return nil
+}
+
+// Add adds a subscription to the revenue.
+func (r *Revenue) Add(s *Subscription) {
+	original := s.OriginalPrice
+	if original == 0 {
+		original = s.Price
+	}
+
+	r.Subscriptions++
+	r.OriginalPrice += original
+	r.Discount += s.Discount
+	r.Price += s.Price
+}
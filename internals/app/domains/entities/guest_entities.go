@@ -0,0 +1,113 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+)
+
+const (
+	GuestPassDay   = "giornaliero" // single visit on a given day
+	GuestPassVisit = "carnet"      // limited visits in a period
+)
+
+// Guest is a visitor using the gym without being a member.
+type Guest struct {
+	gorm.Model
+	Name      string      `json:"name" gorm:"not null"`
+	Surname   string      `json:"surname" gorm:"not null"`
+	Phone     string      `json:"phone"`
+	Email     string      `json:"email"`
+	SponsorID *uint       `json:"sponsor_id" gorm:"index"` // Member who referred the guest
+	MemberID  *uint       `json:"member_id" gorm:"index"`  // Member created from the guest
+	Passes    []GuestPass `json:"passes,omitempty" gorm:"foreignKey:GuestID;constraint:OnDelete:CASCADE;"`
+}
+
+type UpdateGuest struct {
+	Name      string `json:"name"`
+	Surname   string `json:"surname"`
+	Phone     string `json:"phone"`
+	Email     string `json:"email"`
+	SponsorID *uint  `json:"sponsor_id"`
+}
+
+type GuestPass struct {
+	ID         uint `json:"ID" gorm:"primaryKey;autoIncrement;unique;not null"`
+	CreatedAt  time.Time
+	Deleted    gorm.DeletedAt
+	GuestID    uint      `json:"guest_id" gorm:"not null;index"`
+	Type       string    `json:"type" gorm:"not null"`
+	ValidFrom  time.Time `json:"valid_from" gorm:"not null"`
+	ValidUntil time.Time `json:"valid_until" gorm:"not null"`
+	MaxVisits  uint      `json:"max_visits" gorm:"not null"`
+	Visits     uint      `json:"visits" gorm:"default:0"`
+	Price      float32   `json:"price"`
+}
+
+func (g *Guest) Validate() error {
+	if g.Name == "" || g.Surname == "" {
+		return NewValidationError(i18n.MsgRequiredFields)
+	}
+
+	if g.Phone == "" && g.Email == "" {
+		return NewValidationError(i18n.MsgGuestContactRequired)
+	}
+
+	return nil
+}
+
+func (p *GuestPass) Validate() error {
+	if p.ValidFrom.IsZero() || p.Price < 0 {
+		return NewValidationError(i18n.MsgPassFieldsInvalid)
+	}
+
+	switch p.Type {
+	case GuestPassDay:
+		return nil
+	case GuestPassVisit:
+		if p.MaxVisits == 0 || p.ValidUntil.IsZero() {
+			return NewValidationError(i18n.MsgPassPunchCardRequired)
+		}
+		if p.ValidUntil.Before(p.ValidFrom) {
+			return NewValidationError(i18n.MsgPassExpiryBeforeStart)
+		}
+		return nil
+	default:
+		return NewValidationError(i18n.MsgPassTypeInvalid)
+	}
+}
+
+// AddValidity sets the validity of a day pass to the whole day of ValidFrom.
+func (p *GuestPass) AddValidity() {
+	if p.Type != GuestPassDay {
+		return
+	}
+
+	year, month, day := p.ValidFrom.Date()
+	p.ValidFrom = time.Date(year, month, day, 0, 0, 0, 0, p.ValidFrom.Location())
+	p.ValidUntil = p.ValidFrom.AddDate(0, 0, 1)
+	p.MaxVisits = 1
+}
+
+// CanVisit checks if the pass can be used at the given time.
+func (p *GuestPass) CanVisit(t time.Time) bool {
+	return !t.Before(p.ValidFrom) && t.Before(p.ValidUntil) && p.Visits < p.MaxVisits
+}
+
+// ToMember fills the missing data of a member with the data of the guest.
+func (g *Guest) ToMember(m *Member) {
+	if m.Name == "" {
+		m.Name = g.Name
+	}
+	if m.Surname == "" {
+		m.Surname = g.Surname
+	}
+	if m.Contacts == nil {
+		m.Contacts = &Contacts{
+			Phone: g.Phone,
+			Email: g.Email,
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package entities
+
+const (
+	HealthUp   = "up"
+	HealthDown = "down"
+)
+
+// Health is the status of the server and of the services it depends on.
+type Health struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks,omitempty"`
+}
+
+type HealthCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// AddCheck records the result of a check, a failed one sets the health down.
+func (h *Health) AddCheck(name string, err error) {
+	check := HealthCheck{Name: name, Status: HealthUp}
+	if err != nil {
+		check.Status = HealthDown
+		check.Error = err.Error()
+		h.Status = HealthDown
+	}
+	h.Checks = append(h.Checks, check)
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+)
+
+// Age of majority, younger members need a guardian consent
+const adultAge = 18
+
+type Household struct {
+	gorm.Model
+	Name            string   `json:"name" gorm:"not null"`
+	PayerID         uint     `json:"payer_id" gorm:"not null;index"`
+	DiscountPercent float32  `json:"discount_percent" gorm:"default:0"`
+	Members         []Member `json:"members,omitempty" gorm:"foreignKey:HouseholdID"`
+}
+
+type UpdateHousehold struct {
+	Name            string   `json:"name"`
+	PayerID         uint     `json:"payer_id"`
+	DiscountPercent *float32 `json:"discount_percent"`
+}
+
+// HouseholdMember is the request to add a member to a household.
+type HouseholdMember struct {
+	MemberID        uint  `json:"member_id"`
+	InheritContacts bool  `json:"inherit_contacts"`
+	InheritAddress  bool  `json:"inherit_address"`
+	GuardianID      *uint `json:"guardian_id"`
+	GuardianConsent bool  `json:"guardian_consent"`
+}
+
+func (h *Household) Validate() error {
+	if h.Name == "" || h.PayerID == 0 {
+		return NewValidationError(i18n.MsgRequiredFields)
+	}
+
+	if h.DiscountPercent < 0 || h.DiscountPercent > 100 {
+		return NewValidationError(i18n.MsgHouseholdDiscountInvalid)
+	}
+
+	return nil
+}
+
+func (h *UpdateHousehold) Validate() error {
+	if h.DiscountPercent != nil && (*h.DiscountPercent < 0 || *h.DiscountPercent > 100) {
+		return NewValidationError(i18n.MsgHouseholdDiscountInvalid)
+	}
+
+	return nil
+}
+
+func (h *HouseholdMember) Validate() error {
+	if h.MemberID == 0 {
+		return NewValidationError(i18n.MsgHouseholdMemberRequired)
+	}
+
+	return nil
+}
+
+// DiscountFor returns the family-plan discount on the given price.
+func (h *Household) DiscountFor(price float32) float32 {
+	return price * h.DiscountPercent / 100
+}
+
+// IsMinor checks if the member is younger than the age of majority.
+func (m *Member) IsMinor(now time.Time) bool {
+	return m.DateOfBirth.AddDate(adultAge, 0, 0).After(now)
+}
@@ -0,0 +1,248 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+)
+
+// Fields of a member that can be imported
+const (
+	ImportName              = "name"
+	ImportSurname           = "surname"
+	ImportGender            = "gender"
+	ImportDateOfBirth       = "date_of_birth"
+	ImportPhone             = "phone"
+	ImportEmail             = "email"
+	ImportCountry           = "country"
+	ImportCity              = "city"
+	ImportStreet            = "street"
+	ImportSubscriptionType  = "subscription_type"
+	ImportSubscriptionStart = "subscription_start"
+	ImportSubscriptionEnd   = "subscription_end"
+	ImportSubscriptionPrice = "subscription_price"
+)
+
+// ImportColumns maps the fields of a member to the column headers of the file.
+type ImportColumns map[string]string
+
+// DefaultImportColumns are the column headers of the import template.
+func DefaultImportColumns() ImportColumns {
+	return ImportColumns{
+		ImportName:              "nome",
+		ImportSurname:           "cognome",
+		ImportGender:            "sesso",
+		ImportDateOfBirth:       "data_nascita",
+		ImportPhone:             "telefono",
+		ImportEmail:             "email",
+		ImportCountry:           "paese",
+		ImportCity:              "citta",
+		ImportStreet:            "via",
+		ImportSubscriptionType:  "abbonamento",
+		ImportSubscriptionStart: "inizio_abbonamento",
+		ImportSubscriptionEnd:   "fine_abbonamento",
+		ImportSubscriptionPrice: "prezzo",
+	}
+}
+
+// Columns that may be missing from the file
+var optionalImportColumns = []string{ImportEmail, ImportSubscriptionEnd}
+
+// Date formats accepted in the file
+var importDateLayouts = []string{time.DateOnly, "02/01/2006", "2/1/2006", "02-01-2006", "02.01.2006"}
+
+// Imported fields of the member validation errors
+var importValidationFields = map[string]string{
+	"name":                       ImportName,
+	"surname":                    ImportSurname,
+	"gender":                     ImportGender,
+	"date_of_birth":              ImportDateOfBirth,
+	"contacts.phone":             ImportPhone,
+	"address.country":            ImportCountry,
+	"address.city":               ImportCity,
+	"address.street":             ImportStreet,
+	"subscription[0].type":       ImportSubscriptionType,
+	"subscription[0].start_date": ImportSubscriptionStart,
+	"subscription[0].end_date":   ImportSubscriptionEnd,
+	"subscription[0].price":      ImportSubscriptionPrice,
+}
+
+// ImportField returns the imported field of a member validation error.
+func ImportField(field string) string {
+	if imported, ok := importValidationFields[field]; ok {
+		return imported
+	}
+	return field
+}
+
+// ImportRowError is an error found in a row of the import file.
+type ImportRowError struct {
+	Row     int    `json:"row"` // Row of the file, the header is row 1
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+
+	// Code and arguments of the message in the i18n catalog
+	MessageCode string        `json:"-"`
+	Args        []interface{} `json:"-"`
+}
+
+// NewImportRowError returns the error of a field, with the message of the given i18n code.
+func NewImportRowError(field string, message string, args ...interface{}) ImportRowError {
+	return ImportRowError{
+		Field:       field,
+		Message:     i18n.Translate(i18n.DefaultLanguage, message, args...),
+		MessageCode: message,
+		Args:        args,
+	}
+}
+
+// ImportRowErrors are the errors found in the import file.
+type ImportRowErrors []ImportRowError
+
+// Localize translates the messages in the given language.
+func (e ImportRowErrors) Localize(lang string) {
+	for i, err := range e {
+		if err.MessageCode != "" {
+			e[i].Message = i18n.Translate(lang, err.MessageCode, err.Args...)
+		}
+	}
+}
+
+// ImportReport is the outcome of an import.
+type ImportReport struct {
+	DryRun   bool            `json:"dry_run"`
+	Rows     int             `json:"rows"`
+	Imported int             `json:"imported"`
+	Errors   ImportRowErrors `json:"errors"`
+	Members  []Member        `json:"members,omitempty"`
+}
+
+// Localize translates the messages of the errors in the given language.
+func (r *ImportReport) Localize(lang string) {
+	r.Errors.Localize(lang)
+}
+
+// Merge returns a copy of the columns overridden by the given ones.
+func (c ImportColumns) Merge(columns ImportColumns) ImportColumns {
+	merged := make(ImportColumns, len(c))
+	for field, header := range c {
+		merged[field] = header
+	}
+	for field, header := range columns {
+		merged[field] = header
+	}
+	return merged
+}
+
+// Index finds the position of each field in the header of the file.
+func (c ImportColumns) Index(header []string) (map[string]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	index := make(map[string]int, len(c))
+	for field, name := range c {
+		if _, ok := DefaultImportColumns()[field]; !ok {
+			return nil, NewValidationError(i18n.MsgImportFieldInvalid, field)
+		}
+
+		position, ok := positions[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			if slices.Contains(optionalImportColumns, field) {
+				continue
+			}
+			return nil, NewValidationError(i18n.MsgImportColumnMissing, name)
+		}
+		index[field] = position
+	}
+
+	return index, nil
+}
+
+// ParseMember builds a member with its contacts, address and initial
+// subscription from a row of the file.
+func (c ImportColumns) ParseMember(index map[string]int, row []string) (*Member, []ImportRowError) {
+	var errs []ImportRowError
+	value := func(field string) string {
+		position, ok := index[field]
+		if !ok || position >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[position])
+	}
+	date := func(field string) time.Time {
+		raw := value(field)
+		if raw == "" {
+			return time.Time{}
+		}
+		parsed, err := parseImportDate(raw)
+		if err != nil {
+			errs = append(errs, NewImportRowError(field, i18n.MsgImportDateInvalid, raw))
+		}
+		return parsed
+	}
+
+	subscription := Subscription{
+		Type:      strings.ToLower(value(ImportSubscriptionType)),
+		StartDate: date(ImportSubscriptionStart),
+		EndDate:   date(ImportSubscriptionEnd),
+		IsActive:  new(bool),
+	}
+	if raw := value(ImportSubscriptionPrice); raw != "" {
+		price, err := parseImportPrice(raw)
+		if err != nil {
+			errs = append(errs, NewImportRowError(ImportSubscriptionPrice, i18n.MsgImportPriceInvalid, raw))
+		}
+		subscription.Price = price
+	}
+	subscription.AddEndDate()
+
+	// Old subscriptions are imported as expired
+	now := time.Now()
+	*subscription.IsActive = !subscription.StartDate.After(now) && subscription.EndDate.After(now)
+
+	member := &Member{
+		Name:        value(ImportName),
+		Surname:     value(ImportSurname),
+		Gender:      value(ImportGender),
+		DateOfBirth: date(ImportDateOfBirth),
+		Contacts: &Contacts{
+			Phone: value(ImportPhone),
+			Email: strings.ToLower(value(ImportEmail)),
+		},
+		Address: &Address{
+			Country: value(ImportCountry),
+			City:    value(ImportCity),
+			Street:  value(ImportStreet),
+		},
+		Subscription: []Subscription{subscription},
+	}
+
+	return member, errs
+}
+
+func parseImportDate(raw string) (time.Time, error) {
+	for _, layout := range importDateLayouts {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %s", raw)
+}
+
+// parseImportPrice parses prices written as 1.200,50 €, 1200.50 or 45.
+func parseImportPrice(raw string) (float32, error) {
+	raw = strings.TrimSpace(strings.Trim(raw, "€ "))
+	if strings.Contains(raw, ",") {
+		raw = strings.ReplaceAll(raw, ".", "")
+		raw = strings.ReplaceAll(raw, ",", ".")
+	}
+
+	price, err := strconv.ParseFloat(raw, 32)
+	return float32(price), err
+}
@@ -2,20 +2,30 @@ package entities
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 )
 
 type Member struct {
 	gorm.Model
-	Name         string         `json:"name" gorm:"not null,required"`
-	Surname      string         `json:"surname" gorm:"not null,required"`
-	Gender       string         `json:"gender"`
-	DateOfBirth  time.Time      `json:"date_of_birth" gorm:"not null,required"`
-	Contacts     *Contacts      `json:"contacts" gorm:"foreignKey:ID;constraint:OnDelete:CASCADE;"`
-	Address      *Address       `json:"address" gorm:"foreignKey:ID;constraint:OnDelete:CASCADE;"`
-	Subscription []Subscription `json:"subscription" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Name              string         `json:"name" gorm:"not null,required"`
+	Surname           string         `json:"surname" gorm:"not null,required"`
+	Gender            string         `json:"gender"`
+	DateOfBirth       time.Time      `json:"date_of_birth" gorm:"not null,required"`
+	Contacts          *Contacts      `json:"contacts" gorm:"foreignKey:ID;constraint:OnDelete:CASCADE;"`
+	Address           *Address       `json:"address" gorm:"foreignKey:ID;constraint:OnDelete:CASCADE;"`
+	Subscription      []Subscription `json:"subscription" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	HouseholdID       *uint          `json:"household_id" gorm:"index"`
+	InheritsContacts  bool           `json:"inherits_contacts" gorm:"default:false"` // Contacts kept equal to the ones of the payer
+	InheritsAddress   bool           `json:"inherits_address" gorm:"default:false"`  // Address kept equal to the one of the payer
+	GuardianConsent   bool           `json:"guardian_consent,omitempty" gorm:"-"`    // Consent of the guardian, for minors joining a household
+	GuardianID        *uint          `json:"guardian_id"`                            // Member who gave the consent for a minor
+	GuardianConsentAt *time.Time     `json:"guardian_consent_at"`                    // When the guardian gave the consent
+	ErasedAt          *time.Time     `json:"erased_at"`                              // When the personal data was anonymized
 }
 
 type UpdateMember struct {
@@ -26,10 +36,12 @@ type UpdateMember struct {
 }
 
 type Contacts struct {
-	ID      uint `json:"ID" gorm:"primaryKey;autoIncrement;unique;not null"`
-	Deleted gorm.DeletedAt
-	Phone   string `json:"phone"`
-	Email   string `json:"email"`
+	ID            uint `json:"ID" gorm:"primaryKey;autoIncrement;unique;not null"`
+	Deleted       gorm.DeletedAt
+	Phone         string `json:"phone"`
+	Email         string `json:"email"`
+	NotifyByEmail *bool  `json:"notify_by_email" gorm:"default:true"`
+	NotifyBySMS   *bool  `json:"notify_by_sms" gorm:"default:true"`
 }
 
 type Address struct {
@@ -41,14 +53,26 @@ type Address struct {
 }
 
 type Subscription struct {
-	ID        uint `json:"ID" gorm:"primaryKey;autoIncrement;unique;not null"`
-	Deleted   gorm.DeletedAt
-	UserID    uint      `json:"user_id"`
-	Type      string    `json:"type"`
-	StartDate time.Time `json:"start_date"`
-	EndDate   time.Time `json:"end_date"`
-	IsActive  *bool     `json:"is_active"`
-	Price     float32   `json:"price"`
+	ID         uint `json:"ID" gorm:"primaryKey;autoIncrement;unique;not null"`
+	Deleted    gorm.DeletedAt
+	UserID     uint      `json:"user_id"`
+	Type       string    `json:"type"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+	IsActive   *bool     `json:"is_active"`
+	Price      float32   `json:"price"`
+	AutoRenew  *bool     `json:"auto_renew" gorm:"default:false"`
+	PreviousID *uint     `json:"previous_id" gorm:"uniqueIndex:idx_subscriptions_renewal,where:deleted IS NULL"` // Subscription this one renews, at most once
+	// Queued after the subscriptions it overlapped, activated when it starts
+	Queued bool `json:"queued" gorm:"default:false"`
+	// Deleted by the deletion of the member, and restored with it
+	DeletedWithMember bool `json:"-" gorm:"default:false"`
+
+	// Pricing, Price is the final price
+	OriginalPrice float32 `json:"original_price"`
+	Discount      float32 `json:"discount"`
+	PromotionID   *uint   `json:"promotion_id" gorm:"index"`
+	PromotionCode string  `json:"promotion_code,omitempty" gorm:"-"`
 }
 
 type UpdateSubscription struct {
@@ -57,8 +81,28 @@ type UpdateSubscription struct {
 	EndDate   time.Time `json:"end_date"`
 	IsActive  *bool     `json:"is_active"`
 	Price     float32   `json:"price"`
+	AutoRenew *bool     `json:"auto_renew"`
+}
+
+// OverlapPolicy defines what to do with a subscription overlapping the
+// existing ones of the member.
+type OverlapPolicy string
+
+const (
+	// OverlapReject refuses the overlapping subscription
+	OverlapReject OverlapPolicy = "reject"
+	// OverlapQueue moves the subscription after the last overlapping one
+	OverlapQueue OverlapPolicy = "queue"
+)
+
+type RenewSubscription struct {
+	Price         float32 `json:"price"`
+	AutoRenew     *bool   `json:"auto_renew"`
+	PromotionCode string  `json:"promotion_code"`
 }
 
+var subscriptionTypes = []string{"mensile", "trimestrale", "semestrale", "annuale", "custom"}
+
 func (s *Subscription) AddEndDate() {
 	switch s.Type {
 	case "mensile":
@@ -76,6 +120,68 @@ func (s *Subscription) AddEndDate() {
 	}
 }
 
+// NextSubscription builds the subscription that renews s: it starts at the
+// previous EndDate with the same type and, for custom subscriptions, the same
+// duration. A zero price keeps the previous price before discounts.
+func (s *Subscription) NextSubscription(price float32) *Subscription {
+	if price == 0 {
+		price = s.Price
+		if s.OriginalPrice != 0 {
+			price = s.OriginalPrice
+		}
+	}
+
+	next := &Subscription{
+		UserID:     s.UserID,
+		Type:       s.Type,
+		StartDate:  s.EndDate,
+		IsActive:   new(bool),
+		Price:      price,
+		AutoRenew:  new(bool),
+		PreviousID: &s.ID,
+	}
+	if s.AutoRenew != nil {
+		*next.AutoRenew = *s.AutoRenew
+	}
+
+	if s.Type == "custom" {
+		next.EndDate = next.StartDate.Add(s.EndDate.Sub(s.StartDate))
+	}
+	next.AddEndDate()
+
+	return next
+}
+
+// ApplyDiscount lowers the price of the subscription keeping track of the
+// original price and of the total discount.
+func (s *Subscription) ApplyDiscount(amount float32) {
+	if s.OriginalPrice == 0 {
+		s.OriginalPrice = s.Price
+	}
+
+	amount = min(amount, s.Price)
+	s.Discount += amount
+	s.Price -= amount
+}
+
+// Reschedule moves the subscription to a new start date keeping its duration,
+// queued until it starts.
+func (s *Subscription) Reschedule(start time.Time) {
+	duration := s.EndDate.Sub(s.StartDate)
+
+	s.StartDate = start
+	if s.Type == "custom" {
+		s.EndDate = start.Add(duration)
+	}
+
+	if s.IsActive == nil {
+		s.IsActive = new(bool)
+	}
+	*s.IsActive = false
+	s.AddEndDate()
+	s.Queued = !*s.IsActive
+}
+
 func (s *UpdateSubscription) AddEndDate() {
 	switch s.Type {
 	case "mensile":
@@ -89,88 +195,119 @@ func (s *UpdateSubscription) AddEndDate() {
 	}
 }
 
+func (p OverlapPolicy) Validate() error {
+	var errs ValidationErrors
+	if p != OverlapReject && p != OverlapQueue {
+		errs.Add("policy", ValidationInvalid, i18n.MsgOverlapPolicyInvalid)
+	}
+	return errs.Err()
+}
+
+func (r *RenewSubscription) Validate() error {
+	var errs ValidationErrors
+	if r.Price < 0 {
+		errs.Add("price", ValidationInvalid, i18n.MsgSubscriptionPriceNegative)
+	}
+	return errs.Err()
+}
+
 func (m *Member) Validate() error {
-	if m.Name == "" ||
-		m.Surname == "" ||
-		m.Gender == "" ||
-		m.DateOfBirth.IsZero() ||
-		m.Contacts == nil ||
-		m.Address == nil ||
-		len(m.Subscription) == 0 {
-		return fmt.Errorf("compilare i campi obbligatori")
+	var errs ValidationErrors
+	if m.Name == "" {
+		errs.Add("name", ValidationRequired, i18n.MsgNameRequired)
+	}
+	if m.Surname == "" {
+		errs.Add("surname", ValidationRequired, i18n.MsgSurnameRequired)
+	}
+	if m.Gender == "" {
+		errs.Add("gender", ValidationRequired, i18n.MsgGenderRequired)
+	}
+	if m.DateOfBirth.IsZero() {
+		errs.Add("date_of_birth", ValidationRequired, i18n.MsgDateOfBirthRequired)
 	}
 
-	if err := m.Contacts.Validate(); err != nil {
-		return err
+	if m.Contacts == nil {
+		errs.Add("contacts", ValidationRequired, i18n.MsgContactsRequired)
+	} else {
+		errs.Nest("contacts", m.Contacts.Validate())
 	}
 
-	if err := m.Address.Validate(); err != nil {
-		return err
+	if m.Address == nil {
+		errs.Add("address", ValidationRequired, i18n.MsgAddressRequired)
+	} else {
+		errs.Nest("address", m.Address.Validate())
 	}
 
-	if err := m.Subscription[0].Validate(); err != nil {
-		return err
+	if len(m.Subscription) == 0 {
+		errs.Add("subscription", ValidationRequired, i18n.MsgSubscriptionRequired)
+	}
+	for i := range m.Subscription {
+		errs.Nest(fmt.Sprintf("subscription[%d]", i), m.Subscription[i].Validate())
 	}
 
-	return nil
+	return errs.Err()
 }
 
 func (c *Contacts) Validate() error {
+	var errs ValidationErrors
 	if c.Phone == "" {
-		return fmt.Errorf("compilare il numero di telefono")
+		errs.Add("phone", ValidationRequired, i18n.MsgPhoneRequired)
 	}
-	return nil
+	return errs.Err()
 }
 
 func (a *Address) Validate() error {
-	if a.Country == "" || a.City == "" || a.Street == "" {
-		return fmt.Errorf("l'indirizzo deve avere tutti i campi obbligatori")
+	var errs ValidationErrors
+	if a.Country == "" {
+		errs.Add("country", ValidationRequired, i18n.MsgCountryRequired)
+	}
+	if a.City == "" {
+		errs.Add("city", ValidationRequired, i18n.MsgCityRequired)
 	}
-	return nil
+	if a.Street == "" {
+		errs.Add("street", ValidationRequired, i18n.MsgStreetRequired)
+	}
+	return errs.Err()
 }
 
 func (s *Subscription) Validate() error {
-	validTypes := map[string]bool{
-		"mensile":     true,
-		"trimestrale": true,
-		"semestrale":  true,
-		"annuale":     true,
-		"custom":      true,
-	}
+	return validateSubscription(s.Type, s.StartDate, s.EndDate, s.Price, s.IsActive).Err()
+}
 
-	if !validTypes[s.Type] || s.StartDate.IsZero() || s.Price == 0 || s.Price < 0 || s.IsActive == nil {
-		return fmt.Errorf("compilare i campi d'abbonamento correttamente")
-	}
+func (m *UpdateSubscription) Validate() error {
+	return validateSubscription(m.Type, m.StartDate, m.EndDate, m.Price, m.IsActive).Err()
+}
 
-	if s.Type == "custom" && s.EndDate.IsZero() {
-		return fmt.Errorf("compilare la data di fine abbonamento")
+// validateSubscription checks the fields shared by new and updated subscriptions.
+func validateSubscription(subType string, start time.Time, end time.Time, price float32, isActive *bool) ValidationErrors {
+	var errs ValidationErrors
+	if subType == "" {
+		errs.Add("type", ValidationRequired, i18n.MsgSubscriptionTypeRequired)
+	} else if !slices.Contains(subscriptionTypes, subType) {
+		errs.Add("type", ValidationInvalid, i18n.MsgSubscriptionTypeInvalid)
 	}
 
-	if s.Type == "custom" && s.EndDate.Before(s.StartDate) {
-		return fmt.Errorf("la data di fine abbonamento deve essere successiva alla data di inizio")
+	if start.IsZero() {
+		errs.Add("start_date", ValidationRequired, i18n.MsgSubscriptionStartRequired)
 	}
-	return nil
-}
 
-func (m *UpdateSubscription) Validate() error {
-	validTypes := map[string]bool{
-		"mensile":     true,
-		"trimestrale": true,
-		"semestrale":  true,
-		"annuale":     true,
-		"custom":      true,
+	if price == 0 {
+		errs.Add("price", ValidationRequired, i18n.MsgSubscriptionPriceRequired)
+	} else if price < 0 {
+		errs.Add("price", ValidationInvalid, i18n.MsgSubscriptionPriceNegative)
 	}
 
-	if !validTypes[m.Type] || m.StartDate.IsZero() || m.Price == 0 || m.Price < 0 || m.IsActive == nil {
-		return fmt.Errorf("compilare i campi d'abbonamento correttamente")
+	if isActive == nil {
+		errs.Add("is_active", ValidationRequired, i18n.MsgSubscriptionActiveRequired)
 	}
 
-	if m.Type == "custom" && m.EndDate.IsZero() {
-		return fmt.Errorf("compilare la data di fine abbonamento")
+	if subType == "custom" {
+		if end.IsZero() {
+			errs.Add("end_date", ValidationRequired, i18n.MsgSubscriptionEndRequired)
+		} else if end.Before(start) {
+			errs.Add("end_date", ValidationBeforeStart, i18n.MsgSubscriptionEndBeforeStart)
+		}
 	}
 
-	if m.Type == "custom" && m.EndDate.Before(m.StartDate) {
-		return fmt.Errorf("la data di fine abbonamento deve essere successiva alla data di inizio")
-	}
-	return nil
+	return errs
 }
@@ -0,0 +1,170 @@
+package entities
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+)
+
+// Channels of the notifications
+const (
+	ChannelEmail   = "email"
+	ChannelSMS     = "sms"
+	ChannelWebhook = "webhook"
+	ChannelFile    = "file"
+)
+
+// Rules of the notifications
+const (
+	RuleSubscriptionExpiring = "abbonamento_in_scadenza"
+	RuleSubscriptionExpired  = "abbonamento_scaduto"
+	RuleBirthday             = "compleanno"
+)
+
+const (
+	NotificationSent   = "sent"
+	NotificationFailed = "failed"
+)
+
+// NotificationRule selects the members to notify and the message sent to them.
+type NotificationRule struct {
+	Name    string
+	Days    int    // Days before the end of the subscription, or after it for expired ones
+	Consent string // Consent required besides the privacy one, if any
+	Subject string // Template of the subject
+	Body    string // Template of the message
+}
+
+// Notification is a message sent, or tried, to a member on a channel.
+type Notification struct {
+	ID        uint      `json:"ID" gorm:"primaryKey;autoIncrement;unique;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	MemberID  uint      `json:"member_id" gorm:"not null;index"`
+	Rule      string    `json:"rule" gorm:"not null"`
+	Channel   string    `json:"channel" gorm:"not null"`
+	Recipient string    `json:"recipient"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	Status    string    `json:"status" gorm:"not null;index"`
+	Error     string    `json:"error,omitempty"`
+	Attempts  uint      `json:"attempts"`
+	DedupKey  string    `json:"-" gorm:"not null;uniqueIndex"` // Rule, occurrence, member and channel, a notification is sent once
+}
+
+// NotificationData is the data available to the templates.
+type NotificationData struct {
+	Member       *Member
+	Subscription *Subscription // Set for the subscription rules
+}
+
+// NotificationPreferences are the channels a member accepts to be contacted on.
+type NotificationPreferences struct {
+	NotifyByEmail *bool `json:"notify_by_email"`
+	NotifyBySMS   *bool `json:"notify_by_sms"`
+}
+
+var notificationFuncs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("02/01/2006")
+	},
+}
+
+// DefaultNotificationRules returns the rules with the default messages.
+//
+// Parameters:
+//   - expiringDays: days before the end of the subscription of the reminder.
+//   - expiredDays: days after the end of the subscription of the notice.
+func DefaultNotificationRules(expiringDays int, expiredDays int) []NotificationRule {
+	return []NotificationRule{
+		{
+			Name:    RuleSubscriptionExpiring,
+			Days:    expiringDays,
+			Subject: "Il tuo abbonamento scade il {{date .Subscription.EndDate}}",
+			Body:    "Ciao {{.Member.Name}}, il tuo abbonamento {{.Subscription.Type}} scade il {{date .Subscription.EndDate}}. Passa in reception per rinnovarlo!",
+		},
+		{
+			Name:    RuleSubscriptionExpired,
+			Days:    expiredDays,
+			Subject: "Il tuo abbonamento è scaduto",
+			Body:    "Ciao {{.Member.Name}}, il tuo abbonamento {{.Subscription.Type}} è scaduto il {{date .Subscription.EndDate}}. Ti aspettiamo per rinnovarlo!",
+		},
+		{
+			Name:    RuleBirthday,
+			Consent: ConsentMarketing,
+			Subject: "Buon compleanno {{.Member.Name}}!",
+			Body:    "Tanti auguri {{.Member.Name}} da tutto lo staff della palestra!",
+		},
+	}
+}
+
+// Render fills the templates of the rule with the data.
+func (r *NotificationRule) Render(data *NotificationData) (subject string, body string, err error) {
+	if subject, err = renderTemplate(r.Name+".subject", r.Subject, data); err != nil {
+		return "", "", err
+	}
+	if body, err = renderTemplate(r.Name+".body", r.Body, data); err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+func renderTemplate(name string, text string, data *NotificationData) (string, error) {
+	tmpl, err := template.New(name).Funcs(notificationFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// NotificationKey identifies a notification, so that it is sent once.
+//
+// Parameters:
+//   - rule: the name of the rule.
+//   - occurrence: what the notification is about, as a subscription or a year.
+//   - member_id: the ID of the member.
+//   - channel: the name of the channel.
+func NotificationKey(rule string, occurrence string, member_id uint, channel string) string {
+	return fmt.Sprintf("%s:%s:%d:%s", rule, occurrence, member_id, channel)
+}
+
+// Recipient returns the address of the member on a channel, empty if the
+// member has no address or refused the channel. Webhook and file channels
+// receive the email, or else the phone, the member accepts.
+func (c *Contacts) Recipient(channel string) string {
+	email := ""
+	if c.Email != "" && (c.NotifyByEmail == nil || *c.NotifyByEmail) {
+		email = c.Email
+	}
+	phone := ""
+	if c.Phone != "" && (c.NotifyBySMS == nil || *c.NotifyBySMS) {
+		phone = c.Phone
+	}
+
+	switch channel {
+	case ChannelEmail:
+		return email
+	case ChannelSMS:
+		return phone
+	default:
+		if email != "" {
+			return email
+		}
+		return phone
+	}
+}
+
+func (p *NotificationPreferences) Validate() error {
+	if p.NotifyByEmail == nil && p.NotifyBySMS == nil {
+		return NewValidationError(i18n.MsgPreferencesRequired)
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package entities
 
 import (
 	"gorm.io/gorm"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 )
 
 // Notes:
@@ -24,3 +26,42 @@ type UpdatePermissions struct {
 	Update *uint `json:"update" gorm:"default:0"`
 	Delete *uint `json:"delete" gorm:"default:0"`
 }
+
+// Highest value of each permission
+const (
+	maxCreatePermission = 1
+	maxPermission       = 2
+)
+
+func (p *Permissions) Validate() error {
+	var errs ValidationErrors
+	if p.TableName == "" {
+		errs.Add("table_name", ValidationRequired, i18n.MsgTableRequired)
+	}
+	if p.RoleId == 0 {
+		errs.Add("role_id", ValidationRequired, i18n.MsgRoleRequired)
+	}
+	errs = append(errs, validatePermissionLevels(p.Create, p.Read, p.Update, p.Delete)...)
+	return errs.Err()
+}
+
+func (p *UpdatePermissions) Validate() error {
+	return validatePermissionLevels(p.Create, p.Read, p.Update, p.Delete).Err()
+}
+
+func validatePermissionLevels(create, read, update, delete *uint) ValidationErrors {
+	var errs ValidationErrors
+	if create != nil && *create > maxCreatePermission {
+		errs.Add("create", ValidationInvalid, i18n.MsgCreateLevelInvalid)
+	}
+	if read != nil && *read > maxPermission {
+		errs.Add("read", ValidationInvalid, i18n.MsgReadLevelInvalid)
+	}
+	if update != nil && *update > maxPermission {
+		errs.Add("update", ValidationInvalid, i18n.MsgUpdateLevelInvalid)
+	}
+	if delete != nil && *delete > maxPermission {
+		errs.Add("delete", ValidationInvalid, i18n.MsgDeleteLevelInvalid)
+	}
+	return errs
+}
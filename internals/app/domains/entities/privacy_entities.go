@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+)
+
+// Name given to erased members
+const ErasedName = "Anonimo"
+
+// MemberExport is everything held about a member, returned on a data-subject
+// access request.
+type MemberExport struct {
+	ExportedAt           time.Time             `json:"exported_at"`
+	Member               Member                `json:"member"`
+	Household            *Household            `json:"household,omitempty"`
+	PromotionRedemptions []PromotionRedemption `json:"promotion_redemptions"`
+	GuestProfile         *Guest                `json:"guest_profile,omitempty"` // Guest the member was converted from
+	SponsoredGuests      []Guest               `json:"sponsored_guests"`
+	Consents             []Consent             `json:"consents"`
+	Notifications        []Notification        `json:"notifications"`
+	Changes              []Audit               `json:"changes"`
+}
+
+// Files returns the sections of the export, one per file of the archive.
+func (e *MemberExport) Files() map[string]interface{} {
+	return map[string]interface{}{
+		"member.json":                e.Member,
+		"household.json":             e.Household,
+		"promotion_redemptions.json": e.PromotionRedemptions,
+		"guest_profile.json":         e.GuestProfile,
+		"sponsored_guests.json":      e.SponsoredGuests,
+		"consents.json":              e.Consents,
+		"notifications.json":         e.Notifications,
+		"changes.json":               e.Changes,
+	}
+}
+
+const (
+	ConsentPrivacy   = "privacy"   // processing of personal data, required by the policy
+	ConsentMarketing = "marketing" // promotional communications
+	ConsentPhoto     = "foto"      // photos and videos taken in the gym
+)
+
+var consentTypes = []string{ConsentPrivacy, ConsentMarketing, ConsentPhoto}
+
+// Consent records a consent given by a member and its revocation.
+type Consent struct {
+	ID            uint       `json:"ID" gorm:"primaryKey;autoIncrement;unique;not null"`
+	CreatedAt     time.Time  `json:"created_at"`
+	MemberID      uint       `json:"member_id" gorm:"not null;index"`
+	Type          string     `json:"type" gorm:"not null;index"`
+	PolicyVersion string     `json:"policy_version" gorm:"not null"` // Version of the policy text accepted
+	GivenAt       time.Time  `json:"given_at" gorm:"not null"`
+	CollectedBy   uint       `json:"collected_by" gorm:"not null"` // Staff user who collected the consent
+	RevokedAt     *time.Time `json:"revoked_at" gorm:"index"`
+	RevokedBy     *uint      `json:"revoked_by"`
+}
+
+func (c *Consent) Validate() error {
+	if !slices.Contains(consentTypes, c.Type) {
+		return NewValidationError(i18n.MsgConsentTypeInvalid)
+	}
+
+	if c.PolicyVersion == "" {
+		return NewValidationError(i18n.MsgPolicyVersionRequired)
+	}
+
+	if !c.GivenAt.IsZero() && c.GivenAt.After(time.Now()) {
+		return NewValidationError(i18n.MsgConsentDateFuture)
+	}
+
+	return nil
+}
+
+// IsActive checks if the consent has not been revoked.
+func (c *Consent) IsActive() bool {
+	return c.RevokedAt == nil
+}
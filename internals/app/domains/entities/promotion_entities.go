@@ -0,0 +1,160 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+)
+
+const (
+	PromotionPercentage = "percentage"
+	PromotionFixed      = "fixed"
+)
+
+type Promotion struct {
+	gorm.Model
+	Code          string    `json:"code" gorm:"unique;not null;index"`
+	Description   string    `json:"description"`
+	Kind          string    `json:"kind" gorm:"not null"` // percentage or fixed
+	Value         float32   `json:"value" gorm:"not null"`
+	ValidFrom     time.Time `json:"valid_from" gorm:"not null"`
+	ValidUntil    time.Time `json:"valid_until" gorm:"not null"`
+	MaxUses       *uint     `json:"max_uses"` // nil -> unlimited
+	Uses          uint      `json:"uses" gorm:"default:0"`
+	EligibleTypes []string  `json:"eligible_types" gorm:"serializer:json"` // empty -> every type
+	FirstTimeOnly bool      `json:"first_time_only" gorm:"default:false"`
+}
+
+type UpdatePromotion struct {
+	Description   string    `json:"description"`
+	Value         float32   `json:"value"`
+	ValidFrom     time.Time `json:"valid_from"`
+	ValidUntil    time.Time `json:"valid_until"`
+	MaxUses       *uint     `json:"max_uses"`                              // 0 -> unlimited
+	EligibleTypes []string  `json:"eligible_types" gorm:"serializer:json"` // empty -> every type
+	FirstTimeOnly *bool     `json:"first_time_only"`
+}
+
+// PromotionRedemption records a promotion applied to a subscription.
+type PromotionRedemption struct {
+	ID             uint      `json:"ID" gorm:"primaryKey;autoIncrement;unique;not null"`
+	CreatedAt      time.Time `json:"created_at"`
+	PromotionID    uint      `json:"promotion_id" gorm:"not null;index"`
+	SubscriptionID uint      `json:"subscription_id" gorm:"not null;index"`
+	MemberID       uint      `json:"member_id" gorm:"not null;index"`
+	OriginalPrice  float32   `json:"original_price"`
+	Discount       float32   `json:"discount"`
+	FinalPrice     float32   `json:"final_price"`
+}
+
+// PromotionReport sums up the redemptions of a promotion.
+type PromotionReport struct {
+	PromotionID   uint    `json:"promotion_id"`
+	Code          string  `json:"code"`
+	Redemptions   int64   `json:"redemptions"`
+	TotalDiscount float32 `json:"total_discount"`
+	TotalRevenue  float32 `json:"total_revenue"`
+}
+
+func (p *Promotion) Validate() error {
+	if p.Code == "" || p.ValidFrom.IsZero() || p.ValidUntil.IsZero() {
+		return NewValidationError(i18n.MsgRequiredFields)
+	}
+
+	if p.Kind != PromotionPercentage && p.Kind != PromotionFixed {
+		return NewValidationError(i18n.MsgPromotionTypeInvalid)
+	}
+
+	if p.Value <= 0 || (p.Kind == PromotionPercentage && p.Value > 100) {
+		return NewValidationError(i18n.MsgPromotionValueInvalid)
+	}
+
+	if p.ValidUntil.Before(p.ValidFrom) {
+		return NewValidationError(i18n.MsgPromotionEndBeforeStart)
+	}
+
+	return validSubscriptionTypes(p.EligibleTypes)
+}
+
+func (p *UpdatePromotion) Validate() error {
+	if p.Value < 0 {
+		return NewValidationError(i18n.MsgPromotionValueInvalid)
+	}
+
+	if !p.ValidFrom.IsZero() && !p.ValidUntil.IsZero() && p.ValidUntil.Before(p.ValidFrom) {
+		return NewValidationError(i18n.MsgPromotionEndBeforeStart)
+	}
+
+	return validSubscriptionTypes(p.EligibleTypes)
+}
+
+// ApplyUpdate sets the values of the update, the zero ones are not updated.
+// A zero MaxUses removes the limit and empty EligibleTypes allow every type.
+func (p *Promotion) ApplyUpdate(update *UpdatePromotion) {
+	if update.Description != "" {
+		p.Description = update.Description
+	}
+	if update.Value != 0 {
+		p.Value = update.Value
+	}
+	if !update.ValidFrom.IsZero() {
+		p.ValidFrom = update.ValidFrom
+	}
+	if !update.ValidUntil.IsZero() {
+		p.ValidUntil = update.ValidUntil
+	}
+	if update.MaxUses != nil {
+		p.MaxUses = update.MaxUses
+		if *update.MaxUses == 0 {
+			p.MaxUses = nil
+		}
+	}
+	if update.EligibleTypes != nil {
+		p.EligibleTypes = update.EligibleTypes
+	}
+	if update.FirstTimeOnly != nil {
+		p.FirstTimeOnly = *update.FirstTimeOnly
+	}
+}
+
+// NormalizeCode makes promotion codes case insensitive.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// DiscountFor returns the discount of the promotion on the given price.
+func (p *Promotion) DiscountFor(price float32) float32 {
+	discount := p.Value
+	if p.Kind == PromotionPercentage {
+		discount = price * p.Value / 100
+	}
+	return min(discount, price)
+}
+
+// IsEligible checks if the promotion applies to the subscription type.
+func (p *Promotion) IsEligible(subscriptionType string) bool {
+	return len(p.EligibleTypes) == 0 || slices.Contains(p.EligibleTypes, subscriptionType)
+}
+
+// IsValidAt checks if the promotion can be used at the given time.
+func (p *Promotion) IsValidAt(t time.Time) bool {
+	return !t.Before(p.ValidFrom) && !t.After(p.ValidUntil)
+}
+
+// IsExhausted checks if the promotion reached its usage limit.
+func (p *Promotion) IsExhausted() bool {
+	return p.MaxUses != nil && p.Uses >= *p.MaxUses
+}
+
+func validSubscriptionTypes(types []string) error {
+	for _, t := range types {
+		if !slices.Contains(subscriptionTypes, t) {
+			return NewValidationError(i18n.MsgPromotionSubscriptionTypeInvalid, t)
+		}
+	}
+	return nil
+}
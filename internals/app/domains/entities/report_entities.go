@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/goccy/go-json"
+)
+
+// Time a report is kept in the cache
+const reportCacheExpiration = 15 * time.Minute
+
+// Longest period of a report, in months
+const maxReportMonths = 60
+
+// Month layout of the reports
+const ReportMonthLayout = "2006-01"
+
+// Report is the result of a report over a period of months, cached by name
+// and period.
+type Report struct {
+	Name        string      `json:"name"`
+	From        string      `json:"from"` // First month, as 2006-01
+	To          string      `json:"to"`   // Last month, included
+	GeneratedAt time.Time   `json:"generated_at"`
+	Data        interface{} `json:"data"`
+}
+
+// ReportPeriod is a period of whole months.
+type ReportPeriod struct {
+	From time.Time // First day of the first month
+	To   time.Time // First day of the last month
+}
+
+// MonthlyRevenue is the monthly recurring revenue at the end of a month.
+type MonthlyRevenue struct {
+	Month         string  `json:"month"`
+	MRR           float32 `json:"mrr"`
+	Subscriptions int     `json:"subscriptions"`
+}
+
+// MemberGrowth is the number of members who joined and left in a month.
+type MemberGrowth struct {
+	Month   string `json:"month"`
+	New     int    `json:"new"`
+	Churned int    `json:"churned"`
+	Net     int    `json:"net"`
+}
+
+// ActiveMembers is the number of members with a subscription at the end of a month.
+type ActiveMembers struct {
+	Month   string `json:"month"`
+	Members int    `json:"members"`
+}
+
+// SubscriptionMix is the share of a subscription type among the subscriptions started in the period.
+type SubscriptionMix struct {
+	Type          string  `json:"type"`
+	Subscriptions int     `json:"subscriptions"`
+	Share         float32 `json:"share"` // Percentage of the subscriptions
+	Revenue       float32 `json:"revenue"`
+}
+
+// MemberLifetime is the time members joined in the period stayed subscribed.
+type MemberLifetime struct {
+	Members              int     `json:"members"`
+	AverageMonths        float32 `json:"average_months"` // Up to today for the members still subscribed
+	Churned              int     `json:"churned"`
+	ChurnedAverageMonths float32 `json:"churned_average_months"`
+}
+
+// RetentionCohort is the share of the members joined in a month still
+// subscribed in each of the following months.
+type RetentionCohort struct {
+	Month     string    `json:"month"`
+	Members   int       `json:"members"`
+	Retention []float32 `json:"retention"` // Percentage, the first value is the month of the cohort
+}
+
+func (p *ReportPeriod) Validate() error {
+	if p.To.Before(p.From) {
+		return NewValidationError(i18n.MsgReportMonthsOrder)
+	}
+
+	if len(p.Months()) > maxReportMonths {
+		return NewValidationError(i18n.MsgReportPeriodTooLong, maxReportMonths)
+	}
+
+	return nil
+}
+
+// Months returns the first day of each month of the period.
+func (p *ReportPeriod) Months() []time.Time {
+	var months []time.Time
+	for month := p.From; !month.After(p.To); month = month.AddDate(0, 1, 0) {
+		months = append(months, month)
+	}
+	return months
+}
+
+// End returns the end of the period, excluded.
+func (p *ReportPeriod) End() time.Time {
+	return p.To.AddDate(0, 1, 0)
+}
+
+// NewReport creates an empty report of the period.
+func NewReport(name string, period *ReportPeriod) *Report {
+	return &Report{
+		Name: name,
+		From: period.From.Format(ReportMonthLayout),
+		To:   period.To.Format(ReportMonthLayout),
+	}
+}
+
+func (r *Report) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, r)
+}
+
+func (r *Report) SetCacheKey() string {
+	return fmt.Sprintf("report:%s:%s:%s", r.Name, r.From, r.To)
+}
+
+func (r *Report) SetCacheExpiration() time.Duration {
+	return reportCacheExpiration
+}
+
+func (r *Report) GetCacheKey() string {
+	// Without a name it matches all the reports
+	if r.Name == "" {
+		return "report:*"
+	}
+	return r.SetCacheKey()
+}
@@ -1,9 +1,9 @@
 package entities
 
 import (
-	"fmt"
-
 	"gorm.io/gorm"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 )
 
 type Roles struct {
@@ -18,17 +18,17 @@ type UpdateRoles struct {
 }
 
 func (r *Roles) Validate() error {
-	if r.Name == "" {
-		return fmt.Errorf("inserire un nome")
-	}
-
-	return nil
+	return validateRoleName(r.Name).Err()
 }
 
 func (r *UpdateRoles) Validate() error {
-	if r.Name == "" {
-		return fmt.Errorf("inserire un nome")
-	}
+	return validateRoleName(r.Name).Err()
+}
 
-	return nil
+func validateRoleName(name string) ValidationErrors {
+	var errs ValidationErrors
+	if name == "" {
+		errs.Add("name", ValidationRequired, i18n.MsgRoleNameRequired)
+	}
+	return errs
 }
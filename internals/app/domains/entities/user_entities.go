@@ -1,9 +1,10 @@
 package entities
 
 import (
-	"fmt"
+	"net/mail"
 	"time"
 
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
@@ -30,36 +31,42 @@ type UpdateUser struct {
 }
 
 func (u *User) Validate() error {
+	var errs ValidationErrors
+
 	//Check the email
 	if u.Email == "" {
-		return fmt.Errorf("email is required")
+		errs.Add("email", ValidationRequired, i18n.MsgEmailRequired)
+	} else if _, err := mail.ParseAddress(u.Email); err != nil {
+		errs.Add("email", ValidationInvalid, i18n.MsgEmailInvalid)
 	}
 
 	//Check the password
 	if u.Password == "" {
-		return fmt.Errorf("password is required")
+		errs.Add("password", ValidationRequired, i18n.MsgPasswordRequired)
 	}
 
 	//Check the Role
 	if u.RoleID == 0 {
-		return fmt.Errorf("role is required")
+		errs.Add("role_id", ValidationRequired, i18n.MsgRoleRequired)
 	}
 
-	return nil
+	return errs.Err()
 }
 
 func (u *UserLogin) Validate() error {
+	var errs ValidationErrors
+
 	//Check the email
 	if u.Email == "" {
-		return fmt.Errorf("l'email è obbligatoria")
+		errs.Add("email", ValidationRequired, i18n.MsgEmailRequired)
 	}
 
 	//Check the password
 	if u.Password == "" {
-		return fmt.Errorf("la password è obbligatoria")
+		errs.Add("password", ValidationRequired, i18n.MsgPasswordRequired)
 	}
 
-	return nil
+	return errs.Err()
 }
 
 func (u *User) RemovePassword() {
@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+)
+
+// Codes of the validation errors
+const (
+	ValidationRequired    = "required"
+	ValidationInvalid     = "invalid"
+	ValidationBeforeStart = "before_start_date"
+	ValidationDuplicate   = "duplicate"
+)
+
+// ValidationError describes why a single field of a request is not valid.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	// Code and arguments of the message in the i18n catalog
+	MessageCode string        `json:"-"`
+	Args        []interface{} `json:"-"`
+}
+
+// ValidationErrors is returned by the services when a request breaks one or
+// more domain rules.
+type ValidationErrors []ValidationError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, err := range v {
+		messages[i] = err.Message
+	}
+	return strings.Join(messages, ", ")
+}
+
+// Add appends the error of a field, with the message of the given i18n code.
+func (v *ValidationErrors) Add(field string, code string, message string, args ...interface{}) {
+	*v = append(*v, ValidationError{
+		Field:       field,
+		Code:        code,
+		Message:     i18n.Translate(i18n.DefaultLanguage, message, args...),
+		MessageCode: message,
+		Args:        args,
+	})
+}
+
+// Nest appends the errors of a nested object, e.g. contacts.phone or
+// subscription[0].end_date.
+func (v *ValidationErrors) Nest(prefix string, err error) {
+	if err == nil {
+		return
+	}
+
+	var nested ValidationErrors
+	if !errors.As(err, &nested) {
+		var domainError *DomainError
+		if errors.As(err, &domainError) {
+			v.Add(prefix, ValidationInvalid, domainError.Message, domainError.Args...)
+		} else {
+			v.Add(prefix, ValidationInvalid, err.Error())
+		}
+		return
+	}
+	for _, e := range nested {
+		e.Field = prefix + "." + e.Field
+		*v = append(*v, e)
+	}
+}
+
+// Localize translates the messages in the given language.
+func (v ValidationErrors) Localize(lang string) {
+	for i, err := range v {
+		if err.MessageCode != "" {
+			v[i].Message = i18n.Translate(lang, err.MessageCode, err.Args...)
+		}
+	}
+}
+
+// Is allows errors.Is to match ErrValidation.
+func (v ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Err returns the errors, or nil when there are none.
+func (v ValidationErrors) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,180 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+)
+
+// Events the webhooks can subscribe to
+var WebhookEvents = []string{EventMemberCreated, EventMemberDeleted, EventSubscriptionCreated, EventSubscriptionExpired, EventUserLogin}
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Delay before each retry of a failed delivery, the delivery fails after the last one
+var deliveryBackoff = []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute, 2 * time.Hour, 12 * time.Hour}
+
+// Webhook is an endpoint receiving the events it subscribed to.
+type Webhook struct {
+	gorm.Model
+	URL         string   `json:"url" gorm:"not null"`
+	Description string   `json:"description"`
+	Secret      string   `json:"-" gorm:"not null"` // Key of the HMAC signature of the payloads, returned only on creation
+	Events      []string `json:"events" gorm:"serializer:json"`
+	Active      *bool    `json:"active" gorm:"default:true"`
+}
+
+// NewWebhook is a webhook to create, with its secret. It is the only response
+// returning the secret.
+type NewWebhook struct {
+	Webhook
+	Secret string `json:"secret"` // Generated when empty
+}
+
+type UpdateWebhook struct {
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Events      []string `json:"events"`
+	Active      *bool    `json:"active"`
+}
+
+// WebhookDelivery is an event sent, or to be sent, to a webhook.
+type WebhookDelivery struct {
+	ID             uint       `json:"ID" gorm:"primaryKey;autoIncrement;unique;not null"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	WebhookID      uint       `json:"webhook_id" gorm:"not null;index"`
+	MemberID       *uint      `json:"member_id,omitempty" gorm:"index"` // Member whose personal data is in the payload
+	Event          string     `json:"event" gorm:"not null"`
+	Payload        string     `json:"payload" gorm:"not null"`
+	Status         string     `json:"status" gorm:"not null;index"`
+	Attempts       uint       `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at" gorm:"index"`
+	ResponseStatus int        `json:"response_status"`
+	Error          string     `json:"error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+}
+
+// WebhookEvent is the payload posted to the webhooks.
+type WebhookEvent struct {
+	Event     string      `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+func (w *Webhook) Validate() error {
+	if err := validateWebhookURL(w.URL); err != nil {
+		return err
+	}
+	return validateWebhookEvents(w.Events)
+}
+
+func (w *UpdateWebhook) Validate() error {
+	if w.URL != "" {
+		if err := validateWebhookURL(w.URL); err != nil {
+			return err
+		}
+	}
+	if w.Events != nil {
+		return validateWebhookEvents(w.Events)
+	}
+	return nil
+}
+
+func validateWebhookURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return NewValidationError(i18n.MsgWebhookURLInvalid)
+	}
+	return nil
+}
+
+func validateWebhookEvents(events []string) error {
+	if len(events) == 0 {
+		return NewValidationError(i18n.MsgWebhookEventsRequired)
+	}
+	for _, event := range events {
+		if !slices.Contains(WebhookEvents, event) {
+			return NewValidationError(i18n.MsgWebhookEventUnknown, event)
+		}
+	}
+	return nil
+}
+
+// Subscribed checks if the webhook receives the event.
+func (w *Webhook) Subscribed(event string) bool {
+	return (w.Active == nil || *w.Active) && slices.Contains(w.Events, event)
+}
+
+// Sign returns the signature header of a payload: the timestamp and the
+// HMAC-SHA256 of "timestamp.payload" with the secret of the webhook.
+func (w *Webhook) Sign(payload []byte, timestamp time.Time) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write([]byte(unix + "."))
+	mac.Write(payload)
+
+	return fmt.Sprintf("t=%s,v1=%s", unix, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// Erase replaces the payload with the ID of the member only, once the
+// personal data of the member is erased. The pending delivery is still sent.
+func (d *WebhookDelivery) Erase() error {
+	if d.MemberID == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(WebhookEvent{
+		Event:     d.Event,
+		CreatedAt: d.CreatedAt,
+		Data:      map[string]interface{}{"member_id": *d.MemberID, "erased": true},
+	})
+	if err != nil {
+		return err
+	}
+	d.Payload = string(payload)
+	return nil
+}
+
+// Delivered marks the delivery as delivered.
+func (d *WebhookDelivery) Delivered(status int, now time.Time) {
+	d.Attempts++
+	d.Status = DeliveryDelivered
+	d.ResponseStatus = status
+	d.Error = ""
+	d.NextAttemptAt = nil
+	d.DeliveredAt = &now
+}
+
+// Failed schedules the next attempt of the delivery, or marks it as failed
+// once the retries are over.
+func (d *WebhookDelivery) Failed(status int, err error, now time.Time) {
+	d.Attempts++
+	d.ResponseStatus = status
+	d.Error = err.Error()
+
+	if int(d.Attempts) > len(deliveryBackoff) {
+		d.Status = DeliveryFailed
+		d.NextAttemptAt = nil
+		return
+	}
+
+	next := now.Add(deliveryBackoff[d.Attempts-1])
+	d.Status = DeliveryPending
+	d.NextAttemptAt = &next
+}
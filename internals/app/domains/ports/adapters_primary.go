@@ -11,6 +11,6 @@ type ParserAdapters interface {
 	//   - target: the interface to which the data will be parsed.
 	//
 	// Return:
-	//   - error: if there was an error parsing the data
+	//   - error: entities.ErrValidation if the body is not valid JSON
 	ParseData(c *fiber.Ctx, target interface{}) error
 }
\ No newline at end of file
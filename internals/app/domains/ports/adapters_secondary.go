@@ -1,12 +1,16 @@
 package ports
 
 import (
+	"context"
+	"io"
 	"time"
 
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 	"github.com/gofiber/fiber/v2"
 )
 
-// HttpResponses defines methods for handling HTTP responses
+// HttpResponses defines methods for handling HTTP responses, the messages are
+// i18n codes translated in the language of the Accept-Language header.
 type HttpAdapters interface {
 
 	// 200 ok response
@@ -15,6 +19,9 @@ type HttpAdapters interface {
 	// 400 bad request
 	BadRequest(c *fiber.Ctx, message string) error
 
+	// 400 bad request with the list of validation errors
+	ValidationFailed(c *fiber.Ctx, message string, errors interface{}) error
+
 	// 401 unauthorized
 	Unauthorized(c *fiber.Ctx, message string) error
 
@@ -24,11 +31,110 @@ type HttpAdapters interface {
 	// 404 not found
 	NotFound(c *fiber.Ctx, message string) error
 
+	// 409 conflict
+	Conflict(c *fiber.Ctx, message string) error
+
 	// 500 internal server error
 	InternalServerError(c *fiber.Ctx, message string) error
 
+	// Error responds with the status of an error, it's the ErrorHandler of the app.
+	// 		Note: domain errors are mapped to their status, e.g. entities.ErrNotFound to 404.
+	// 		Note: unexpected errors are logged and answered with a generic 500.
+	Error(c *fiber.Ctx, err error) error
+
 	// 200 ok response with file
 	WithFile(c *fiber.Ctx, pathToFile string) error
+
+	// 200 ok response with data downloaded as a file
+	Attachment(c *fiber.Ctx, filename string, data []byte) error
+
+	// 200 ok response downloaded as a file, written by write while it is sent.
+	// 		Note: write runs after the handler returns, the errors can only be logged.
+	Stream(c *fiber.Ctx, filename string, write func(w io.Writer) error) error
+}
+
+// NotificationChannel defines methods for sending notifications
+type NotificationChannel interface {
+
+	// Name returns the name of the channel, stored with the notifications.
+	Name() string
+
+	// Send sends the notification to its recipient.
+	//
+	// Parameters:
+	//   - notification: the notification, with recipient, subject and body.
+	//
+	// Returns:
+	//   - error: if the notification can't be sent
+	Send(notification *entities.Notification) error
+}
+
+// EventHandler reacts to a published event, with the context of the change
+type EventHandler func(ctx context.Context, event entities.Event) error
+
+// EventBus defines methods for publishing the domain events
+type EventBus interface {
+
+	// Subscribe registers a handler for the events with the given name.
+	// 		Note: the handlers are called in the order they subscribed.
+	//
+	// Parameters:
+	//   - name: the name of the event, e.g. entities.EventMemberCreated.
+	//   - handler: the function called with the event.
+	Subscribe(name string, handler EventHandler)
+
+	// Publish sends the event to the handlers subscribed to its name.
+	// 		Note: it's called once the change is committed.
+	// 		Note: the errors of the handlers are logged and don't stop the other handlers.
+	//
+	// Parameters:
+	//   - ctx: the context of the change, passed to the handlers.
+	//   - event: the event to publish.
+	Publish(ctx context.Context, event entities.Event)
+}
+
+// WebhookAdapters defines methods for posting to webhooks
+type WebhookAdapters interface {
+
+	// Post posts a JSON payload to a webhook.
+	//
+	// Parameters:
+	//   - url: the address of the webhook.
+	//   - headers: the headers of the request.
+	//   - payload: the JSON body.
+	//
+	// Returns:
+	//   - int: the status of the response, 0 if there was no response
+	//   - error: if the request failed or the status is not 2xx
+	Post(url string, headers map[string]string, payload []byte) (int, error)
+}
+
+// TableAdapters defines methods for writing tables to files
+type TableAdapters interface {
+	// NewTableWriter creates a writer of a table in the given format.
+	//
+	// Parameters:
+	//   - format: the format of the file, csv, xlsx or pdf.
+	//   - title: the title of the table, printed on the pdf.
+	//   - w: the writer of the file.
+	//
+	// Returns:
+	//   - TableWriter: the writer of the table.
+	//   - error: if the format is not supported
+	NewTableWriter(format string, title string, w io.Writer) (TableWriter, error)
+}
+
+// TableWriter writes the rows of a table
+type TableWriter interface {
+
+	// WriteHeader writes the names of the columns.
+	WriteHeader(columns []string) error
+
+	// WriteRow writes a row, values can be strings, numbers, bools or times.
+	WriteRow(values []interface{}) error
+
+	// Close completes the file, it must be called once all rows are written.
+	Close() error
 }
 
 type CacheAdapters interface {
@@ -49,6 +155,16 @@ type CacheAdapters interface {
 	//   - []string: a slice of strings representing the keys retrieved from Redis
 	//   - error: if there was an error retrieving the keys from Redis
 	GetCacheKeys(data CachePort) ([]string, error)
+	// CountCacheKeys counts the keys in Redis matching the provided CachePort
+	// data, scanned in batches so that Redis is not blocked as with KEYS.
+	//
+	// Parameters:
+	//   - data: the CachePort data used to match the keys in Redis
+	//
+	// Returns:
+	//   - int: the number of keys, keys changed during the scan may be counted twice
+	//   - error: if there was an error scanning the keys in Redis
+	CountCacheKeys(data CachePort) (int, error)
 	// GetCacheFromKey retrieves data from Redis based on the provided CachePort data.
 	//
 	// Parameters:
@@ -82,6 +198,32 @@ type CacheAdapters interface {
 	// Returns:
 	//   - error: if there was an error deleting the keys from Redis
 	DelCacheMultiple(data CachePort) error
+	// Increment increments the counter of the key, created with the expiration
+	// when missing, in a single command so that the instances share it.
+	//
+	// Parameters:
+	//   - key: the key of the counter
+	//   - expiration: the expiration of a new counter
+	//
+	// Returns:
+	//   - int64: the counter after the increment
+	//   - time.Duration: the time left before the counter expires
+	//   - error: if there was an error incrementing the counter in Redis
+	Increment(key string, expiration time.Duration) (int64, time.Duration, error)
+	// Ping checks the connection to Redis.
+	//
+	// Returns:
+	//   - error: if Redis can't be reached
+	Ping() error
+	// WithContext returns a copy of the adapter sending its commands with the
+	// context, so that they are canceled and traced with it.
+	//
+	// Parameters:
+	//   - ctx: the context of the commands
+	//
+	// Returns:
+	//   - CacheAdapters: the copy of the adapter
+	WithContext(ctx context.Context) CacheAdapters
 }
 
 // CachePort defines methods for handling cache
@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditServices interface {
+
+	// LogChange records a change made by the logged user.
+	// 		Note: errors are logged and never stop the request.
+	// 		Note: passwords are never recorded.
+	//
+	// Parameters:
+	//   - c: the fiber.Ctx object representing the HTTP request context.
+	//   - action: one of entities.AuditCreate, entities.AuditUpdate and entities.AuditDelete.
+	//   - entity: the name of the changed table.
+	//   - entityID: the ID of the changed record.
+	//   - before: the record before the change, nil on create.
+	//   - after: the record after the change, nil on delete.
+	LogChange(c *fiber.Ctx, action string, entity string, entityID uint, before interface{}, after interface{})
+
+	// LogSystemChange records a change made by the system, e.g. by the scheduler.
+	// 		Note: errors are logged and never stop the caller.
+	//
+	// Parameters:
+	//   - action: one of entities.AuditCreate, entities.AuditUpdate and entities.AuditDelete.
+	//   - entity: the name of the changed table.
+	//   - entityID: the ID of the changed record.
+	//   - before: the record before the change, nil on create.
+	//   - after: the record after the change, nil on delete.
+	LogSystemChange(action string, entity string, entityID uint, before interface{}, after interface{})
+
+	// GetAuditLogs retrieves the audit logs matching the filter, newest first.
+	//
+	// Parameters:
+	//   - filter: the filter to apply.
+	//
+	// Return type:
+	//   - []entities.Audit: a slice of Audit entities.
+	//   - int64: the total number of logs matching the filter.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetAuditLogs(ctx context.Context, filter *entities.AuditFilter) ([]entities.Audit, int64, error)
+}
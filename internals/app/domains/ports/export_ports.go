@@ -0,0 +1,46 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+)
+
+type ExportServices interface {
+
+	// ExportMembers writes the members matching the filter.
+	// 		Note: the members are read in batches, so large lists are not held in memory.
+	//
+	// Parameters:
+	//   - filter: the filter of the members.
+	//   - w: the writer of the table.
+	//
+	// Return type:
+	//   - error: an error if the export process encounters any issues.
+	ExportMembers(ctx context.Context, filter *entities.MemberFilter, w TableWriter) error
+
+	// ExportExpiringSubscriptions writes the active subscriptions ending in the given period.
+	// 		Note: subscriptions already renewed are left out.
+	//
+	// Parameters:
+	//   - from: the start of the period.
+	//   - to: the end of the period, excluded.
+	//   - w: the writer of the table.
+	//
+	// Return type:
+	//   - error: an error if the export process encounters any issues.
+	ExportExpiringSubscriptions(ctx context.Context, from time.Time, to time.Time, w TableWriter) error
+
+	// ExportRevenue writes the revenue of each month and subscription type in the given period.
+	// 		Note: a subscription is counted in the month it starts.
+	//
+	// Parameters:
+	//   - from: the start of the period.
+	//   - to: the end of the period, excluded.
+	//   - w: the writer of the table.
+	//
+	// Return type:
+	//   - error: an error if the export process encounters any issues.
+	ExportRevenue(ctx context.Context, from time.Time, to time.Time, w TableWriter) error
+}
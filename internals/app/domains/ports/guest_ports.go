@@ -0,0 +1,99 @@
+package ports
+
+import "context"
+
+import "github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+
+type GuestServices interface {
+
+	// CreateGuest creates a new guest in the database.
+	// 		Note: the sponsor must be an existing member.
+	//
+	// Parameters:
+	//   - g: the guest entity to be created.
+	//
+	// Return type:
+	//   - error: an error if the creation process encounters any issues.
+	CreateGuest(ctx context.Context, g *entities.Guest) error
+
+	// GetAllGuests retrieves all guests from the database.
+	//
+	// Return type:
+	//   - []entities.Guest: a slice of Guest entities.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetAllGuests(ctx context.Context) ([]entities.Guest, error)
+
+	// GetGuestById retrieves a guest and its passes from the database.
+	//
+	// Parameters:
+	//   - id: the ID of the guest.
+	//
+	// Return type:
+	//   - *entities.Guest: the guest with the given ID.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetGuestById(ctx context.Context, id uint) (*entities.Guest, error)
+
+	// UpdateGuest updates a guest in the database.
+	//
+	// Parameters:
+	//   - id: the ID of the guest to be updated.
+	//   - g: the updated guest data.
+	//
+	// Return type:
+	//   - *entities.Guest: the updated guest.
+	//   - error: an error if the update process encounters any issues.
+	UpdateGuest(ctx context.Context, id uint, g *entities.UpdateGuest) (*entities.Guest, error)
+
+	// DeleteGuest deletes a guest and its passes from the database.
+	//
+	// Parameters:
+	//   - id: the ID of the guest to be deleted.
+	//
+	// Return type:
+	//   - error: an error if the deletion process encounters any issues.
+	DeleteGuest(ctx context.Context, id uint) error
+
+	// CreateGuestPass creates a new pass for a guest.
+	//
+	// Parameters:
+	//   - guest_id: the ID of the guest.
+	//   - p: the pass entity to be created.
+	//
+	// Return type:
+	//   - error: an error if the creation process encounters any issues.
+	CreateGuestPass(ctx context.Context, guest_id uint, p *entities.GuestPass) error
+
+	// GetGuestPasses retrieves all passes of a guest.
+	//
+	// Parameters:
+	//   - guest_id: the ID of the guest.
+	//
+	// Return type:
+	//   - []entities.GuestPass: a slice of GuestPass entities.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetGuestPasses(ctx context.Context, guest_id uint) ([]entities.GuestPass, error)
+
+	// RegisterVisit uses one visit of a guest pass.
+	// 		Note: the pass must be valid now and have visits left.
+	//
+	// Parameters:
+	//   - guest_id: the ID of the guest.
+	//   - pass_id: the ID of the pass.
+	//
+	// Return type:
+	//   - *entities.GuestPass: the updated pass.
+	//   - error: an error if the pass can't be used.
+	RegisterVisit(ctx context.Context, guest_id uint, pass_id uint) (*entities.GuestPass, error)
+
+	// ConvertGuest promotes a guest into a full member.
+	// 		Note: name, surname and contacts are taken from the guest when missing.
+	// 		Note: a guest is converted once, the member is created only if the guest is still not converted.
+	//
+	// Parameters:
+	//   - guest: the guest to convert.
+	//   - m: the member entity to be created, already validated.
+	//
+	// Return type:
+	//   - error: an error if the conversion process encounters any issues.
+	ConvertGuest(ctx context.Context, guest *entities.Guest, m *entities.Member) error
+}
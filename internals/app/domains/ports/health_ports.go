@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+)
+
+type HealthServices interface {
+
+	// CheckReadiness checks that the database and the cache can be reached.
+	//
+	// Parameters:
+	//   - ctx: the context bounding the time of the checks.
+	//
+	// Returns:
+	//   - *entities.Health: the result of each check, down if any failed.
+	CheckReadiness(ctx context.Context) *entities.Health
+}
@@ -0,0 +1,92 @@
+package ports
+
+import "context"
+
+import "github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+
+type HouseholdServices interface {
+
+	// CreateHousehold creates a new household with its primary payer.
+	// 		Note: the payer must be an adult member and joins the household.
+	//
+	// Parameters:
+	//   - h: the household entity to be created.
+	//
+	// Return type:
+	//   - error: an error if the creation process encounters any issues.
+	CreateHousehold(ctx context.Context, h *entities.Household) error
+
+	// GetAllHouseholds retrieves all households from the database.
+	//
+	// Return type:
+	//   - []entities.Household: a slice of Household entities.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetAllHouseholds(ctx context.Context) ([]entities.Household, error)
+
+	// GetHouseholdById retrieves a household and its members from the database.
+	//
+	// Parameters:
+	//   - id: the ID of the household.
+	//
+	// Return type:
+	//   - *entities.Household: the household with the given ID.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetHouseholdById(ctx context.Context, id uint) (*entities.Household, error)
+
+	// UpdateHousehold updates a household in the database.
+	// 		Note: the new payer must be an adult member of the household.
+	// 		Note: the members inheriting the contacts and address get the ones of the new payer.
+	//
+	// Parameters:
+	//   - id: the ID of the household to be updated.
+	//   - h: the updated household data.
+	//
+	// Return type:
+	//   - *entities.Household: the updated household.
+	//   - error: an error if the update process encounters any issues.
+	UpdateHousehold(ctx context.Context, id uint, h *entities.UpdateHousehold) (*entities.Household, error)
+
+	// DeleteHousehold deletes a household, its members become standalone.
+	//
+	// Parameters:
+	//   - id: the ID of the household to be deleted.
+	//
+	// Return type:
+	//   - error: an error if the deletion process encounters any issues.
+	DeleteHousehold(ctx context.Context, id uint) error
+
+	// AddHouseholdMember adds an existing member to a household.
+	// 		Note: the payer contacts and address are copied when inherited, and follow the payer.
+	// 		Note: minors need the guardian_consent of the payer, or of the adult member given in guardian_id.
+	//
+	// Parameters:
+	//   - id: the ID of the household.
+	//   - hm: the member to add and its options.
+	//
+	// Return type:
+	//   - *entities.Member: the updated member.
+	//   - error: an error if the process encounters any issues.
+	AddHouseholdMember(ctx context.Context, id uint, hm *entities.HouseholdMember) (*entities.Member, error)
+
+	// RemoveHouseholdMember removes a member from a household.
+	// 		Note: the payer can't be removed.
+	//
+	// Parameters:
+	//   - id: the ID of the household.
+	//   - member_id: the ID of the member to remove.
+	//
+	// Return type:
+	//   - error: an error if the process encounters any issues.
+	RemoveHouseholdMember(ctx context.Context, id uint, member_id uint) error
+
+	// PrepareHouseholdMember prepares a new member joining a household on creation.
+	// 		Note: missing contacts and address are inherited from the payer, and follow the payer.
+	// 		Note: minors need the guardian_consent of the payer, or of the adult member given in guardian_id.
+	//
+	// Parameters:
+	//   - m: the member entity to be created.
+	//
+	// Return type:
+	//   - error: an error if the member can't join the household.
+	PrepareHouseholdMember(ctx context.Context, m *entities.Member) error
+}
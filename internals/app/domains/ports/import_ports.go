@@ -0,0 +1,23 @@
+package ports
+
+import "context"
+
+import "github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+
+type ImportServices interface {
+
+	// ImportMembers creates the members listed in the rows of an import file.
+	// 		Note: the first row is the header, the columns are matched through the given mapping.
+	// 		Note: rows with the phone or the email of an existing member, or of a previous row, are duplicates.
+	// 		Note: the members are created only if no row has errors, all in the same transaction.
+	//
+	// Parameters:
+	//   - rows: the rows of the file.
+	//   - columns: the column headers of each field.
+	//   - dryRun: if true, the rows are only validated.
+	//
+	// Return type:
+	//   - *entities.ImportReport: the members and the errors of each row.
+	//   - error: an error if the header is not valid or the import process encounters any issues.
+	ImportMembers(ctx context.Context, rows [][]string, columns entities.ImportColumns, dryRun bool) (*entities.ImportReport, error)
+}
@@ -1,6 +1,11 @@
 package ports
 
-import "github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+import (
+	"context"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+)
 
 type MemberServices interface {
 
@@ -11,7 +16,7 @@ type MemberServices interface {
 	//
 	// Return type:
 	//   - error
-	CreateMember(m *entities.Member) error
+	CreateMember(ctx context.Context, m *entities.Member) error
 
 	// UpdateMember updates a member in the database.
 	// 		Note: It updates the member only and not its associated entities.
@@ -23,7 +28,7 @@ type MemberServices interface {
 	// Return type:
 	//   - error: an error if the update process encounters any issues.
 	//
-	UpdateMember(id uint, m *entities.UpdateMember) error
+	UpdateMember(ctx context.Context, id uint, m *entities.UpdateMember) error
 
 	// GetAllMembers retrieves all members from the database.
 	// 		Note: all members are returned regardless of their subscription status.
@@ -31,7 +36,7 @@ type MemberServices interface {
 	// Return type:
 	//   - []entities.Member: a slice of Member entities representing all members.
 	//   - error: an error if the retrieval process encounters any issues.
-	GetAllMembers() ([]entities.Member, error)
+	GetAllMembers(ctx context.Context) ([]entities.Member, error)
 
 	// GetMemberById retrieves a member from the database by their ID.
 	// 		Note: only active subscriptions is returned for the member.
@@ -42,7 +47,7 @@ type MemberServices interface {
 	//   - entities.Member: the member entity representing the member with the given ID.
 	//   - error: an error if the retrieval process encounters any issues.
 	//
-	GetMemberById(id uint) (entities.Member, error)
+	GetMemberById(ctx context.Context, id uint) (entities.Member, error)
 
 	// DeleteMember deletes a member from the database.
 	//		Note: It deletes the member and its associated entities.
@@ -52,18 +57,21 @@ type MemberServices interface {
 	// Return type:
 	//   - error: an error if the deletion process encounters any issues.
 	//
-	DeleteMember(id uint) error
+	DeleteMember(ctx context.Context, id uint) error
 
 	// CreateSubscription creates a new subscription for a given member ID.
+	// 		Note: overlaps with the other subscriptions of the member are handled by the policy.
+	// 		Note: an active subscription can't be already expired.
 	//
 	// Parameters:
 	// - user_id: the ID of the member.
 	// - subscription: the subscription entity to be created.
+	// - policy: reject the overlapping subscription or queue it after the current ones, activated when it starts.
 	//
 	// Return type:
-	// - error: an error if the creation process encounters any issues.
+	// - error: entities.ValidationErrors if the period is not valid, or an error if the creation process encounters any issues.
 	//
-	CreateMemberSubscription(user_id uint, subscription *entities.Subscription) error
+	CreateMemberSubscription(ctx context.Context, user_id uint, subscription *entities.Subscription, policy entities.OverlapPolicy) error
 
 	// GetAllSubscriptions retrieves all subscriptions for a given member ID.
 	//
@@ -74,7 +82,7 @@ type MemberServices interface {
 	// - []entities.Subscription: a slice of Subscription entities representing all subscriptions.
 	// - error: an error if the retrieval process encounters any issues.
 	//
-	GetAllSubscriptions(id uint) ([]entities.Subscription, error)
+	GetAllSubscriptions(ctx context.Context, id uint) ([]entities.Subscription, error)
 
 	// GetMembersBySubscription retrieves all subscriptions for a given member ID and subscription ID.
 	//
@@ -86,9 +94,11 @@ type MemberServices interface {
 	// - []entities.Subscription: a slice of Subscription entities representing all subscriptions.
 	// - error: an error if the retrieval process encounters any issues.
 	//
-	GetSubscriptionById(id uint, sub_id uint) ([]entities.Subscription, error)
+	GetSubscriptionById(ctx context.Context, id uint, sub_id uint) ([]entities.Subscription, error)
 
 	// UpdateSubscription updates a subscription for a given user and subscription ID.
+	// 		Note: overlapping subscriptions are rejected.
+	// 		Note: the price is before discounts, the family-plan and the redeemed promotion discounts are applied again.
 	//
 	// Parameters:
 	// - user_id: the ID of the user.
@@ -97,8 +107,8 @@ type MemberServices interface {
 	//
 	// Return type:
 	// - []entities.Subscription: a slice of entities.Subscription representing the updated subscriptions.
-	// - error: an error if the update process encounters any issues.
-	UpdateSubscription(user_id uint, sub_id uint, subscription *entities.UpdateSubscription) ([]entities.Subscription, error)
+	// - error: entities.ValidationErrors if the period is not valid, or an error if the update process encounters any issues.
+	UpdateSubscription(ctx context.Context, user_id uint, sub_id uint, subscription *entities.UpdateSubscription) ([]entities.Subscription, error)
 
 	// DeleteSubscription deletes a subscription for a given user and subscription ID.
 	//
@@ -109,5 +119,99 @@ type MemberServices interface {
 	// Return type:
 	// - error: an error if the deletion process encounters any issues.
 	//
-	DeleteSubscription(user_id uint, sub_id uint) error
-}
\ No newline at end of file
+	DeleteSubscription(ctx context.Context, user_id uint, sub_id uint) error
+
+	// RenewSubscription creates the subscription following the given one.
+	// 		Note: the new subscription starts at the previous EndDate with the same type.
+	// 		Note: a subscription can be renewed only once.
+	//
+	// Parameters:
+	// - user_id: the ID of the member.
+	// - sub_id: the ID of the subscription to renew.
+	// - renew: the renewal options, a zero price keeps the previous one.
+	//
+	// Return type:
+	// - *entities.Subscription: the created subscription.
+	// - error: an error if the renewal process encounters any issues.
+	//
+	RenewSubscription(ctx context.Context, user_id uint, sub_id uint, renew *entities.RenewSubscription) (*entities.Subscription, error)
+
+	// ProcessAutoRenewals renews every auto-renew subscription ending before the given time and not renewed yet.
+	// 		Note: the subscriptions already deactivated as expired are renewed too.
+	//
+	// Parameters:
+	// - before: subscriptions ending before this time are renewed.
+	//
+	// Return type:
+	// - []entities.Subscription: the renewals created.
+	// - error: an error if the renewal process encounters any issues.
+	//
+	ProcessAutoRenewals(ctx context.Context, before time.Time) ([]entities.Subscription, error)
+
+	// RefreshSubscriptionsStatus activates the renewals and the queued subscriptions started before now
+	// and deactivates the expired ones.
+	//
+	// Parameters:
+	// - now: the reference time.
+	//
+	// Return type:
+	// - []entities.Subscription: the subscriptions deactivated as expired.
+	// - error: an error if the update process encounters any issues.
+	//
+	RefreshSubscriptionsStatus(ctx context.Context, now time.Time) ([]entities.Subscription, error)
+
+	// GetDeletedMembers retrieves all deleted members from the database.
+	//
+	// Return type:
+	// - []entities.Member: a slice of the deleted Member entities with their contacts and address.
+	// - error: an error if the retrieval process encounters any issues.
+	//
+	GetDeletedMembers(ctx context.Context) ([]entities.Member, error)
+
+	// RestoreMember restores a deleted member.
+	// 		Note: the contacts, address and subscriptions deleted with the member are restored too.
+	//
+	// Parameters:
+	// - id: the ID of the member to be restored.
+	//
+	// Return type:
+	// - error: an error if the restoring process encounters any issues.
+	//
+	RestoreMember(ctx context.Context, id uint) error
+
+	// GetDeletedSubscriptions retrieves the deleted subscriptions of a member.
+	//
+	// Parameters:
+	// - user_id: the ID of the member.
+	//
+	// Return type:
+	// - []entities.Subscription: a slice of the deleted Subscription entities.
+	// - error: an error if the retrieval process encounters any issues.
+	//
+	GetDeletedSubscriptions(ctx context.Context, user_id uint) ([]entities.Subscription, error)
+
+	// RestoreSubscription restores a deleted subscription of a member.
+	// 		Note: subscriptions overlapping the current ones are not restored.
+	//
+	// Parameters:
+	// - user_id: the ID of the member.
+	// - sub_id: the ID of the subscription to be restored.
+	//
+	// Return type:
+	// - error: entities.ValidationErrors if the subscription overlaps, or an error if the restoring process encounters any issues.
+	//
+	RestoreSubscription(ctx context.Context, user_id uint, sub_id uint) error
+
+	// PurgeDeleted permanently deletes the members and subscriptions deleted before the given time.
+	// 		Note: consents and notifications of the members are deleted, guests and redemptions are kept without them,
+	// 		and their households get another adult payer or are deleted.
+	//
+	// Parameters:
+	// - before: records deleted before this time are purged.
+	//
+	// Return type:
+	// - int64: the number of purged records.
+	// - error: an error if the purge process encounters any issues.
+	//
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
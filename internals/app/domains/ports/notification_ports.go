@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+)
+
+type NotificationServices interface {
+
+	// SendNotifications sends the notifications of the rules due at the given time.
+	// 		Note: a notification is sent once per rule, occurrence, member and channel, failed ones are retried.
+	// 		Note: members without the privacy consent, or the consent required by the rule, are skipped.
+	// 		Note: the channels a member refused in the contact preferences are skipped.
+	//
+	// Parameters:
+	//   - rules: the rules to run.
+	//   - channels: the channels to send the notifications on.
+	//   - now: the time of the run.
+	//
+	// Return type:
+	//   - int: the number of notifications sent.
+	//   - error: an error if the process encounters any issues.
+	SendNotifications(ctx context.Context, rules []entities.NotificationRule, channels []NotificationChannel, now time.Time) (int, error)
+
+	// GetMemberNotifications retrieves the notifications sent to a member.
+	//
+	// Parameters:
+	//   - member_id: the ID of the member.
+	//
+	// Return type:
+	//   - []entities.Notification: the notifications, latest first.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetMemberNotifications(ctx context.Context, member_id uint) ([]entities.Notification, error)
+
+	// UpdatePreferences updates the channels a member accepts to be contacted on.
+	//
+	// Parameters:
+	//   - member_id: the ID of the member.
+	//   - preferences: the preferences to update, nil values are kept.
+	//
+	// Return type:
+	//   - *entities.Contacts: the updated contacts of the member.
+	//   - error: an error if the update process encounters any issues.
+	UpdatePreferences(ctx context.Context, member_id uint, preferences *entities.NotificationPreferences) (*entities.Contacts, error)
+}
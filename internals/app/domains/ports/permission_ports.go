@@ -1,6 +1,7 @@
 package ports
 
 import (
+	"context"
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 	"github.com/gofiber/fiber/v2"
 )
@@ -13,9 +14,9 @@ type PermissionsServices interface {
 	//   - p: a pointer to the Permissions entity representing the permission to be validated.
 	//
 	// Returns:
-	//   - error: an error if the permission validation fails, nil otherwise.
+	//   - error: entities.ValidationErrors if the permission is not valid, or an error if the validation process encounters any issues.
 	//
-	ValidateNewPermission(p *entities.Permissions) error
+	ValidateNewPermission(ctx context.Context, p *entities.Permissions) error
 
 	// ValidateUpdatePermission checks if an updated permission is valid.
 	//
@@ -23,7 +24,7 @@ type PermissionsServices interface {
 	//   - p: a pointer to the UpdatePermissions entity representing the permission to be validated.
 	//
 	// Returns:
-	//   - error: an error if the permission validation fails, nil otherwise.
+	//   - error: entities.ValidationErrors if the permission is not valid.
 	//
 	ValidateUpdatePermission(p *entities.UpdatePermissions) error
 
@@ -35,7 +36,7 @@ type PermissionsServices interface {
 	// Returns:
 	//   - error: an error if the permission creation fails, nil otherwise.
 	//
-	CreatePermission(p *entities.Permissions) error
+	CreatePermission(ctx context.Context, p *entities.Permissions) error
 
 	// GetPermission retrieves a permission from the system by its ID.
 	//
@@ -46,7 +47,7 @@ type PermissionsServices interface {
 	//   - *entities.Permissions: a pointer to the Permissions entity representing the retrieved permission, or nil if not found.
 	//   - error: an error if the permission retrieval fails, nil otherwise.
 	//
-	GetPermission(id uint) (*entities.Permissions, error)
+	GetPermission(ctx context.Context, id uint) (*entities.Permissions, error)
 
 	// GetAllPermissions retrieves all permissions from the system.
 	//
@@ -54,7 +55,7 @@ type PermissionsServices interface {
 	//   - []entities.Permissions: a slice of Permissions entities representing all permissions in the system.
 	//   - error: an error if the permission retrieval fails, nil otherwise.
 	//
-	GetAllPermissions() ([]entities.Permissions, error)
+	GetAllPermissions(ctx context.Context) ([]entities.Permissions, error)
 
 	// GetPermissionsByRole retrieves all permissions for a specific role from the system.
 	//
@@ -65,7 +66,7 @@ type PermissionsServices interface {
 	//   - []entities.Permissions: a slice of Permissions entities representing all permissions for the specified role.
 	//   - error: an error if the permission retrieval fails, nil otherwise.
 	//
-	GetPermissionsByRole(roleId uint) ([]entities.Permissions, error)
+	GetPermissionsByRole(ctx context.Context, roleId uint) ([]entities.Permissions, error)
 
 	// GetPermissionsByTable retrieves all permissions for a specific table from the system.
 	//
@@ -76,7 +77,7 @@ type PermissionsServices interface {
 	//   - []entities.Permissions: a slice of Permissions entities representing all permissions for the specified table.
 	//   - error: an error if the permission retrieval fails, nil otherwise.
 	//
-	GetPermissionsByTable(table string) ([]entities.Permissions, error)
+	GetPermissionsByTable(ctx context.Context, table string) ([]entities.Permissions, error)
 
 	// UpdatePermission updates a permission in the system by its ID.
 	//
@@ -88,7 +89,7 @@ type PermissionsServices interface {
 	//   - *entities.Permissions: a pointer to the Permissions entity representing the updated permission, or nil if not found.
 	//   - error: an error if the permission update fails, nil otherwise.
 	//
-	UpdatePermission(id uint, p *entities.UpdatePermissions) (*entities.Permissions, error)
+	UpdatePermission(ctx context.Context, id uint, p *entities.UpdatePermissions) (*entities.Permissions, error)
 
 	// DeletePermission deletes a permission from the system by its ID.
 	//
@@ -98,7 +99,7 @@ type PermissionsServices interface {
 	// Returns:
 	//   - error: an error if the permission deletion fails, nil otherwise.
 	//
-	DeletePermission(id uint) error
+	DeletePermission(ctx context.Context, id uint) error
 
 	// HasPermission checks if a permission exists for a specific role and table.
 	//
@@ -111,7 +112,7 @@ type PermissionsServices interface {
 	//   - uint: the permission value for the specified role and table.
 	//   - error: an error if the permission check fails, nil otherwise.
 	//
-	HasPermission(table_name string, roleId uint, action string) (uint, error)
+	HasPermission(ctx context.Context, table_name string, roleId uint, action string) (uint, error)
 
 	// CheckPermissionExists checks if a permission exists for a specific role and table.
 	//
@@ -123,15 +124,16 @@ type PermissionsServices interface {
 	//   - bool: true if the permission exists, false otherwise.
 	//   - error: an error if the permission check fails, nil otherwise.
 	//
-	CheckPermissionExists(table string, roleId uint) (bool, error)
+	CheckPermissionExists(ctx context.Context, table string, roleId uint) (bool, error)
 
 	// GetTableList returns a list of all tables in the system.
+	// 		Note: the list is read once, the tables change only with the migrations at startup.
 	//
 	// Returns:
 	//   - []string: a slice of strings representing the list of tables.
 	//   - error: an error if the table list retrieval fails, nil otherwise.
 	//
-	GetTableList() ([]string, error)
+	GetTableList(ctx context.Context) ([]string, error)
 
 	// GetRequestedActionAndTable retrieves the requested action and table from the given fiber context.
 	//
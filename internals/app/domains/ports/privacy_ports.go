@@ -0,0 +1,76 @@
+package ports
+
+import "context"
+
+import "github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+
+type PrivacyServices interface {
+
+	// ExportMember collects everything held about a member.
+	// 		Note: deleted members and subscriptions are exported too.
+	// 		Note: the changes recorded about the member, its contacts, address, guest profile and consents are exported.
+	//
+	// Parameters:
+	//   - id: the ID of the member.
+	//
+	// Return type:
+	//   - *entities.MemberExport: the data held about the member.
+	//   - error: an error if the export process encounters any issues.
+	ExportMember(ctx context.Context, id uint) (*entities.MemberExport, error)
+
+	// EraseMember anonymizes the personal data of a member.
+	// 		Note: subscriptions and promotion redemptions are kept for tax retention.
+	// 		Note: the changes recorded about the member, its contacts, address, guest profile and consents are cleared.
+	//
+	// Parameters:
+	//   - id: the ID of the member.
+	//
+	// Return type:
+	//   - error: an error if the erasure process encounters any issues.
+	EraseMember(ctx context.Context, id uint) error
+
+	// GetMemberConsents retrieves the consents of a member, revoked ones included.
+	//
+	// Parameters:
+	//   - member_id: the ID of the member.
+	//
+	// Return type:
+	//   - []entities.Consent: the consents of the member, latest first.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetMemberConsents(ctx context.Context, member_id uint) ([]entities.Consent, error)
+
+	// GiveConsent records a consent given by a member.
+	// 		Note: an active consent of the same type is revoked, so only the latest policy version is active.
+	//
+	// Parameters:
+	//   - consent: the consent to record, with MemberID and CollectedBy set.
+	//
+	// Return type:
+	//   - error: an error if the same policy version is already accepted or the process encounters any issues.
+	GiveConsent(ctx context.Context, consent *entities.Consent) error
+
+	// RevokeConsent revokes a consent of a member.
+	// 		Note: the consent is kept as proof of the processing done before the revocation.
+	//
+	// Parameters:
+	//   - member_id: the ID of the member.
+	//   - consent_id: the ID of the consent.
+	//   - user_id: the ID of the staff user recording the revocation.
+	//
+	// Return type:
+	//   - *entities.Consent: the revoked consent.
+	//   - error: an error if the consent is already revoked or the process encounters any issues.
+	RevokeConsent(ctx context.Context, member_id uint, consent_id uint, user_id uint) (*entities.Consent, error)
+
+	// HasConsent checks if a member has an active consent of the given type.
+	// 		Note: every outbound communication must check it before contacting a member.
+	//
+	// Parameters:
+	//   - member_id: the ID of the member.
+	//   - consentType: the type of the consent.
+	//
+	// Return type:
+	//   - bool: true if the consent is active.
+	//   - error: an error if the check encounters any issues.
+	HasConsent(ctx context.Context, member_id uint, consentType string) (bool, error)
+}
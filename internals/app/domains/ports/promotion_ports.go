@@ -0,0 +1,74 @@
+package ports
+
+import "context"
+
+import "github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+
+type PromotionServices interface {
+
+	// CreatePromotion creates a new promotion in the database.
+	// 		Note: the code is stored uppercase.
+	//
+	// Parameters:
+	//   - p: the promotion entity to be created.
+	//
+	// Return type:
+	//   - error: an error if the creation process encounters any issues.
+	CreatePromotion(ctx context.Context, p *entities.Promotion) error
+
+	// GetAllPromotions retrieves all promotions from the database.
+	//
+	// Return type:
+	//   - []entities.Promotion: a slice of Promotion entities.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetAllPromotions(ctx context.Context) ([]entities.Promotion, error)
+
+	// GetPromotion retrieves a promotion from the database by its ID.
+	//
+	// Parameters:
+	//   - id: the ID of the promotion.
+	//
+	// Return type:
+	//   - *entities.Promotion: the promotion with the given ID.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetPromotion(ctx context.Context, id uint) (*entities.Promotion, error)
+
+	// UpdatePromotion updates a promotion in the database.
+	// 		Note: the promotion is validated with the stored values merged.
+	// 		Note: a zero max_uses removes the limit and empty eligible_types allow every type.
+	//
+	// Parameters:
+	//   - id: the ID of the promotion to be updated.
+	//   - p: the updated promotion data.
+	//
+	// Return type:
+	//   - *entities.Promotion: the updated promotion.
+	//   - error: a validation error if the updated promotion is not valid, or an error if the update process encounters any issues.
+	UpdatePromotion(ctx context.Context, id uint, p *entities.UpdatePromotion) (*entities.Promotion, error)
+
+	// DeletePromotion deletes a promotion from the database.
+	//
+	// Parameters:
+	//   - id: the ID of the promotion to be deleted.
+	//
+	// Return type:
+	//   - error: an error if the deletion process encounters any issues.
+	DeletePromotion(ctx context.Context, id uint) error
+
+	// GetPromotionRedemptions retrieves the redemptions of a promotion.
+	//
+	// Parameters:
+	//   - id: the ID of the promotion.
+	//
+	// Return type:
+	//   - []entities.PromotionRedemption: a slice of PromotionRedemption entities.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetPromotionRedemptions(ctx context.Context, id uint) ([]entities.PromotionRedemption, error)
+
+	// GetRedemptionsReport sums up the redemptions of every promotion.
+	//
+	// Return type:
+	//   - []entities.PromotionReport: the redemptions, discount and revenue per promotion.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetRedemptionsReport(ctx context.Context) ([]entities.PromotionReport, error)
+}
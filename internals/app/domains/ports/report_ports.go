@@ -0,0 +1,71 @@
+package ports
+
+import "context"
+
+import "github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+
+type ReportServices interface {
+
+	// GetRevenueReport computes the monthly recurring revenue at the end of each month.
+	// 		Note: the price of each subscription is spread over the months it lasts.
+	// 		Note: the reports are cached for a few minutes.
+	//
+	// Parameters:
+	//   - period: the months of the report.
+	//
+	// Return type:
+	//   - *entities.Report: the report, with a []entities.MonthlyRevenue.
+	//   - error: an error if the report can't be computed.
+	GetRevenueReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error)
+
+	// GetGrowthReport computes the members who joined and left in each month.
+	// 		Note: a member joins with the first subscription and leaves when the last one ends.
+	//
+	// Parameters:
+	//   - period: the months of the report.
+	//
+	// Return type:
+	//   - *entities.Report: the report, with a []entities.MemberGrowth.
+	//   - error: an error if the report can't be computed.
+	GetGrowthReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error)
+
+	// GetActiveMembersReport computes the members with a subscription at the end of each month.
+	//
+	// Parameters:
+	//   - period: the months of the report.
+	//
+	// Return type:
+	//   - *entities.Report: the report, with a []entities.ActiveMembers.
+	//   - error: an error if the report can't be computed.
+	GetActiveMembersReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error)
+
+	// GetSubscriptionMixReport computes the share of each subscription type started in the period.
+	//
+	// Parameters:
+	//   - period: the months of the report.
+	//
+	// Return type:
+	//   - *entities.Report: the report, with a []entities.SubscriptionMix.
+	//   - error: an error if the report can't be computed.
+	GetSubscriptionMixReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error)
+
+	// GetLifetimeReport computes the average lifetime of the members who joined in the period.
+	//
+	// Parameters:
+	//   - period: the months of the report.
+	//
+	// Return type:
+	//   - *entities.Report: the report, with an entities.MemberLifetime.
+	//   - error: an error if the report can't be computed.
+	GetLifetimeReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error)
+
+	// GetRetentionReport computes the retention of the members who joined in each month.
+	//
+	// Parameters:
+	//   - period: the months of the report.
+	//
+	// Return type:
+	//   - *entities.Report: the report, with a []entities.RetentionCohort.
+	//   - error: an error if the report can't be computed.
+	GetRetentionReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error)
+}
@@ -1,5 +1,7 @@
 package ports
 
+import "context"
+
 import "github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 
 type RolesServices interface {
@@ -12,13 +14,13 @@ type RolesServices interface {
 	// Returns:
 	// - error: An error object if there was an issue creating the role, otherwise nil.
 	//
-	CreateRole(role *entities.Roles) error
+	CreateRole(ctx context.Context, role *entities.Roles) error
 
 	// GetAllRoles retrieves all roles from the system.
 	//
 	// It returns a slice of entities.Roles and an error if any occurred.
 	//
-	GetAllRoles() ([]entities.Roles, error)
+	GetAllRoles(ctx context.Context) ([]entities.Roles, error)
 
 	// GetRole retrieves a role from the system by its ID.
 	//
@@ -29,7 +31,7 @@ type RolesServices interface {
 	// - *entities.Roles: A pointer to the Roles struct representing the retrieved role, or nil if not found.
 	// - error: An error object if there was an issue retrieving the role, otherwise nil.
 	//
-	GetRole(id uint) (*entities.Roles, error)
+	GetRole(ctx context.Context, id uint) (*entities.Roles, error)
 
 	// GetRoleByName retrieves a role from the system by its name.
 	//
@@ -40,7 +42,7 @@ type RolesServices interface {
 	// - *entities.Roles: A pointer to the Roles struct representing the retrieved role, or nil if not found.
 	// - error: An error object if there was an issue retrieving the role, otherwise nil.
 	//
-	GetRoleByName(name string) (*entities.Roles, error)
+	GetRoleByName(ctx context.Context, name string) (*entities.Roles, error)
 
 	// GetRolePermissions retrieves the permissions of a role from the system by its ID.
 	//
@@ -51,7 +53,7 @@ type RolesServices interface {
 	// - []entities.Permissions: A slice of entities.Permissions representing the permissions of the role.
 	// - error: An error object if there was an issue retrieving the permissions, otherwise nil.
 	//
-	GetRolePermissions(roleID uint) ([]entities.Permissions, error)
+	GetRolePermissions(ctx context.Context, roleID uint) ([]entities.Permissions, error)
 
 	// UpdateRole updates a role in the system by its ID.
 	//
@@ -62,7 +64,7 @@ type RolesServices interface {
 	// Returns:
 	// - error: An error object if there was an issue updating the role, otherwise nil.
 	//
-	UpdateRole(id uint, role *entities.UpdateRoles) error
+	UpdateRole(ctx context.Context, id uint, role *entities.UpdateRoles) error
 
 	// DeleteRole deletes a role from the system by its ID.
 	//
@@ -72,7 +74,7 @@ type RolesServices interface {
 	// Returns:
 	// - error: An error object if there was an issue deleting the role, otherwise nil.
 	//
-	DeleteRole(id uint) error
+	DeleteRole(ctx context.Context, id uint) error
 
 	// CreateSystemRole creates a new system role in the system.
 	//
@@ -87,7 +89,7 @@ type RolesServices interface {
 	// - *entities.Roles: A pointer to the Roles struct representing the system role, or nil if not found.
 	// - error: An error object if there was an issue retrieving the system role, otherwise nil.
 	//
-	GetSystemRole() (*entities.Roles, error)
+	GetSystemRole(ctx context.Context) (*entities.Roles, error)
 
 	// IsSystemRole checks if a role is a system role.
 	//
@@ -97,5 +99,5 @@ type RolesServices interface {
 	// Returns:
 	// - bool: true if the role is a system role, false otherwise.
 	//
-	IsSystemRole(roleID uint) bool
+	IsSystemRole(ctx context.Context, roleID uint) bool
 }
@@ -1,6 +1,7 @@
 package ports
 
 import (
+	"context"
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 	"github.com/gofiber/fiber/v2"
 )
@@ -25,7 +26,7 @@ type UserServices interface {
 	//
 	// Return values:
 	//   - error: an error if any occurs during the comparison process.
-	ComparePassword(userID uint, password string) error
+	ComparePassword(ctx context.Context, userID uint, password string) error
 
 	// CreateUser creates a new user in the database.
 	//
@@ -33,7 +34,7 @@ type UserServices interface {
 	//   - user: the user entity to be created.
 	//
 	// Return type: error.
-	CreateUser(user *entities.User) error
+	CreateUser(ctx context.Context, user *entities.User) error
 
 	// DeleteUser deletes a user from the database.
 	//
@@ -41,14 +42,14 @@ type UserServices interface {
 	//   - u: the user entity to be deleted.
 	//
 	// Return type: error.
-	DeleteUser(u *entities.User) error
+	DeleteUser(ctx context.Context, u *entities.User) error
 
 	// GetAllUsers retrieves all users from the database.
 	//
 	// Return type:
 	//   - []entities.User
 	//
-	GetAllUsers() ([]entities.User, error)
+	GetAllUsers(ctx context.Context) ([]entities.User, error)
 
 	// GetUserById retrieves a user from the database by their ID.
 	//
@@ -57,7 +58,7 @@ type UserServices interface {
 	//
 	// Return type: error. If the user is found, the User entity will be populated with the user's data.
 	//               If the user is not found, an error will be returned.
-	GetUserById(u *entities.User) error
+	GetUserById(ctx context.Context, u *entities.User) error
 
 	// GetUserForLogin retrieves a user from the database for login purposes based on the provided ID.
 	//
@@ -67,7 +68,7 @@ type UserServices interface {
 	// Return type:
 	//   - *entities.User
 	//
-	GetUserForLogin(id uint) (*entities.User, error)
+	GetUserForLogin(ctx context.Context, id uint) (*entities.User, error)
 
 	// GetUserByEmail retrieves a user from the database by their email.
 	//
@@ -75,7 +76,7 @@ type UserServices interface {
 	//   - email: the email of the user to retrieve.
 	//
 	// Return type: *entities.User, error.
-	GetUserByEmail(email string) (*entities.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*entities.User, error)
 
 	// UpdateUser updates a user in the database.
 	//
@@ -84,7 +85,7 @@ type UserServices interface {
 	//   - u: the updated user data.
 	//
 	// Return type: *entities.User, error.
-	UpdateUser(id uint, u *entities.UpdateUser) (*entities.User, error)
+	UpdateUser(ctx context.Context, id uint, u *entities.UpdateUser) (*entities.User, error)
 
 	// SetSession sets a session for a user in the database.
 	//
@@ -95,6 +96,20 @@ type UserServices interface {
 	// Return type: error.
 	SetSession(c *fiber.Ctx, user *entities.User) error
 
+	// LoginFailed records a login with a wrong email or password.
+	//
+	// Parameters:
+	//   - c: the fiber.Ctx object representing the HTTP request context.
+	//   - email: the email of the login.
+	LoginFailed(c *fiber.Ctx, email string)
+
+	// CountSessions counts the sessions not expired of every user.
+	//
+	// Returns:
+	//   - int: the number of sessions.
+	//   - error: an error if the sessions could not be counted.
+	CountSessions(ctx context.Context) (int, error)
+
 	// GetSessionByToken retrieves a session from the database by its token.
 	//
 	// Parameters:
@@ -103,7 +118,7 @@ type UserServices interface {
 	// Returns:
 	//   - *entities.Session: the session with the given token, or nil if not found.
 	//   - error: an error if the retrieval process encounters any issues.
-	GetSessionByToken(token string) (*entities.Session, error)
+	GetSessionByToken(ctx context.Context, token string) (*entities.Session, error)
 
 	// DeleteSession deletes a user session from the database by its ID.
 	//
@@ -127,5 +142,5 @@ type UserServices interface {
 	CreateSystemUser() error
 
 	// IsSystemUser checks if a user is a system user.
-	IsSystemUser(id uint) bool
+	IsSystemUser(ctx context.Context, id uint) bool
 }
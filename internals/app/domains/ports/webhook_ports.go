@@ -0,0 +1,105 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+)
+
+type WebhookServices interface {
+
+	// CreateWebhook registers a new webhook.
+	// 		Note: a random secret is generated when none is given.
+	//
+	// Parameters:
+	//   - w: the webhook entity to be created.
+	//
+	// Return type:
+	//   - error: an error if the creation process encounters any issues.
+	CreateWebhook(ctx context.Context, w *entities.Webhook) error
+
+	// GetAllWebhooks retrieves all webhooks from the database.
+	//
+	// Return type:
+	//   - []entities.Webhook: a slice of Webhook entities.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetAllWebhooks(ctx context.Context) ([]entities.Webhook, error)
+
+	// GetWebhookById retrieves a webhook from the database.
+	//
+	// Parameters:
+	//   - id: the ID of the webhook.
+	//
+	// Return type:
+	//   - *entities.Webhook: the webhook with the given ID.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetWebhookById(ctx context.Context, id uint) (*entities.Webhook, error)
+
+	// UpdateWebhook updates a webhook in the database.
+	//
+	// Parameters:
+	//   - id: the ID of the webhook to be updated.
+	//   - w: the updated webhook data.
+	//
+	// Return type:
+	//   - *entities.Webhook: the updated webhook.
+	//   - error: an error if the update process encounters any issues.
+	UpdateWebhook(ctx context.Context, id uint, w *entities.UpdateWebhook) (*entities.Webhook, error)
+
+	// DeleteWebhook deletes a webhook, its pending deliveries are not sent.
+	//
+	// Parameters:
+	//   - id: the ID of the webhook to be deleted.
+	//
+	// Return type:
+	//   - error: an error if the deletion process encounters any issues.
+	DeleteWebhook(ctx context.Context, id uint) error
+
+	// Publish queues an event for the webhooks subscribed to it.
+	// 		Note: the deliveries are sent by ProcessDeliveries.
+	//
+	// 		Note: the deliveries of an entities.MemberEvent are erased with the member.
+	//
+	// Parameters:
+	//   - ctx: the context of the change that published the event.
+	//   - event: the name of the event.
+	//   - data: the data of the event, sent as JSON.
+	//
+	// Return type:
+	//   - error: an error if the event can't be queued.
+	Publish(ctx context.Context, event string, data interface{}) error
+
+	// ProcessDeliveries sends the deliveries due at the given time.
+	// 		Note: failed deliveries are retried with an increasing delay, then marked as failed.
+	//
+	// Parameters:
+	//   - now: the time of the run.
+	//
+	// Return type:
+	//   - int: the number of delivered events.
+	//   - error: an error if the process encounters any issues.
+	ProcessDeliveries(ctx context.Context, now time.Time) (int, error)
+
+	// GetWebhookDeliveries retrieves the latest deliveries of a webhook.
+	//
+	// Parameters:
+	//   - webhook_id: the ID of the webhook.
+	//   - status: the status of the deliveries, empty for all.
+	//
+	// Return type:
+	//   - []entities.WebhookDelivery: the deliveries, latest first.
+	//   - error: an error if the retrieval process encounters any issues.
+	GetWebhookDeliveries(ctx context.Context, webhook_id uint, status string) ([]entities.WebhookDelivery, error)
+
+	// RetryDelivery queues a delivery again, restarting its retries.
+	//
+	// Parameters:
+	//   - webhook_id: the ID of the webhook.
+	//   - delivery_id: the ID of the delivery.
+	//
+	// Return type:
+	//   - *entities.WebhookDelivery: the queued delivery.
+	//   - error: an error if the delivery is already delivered or the process encounters any issues.
+	RetryDelivery(ctx context.Context, webhook_id uint, delivery_id uint) (*entities.WebhookDelivery, error)
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Maximum number of logs returned at once
+const maxAuditLogs = 500
+
+type AuditServices struct {
+	db *gorm.DB
+}
+
+func NewAuditServices(db *gorm.DB) *AuditServices {
+	return &AuditServices{
+		db: db,
+	}
+}
+
+func (a *AuditServices) LogChange(c *fiber.Ctx, action string, entity string, entityID uint, before interface{}, after interface{}) {
+	ctx, span := tracing.Start(c.UserContext(), "AuditServices.LogChange")
+	defer span.End()
+
+	audit, err := newAudit(action, entity, entityID, before, after)
+	if err != nil || audit == nil {
+		return
+	}
+	audit.IPAddress = c.IP()
+
+	// Get the actor from the session
+	if user, ok := c.Locals("user").(*entities.User); ok {
+		audit.UserID = user.ID
+	}
+	if session, ok := c.Locals("session").(*entities.Session); ok {
+		audit.IPAddress = session.IPAddress
+	}
+
+	if err := a.db.WithContext(ctx).Create(audit).Error; err != nil {
+		slog.ErrorContext(c.UserContext(), "Error saving audit", "entity", entity, "entity_id", entityID, "error", err)
+	}
+}
+
+func (a *AuditServices) LogSystemChange(action string, entity string, entityID uint, before interface{}, after interface{}) {
+	ctx, span := tracing.Start(context.Background(), "AuditServices.LogSystemChange")
+	defer span.End()
+
+	audit, err := newAudit(action, entity, entityID, before, after)
+	if err != nil || audit == nil {
+		return
+	}
+
+	if err := a.db.WithContext(ctx).Create(audit).Error; err != nil {
+		slog.Error("Error saving audit", "entity", entity, "entity_id", entityID, "error", err)
+	}
+}
+
+// newAudit returns the audit of a change, nil if nothing changed.
+func newAudit(action string, entity string, entityID uint, before interface{}, after interface{}) (*entities.Audit, error) {
+	changes, err := diffChanges(before, after)
+	if err != nil {
+		slog.Error("Error computing audit changes", "entity", entity, "entity_id", entityID, "error", err)
+		return nil, err
+	}
+
+	// Nothing changed
+	if action == entities.AuditUpdate && len(changes) == 0 {
+		return nil, nil
+	}
+
+	return &entities.Audit{
+		Action:   action,
+		Entity:   entity,
+		EntityID: entityID,
+		Changes:  changes,
+	}, nil
+}
+
+func (a *AuditServices) GetAuditLogs(ctx context.Context, filter *entities.AuditFilter) ([]entities.Audit, int64, error) {
+	ctx, span := tracing.Start(ctx, "AuditServices.GetAuditLogs")
+	defer span.End()
+
+	query := a.db.WithContext(ctx).Model(&entities.Audit{})
+
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Entity != "" {
+		query = query.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > maxAuditLogs {
+		limit = maxAuditLogs
+	}
+
+	var audits []entities.Audit
+	if err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(filter.Offset).
+		Find(&audits).
+		Error; err != nil {
+		return nil, 0, err
+	}
+
+	return audits, total, nil
+}
+
+// diffChanges returns the fields which differ between before and after,
+// compared through their JSON representation.
+func diffChanges(before interface{}, after interface{}) (map[string]entities.AuditChange, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]entities.AuditChange{}
+	for key, value := range beforeFields {
+		if _, ok := afterFields[key]; !ok && value == nil {
+			continue
+		}
+		if !reflect.DeepEqual(value, afterFields[key]) {
+			changes[key] = entities.AuditChange{Before: value, After: afterFields[key]}
+		}
+	}
+	for key, value := range afterFields {
+		if _, ok := beforeFields[key]; !ok && value != nil {
+			changes[key] = entities.AuditChange{Before: nil, After: value}
+		}
+	}
+
+	// Never record passwords and secrets
+	delete(changes, "password")
+	delete(changes, "secret")
+
+	return changes, nil
+}
+
+func toFields(data interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if data == nil || reflect.ValueOf(data).Kind() == reflect.Ptr && reflect.ValueOf(data).IsNil() {
+		return fields, nil
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(bytes, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
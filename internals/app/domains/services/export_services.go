@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"gorm.io/gorm"
+)
+
+// Rows read from the database at once
+const exportBatchSize = 500
+
+type ExportServices struct {
+	db *gorm.DB
+}
+
+func NewExportServices(db *gorm.DB) *ExportServices {
+	return &ExportServices{
+		db: db,
+	}
+}
+
+func (e *ExportServices) ExportMembers(ctx context.Context, filter *entities.MemberFilter, w ports.TableWriter) error {
+	ctx, span := tracing.Start(ctx, "ExportServices.ExportMembers")
+	defer span.End()
+
+	if err := w.WriteHeader([]string{"ID", "Nome", "Cognome", "Sesso", "Data di nascita", "Telefono", "Email", "Città", "Abbonamento", "Scadenza"}); err != nil {
+		return err
+	}
+
+	query := e.db.WithContext(ctx).
+		Preload("Contacts").
+		Preload("Address").
+		Preload("Subscription", "is_active = true")
+
+	const activeSubscription = "SELECT 1 FROM subscriptions WHERE subscriptions.user_id = members.id AND subscriptions.is_active = true AND subscriptions.deleted IS NULL"
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("members.name LIKE ? OR members.surname LIKE ?", search, search)
+	}
+	if filter.Active != nil && *filter.Active {
+		query = query.Where("EXISTS (" + activeSubscription + ")")
+	}
+	if filter.Active != nil && !*filter.Active {
+		query = query.Where("NOT EXISTS (" + activeSubscription + ")")
+	}
+	if filter.SubscriptionType != "" {
+		query = query.Where("EXISTS ("+activeSubscription+" AND subscriptions.type = ?)", filter.SubscriptionType)
+	}
+	if filter.HouseholdID != 0 {
+		query = query.Where("members.household_id = ?", filter.HouseholdID)
+	}
+
+	var members []entities.Member
+	return query.FindInBatches(&members, exportBatchSize, func(tx *gorm.DB, batch int) error {
+		for _, member := range members {
+			if err := w.WriteRow(memberRow(&member)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+func (e *ExportServices) ExportExpiringSubscriptions(ctx context.Context, from time.Time, to time.Time, w ports.TableWriter) error {
+	ctx, span := tracing.Start(ctx, "ExportServices.ExportExpiringSubscriptions")
+	defer span.End()
+
+	if err := w.WriteHeader([]string{"Membro", "Cognome", "Telefono", "Email", "Abbonamento", "Inizio", "Scadenza", "Prezzo", "Rinnovo automatico"}); err != nil {
+		return err
+	}
+
+	rows, err := e.db.WithContext(ctx).
+		Model(&entities.Subscription{}).
+		Select("subscriptions.*, members.name, members.surname, contacts.phone, contacts.email").
+		Joins("JOIN members ON members.id = subscriptions.user_id AND members.deleted_at IS NULL").
+		Joins("LEFT JOIN contacts ON contacts.id = members.id").
+		Where("subscriptions.is_active = true AND subscriptions.end_date >= ? AND subscriptions.end_date < ?", from, to).
+		Where("NOT EXISTS (SELECT 1 FROM subscriptions AS renewals WHERE renewals.previous_id = subscriptions.id AND renewals.deleted IS NULL)").
+		Order("subscriptions.end_date").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var expiring struct {
+			entities.Subscription
+			Name    string
+			Surname string
+			Phone   string
+			Email   string
+		}
+		if err := e.db.WithContext(ctx).ScanRows(rows, &expiring); err != nil {
+			return err
+		}
+
+		if err := w.WriteRow([]interface{}{
+			expiring.Name,
+			expiring.Surname,
+			expiring.Phone,
+			expiring.Email,
+			expiring.Type,
+			expiring.StartDate,
+			expiring.EndDate,
+			expiring.Price,
+			expiring.AutoRenew != nil && *expiring.AutoRenew,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (e *ExportServices) ExportRevenue(ctx context.Context, from time.Time, to time.Time, w ports.TableWriter) error {
+	ctx, span := tracing.Start(ctx, "ExportServices.ExportRevenue")
+	defer span.End()
+
+	if err := w.WriteHeader([]string{"Mese", "Abbonamento", "Abbonamenti", "Prezzo di listino", "Sconti", "Incasso"}); err != nil {
+		return err
+	}
+
+	type revenueKey struct {
+		month time.Time
+		kind  string
+	}
+	revenues := make(map[revenueKey]*entities.Revenue)
+
+	var subscriptions []entities.Subscription
+	if err := e.db.WithContext(ctx).
+		Where("start_date >= ? AND start_date < ?", from, to).
+		FindInBatches(&subscriptions, exportBatchSize, func(tx *gorm.DB, batch int) error {
+			for i := range subscriptions {
+				start := subscriptions[i].StartDate
+				key := revenueKey{
+					month: time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location()),
+					kind:  subscriptions[i].Type,
+				}
+				if revenues[key] == nil {
+					revenues[key] = &entities.Revenue{Month: key.month, Type: key.kind}
+				}
+				revenues[key].Add(&subscriptions[i])
+			}
+			return nil
+		}).
+		Error; err != nil {
+		return err
+	}
+
+	keys := make([]revenueKey, 0, len(revenues))
+	for key := range revenues {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].month.Equal(keys[j].month) {
+			return keys[i].month.Before(keys[j].month)
+		}
+		return keys[i].kind < keys[j].kind
+	})
+
+	total := &entities.Revenue{}
+	for _, key := range keys {
+		revenue := revenues[key]
+		if err := w.WriteRow([]interface{}{
+			revenue.Month.Format("01/2006"),
+			revenue.Type,
+			revenue.Subscriptions,
+			revenue.OriginalPrice,
+			revenue.Discount,
+			revenue.Price,
+		}); err != nil {
+			return err
+		}
+
+		total.Subscriptions += revenue.Subscriptions
+		total.OriginalPrice += revenue.OriginalPrice
+		total.Discount += revenue.Discount
+		total.Price += revenue.Price
+	}
+
+	return w.WriteRow([]interface{}{"Totale", "", total.Subscriptions, total.OriginalPrice, total.Discount, total.Price})
+}
+
+// memberRow returns the exported columns of a member.
+func memberRow(m *entities.Member) []interface{} {
+	var phone, email, city, subscription string
+	var endDate time.Time
+	if m.Contacts != nil {
+		phone, email = m.Contacts.Phone, m.Contacts.Email
+	}
+	if m.Address != nil {
+		city = m.Address.City
+	}
+	for _, sub := range m.Subscription {
+		if sub.EndDate.After(endDate) {
+			subscription, endDate = sub.Type, sub.EndDate
+		}
+	}
+
+	return []interface{}{m.ID, m.Name, m.Surname, m.Gender, m.DateOfBirth, phone, email, city, subscription, endDate}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"gorm.io/gorm"
+)
+
+type GuestServices struct {
+	db             *gorm.DB
+	memberServices *MemberServices
+}
+
+func NewGuestServices(db *gorm.DB, memberServices *MemberServices) *GuestServices {
+	return &GuestServices{
+		db:             db,
+		memberServices: memberServices,
+	}
+}
+
+func (g *GuestServices) CreateGuest(ctx context.Context, guest *entities.Guest) error {
+	ctx, span := tracing.Start(ctx, "GuestServices.CreateGuest")
+	defer span.End()
+
+	if err := g.checkSponsor(ctx, guest.SponsorID); err != nil {
+		return err
+	}
+
+	guest.MemberID = nil
+	return g.db.WithContext(ctx).
+		Omit("Passes").
+		Create(guest).
+		Error
+}
+
+func (g *GuestServices) GetAllGuests(ctx context.Context) ([]entities.Guest, error) {
+	ctx, span := tracing.Start(ctx, "GuestServices.GetAllGuests")
+	defer span.End()
+
+	var guests []entities.Guest
+	if err := g.db.WithContext(ctx).
+		Find(&guests).
+		Error; err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+func (g *GuestServices) GetGuestById(ctx context.Context, id uint) (*entities.Guest, error) {
+	ctx, span := tracing.Start(ctx, "GuestServices.GetGuestById")
+	defer span.End()
+
+	guest := new(entities.Guest)
+	if err := g.db.WithContext(ctx).
+		Preload("Passes").
+		First(guest, id).
+		Error; err != nil {
+		return nil, err
+	}
+	return guest, nil
+}
+
+func (g *GuestServices) UpdateGuest(ctx context.Context, id uint, guest *entities.UpdateGuest) (*entities.Guest, error) {
+	ctx, span := tracing.Start(ctx, "GuestServices.UpdateGuest")
+	defer span.End()
+
+	if err := g.checkSponsor(ctx, guest.SponsorID); err != nil {
+		return nil, err
+	}
+
+	if err := g.db.WithContext(ctx).
+		Model(entities.Guest{}).
+		Where("id = ?", id).
+		Updates(guest).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return g.GetGuestById(ctx, id)
+}
+
+func (g *GuestServices) DeleteGuest(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "GuestServices.DeleteGuest")
+	defer span.End()
+
+	guest := new(entities.Guest)
+	guest.ID = id
+	return g.db.WithContext(ctx).
+		Select("Passes").
+		Delete(guest).
+		Error
+}
+
+func (g *GuestServices) CreateGuestPass(ctx context.Context, guest_id uint, pass *entities.GuestPass) error {
+	ctx, span := tracing.Start(ctx, "GuestServices.CreateGuestPass")
+	defer span.End()
+
+	pass.GuestID = guest_id
+	pass.Visits = 0
+	return g.db.WithContext(ctx).
+		Create(pass).
+		Error
+}
+
+func (g *GuestServices) GetGuestPasses(ctx context.Context, guest_id uint) ([]entities.GuestPass, error) {
+	ctx, span := tracing.Start(ctx, "GuestServices.GetGuestPasses")
+	defer span.End()
+
+	var passes []entities.GuestPass
+	if err := g.db.WithContext(ctx).
+		Where("guest_id = ?", guest_id).
+		Order("valid_from DESC").
+		Find(&passes).
+		Error; err != nil {
+		return nil, err
+	}
+	return passes, nil
+}
+
+func (g *GuestServices) RegisterVisit(ctx context.Context, guest_id uint, pass_id uint) (*entities.GuestPass, error) {
+	ctx, span := tracing.Start(ctx, "GuestServices.RegisterVisit")
+	defer span.End()
+
+	pass := new(entities.GuestPass)
+	if err := g.db.WithContext(ctx).
+		Where("guest_id = ? AND id = ?", guest_id, pass_id).
+		First(pass).
+		Error; err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !pass.CanVisit(now) {
+		return nil, entities.NewValidationError(i18n.MsgPassUnusable)
+	}
+
+	// Use the visit only if it is still available
+	result := g.db.WithContext(ctx).
+		Model(entities.GuestPass{}).
+		Where("id = ? AND visits < max_visits", pass.ID).
+		Update("visits", gorm.Expr("visits + 1"))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, entities.NewValidationError(i18n.MsgPassUnusable)
+	}
+
+	pass.Visits++
+	return pass, nil
+}
+
+func (g *GuestServices) ConvertGuest(ctx context.Context, guest *entities.Guest, member *entities.Member) error {
+	ctx, span := tracing.Start(ctx, "GuestServices.ConvertGuest")
+	defer span.End()
+
+	if guest.MemberID != nil {
+		return entities.NewConflictError(i18n.MsgGuestAlreadyEnrolled)
+	}
+
+	// The guest is linked with the creation of the member, once
+	return g.memberServices.createMember(ctx, member, func(tx *gorm.DB) error {
+		result := tx.
+			Model(entities.Guest{}).
+			Where("id = ? AND member_id IS NULL", guest.ID).
+			Update("member_id", member.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return entities.NewConflictError(i18n.MsgGuestAlreadyEnrolled)
+		}
+		return nil
+	})
+}
+
+// checkSponsor checks that the sponsor of a guest is an existing member.
+func (g *GuestServices) checkSponsor(ctx context.Context, sponsor_id *uint) error {
+	if sponsor_id == nil {
+		return nil
+	}
+
+	if err := g.db.WithContext(ctx).First(&entities.Member{}, *sponsor_id).Error; err != nil {
+		return entities.NewValidationError(i18n.MsgReferrerInvalid)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+)
+
+func TestConvertGuestOnce(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	guests := services.NewGuestServices(db, services.NewMemberServices(db, secondary.NewEventBus()))
+
+	guest := &entities.Guest{Name: "Luca", Surname: "Bianchi", Phone: "3330000000"}
+	if err := guests.CreateGuest(ctx, guest); err != nil {
+		t.Fatalf("creating the guest: %v", err)
+	}
+
+	member := func() *entities.Member {
+		member := &entities.Member{
+			Gender:      "M",
+			DateOfBirth: time.Date(1990, time.March, 1, 0, 0, 0, 0, time.UTC),
+			Address:     &entities.Address{Country: "IT", City: "Roma", Street: "Via Roma 1"},
+		}
+		guest.ToMember(member)
+		return member
+	}
+
+	// Both conversions read the guest before either of them linked it
+	stale := *guest
+	if err := guests.ConvertGuest(ctx, guest, member()); err != nil {
+		t.Fatalf("converting the guest: %v", err)
+	}
+	if err := guests.ConvertGuest(ctx, &stale, member()); !errors.Is(err, entities.ErrConflict) {
+		t.Fatalf("converting the guest again = %v, want a conflict", err)
+	}
+
+	var members int64
+	if err := db.Unscoped().Model(entities.Member{}).Count(&members).Error; err != nil {
+		t.Fatalf("counting the members: %v", err)
+	}
+	if members != 1 {
+		t.Errorf("members = %d after two conversions, want 1", members)
+	}
+}
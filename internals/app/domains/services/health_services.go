@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"gorm.io/gorm"
+)
+
+type HealthServices struct {
+	db    *gorm.DB
+	cache ports.CacheAdapters
+}
+
+func NewHealthServices(db *gorm.DB, cache ports.CacheAdapters) *HealthServices {
+	return &HealthServices{
+		db:    db,
+		cache: cache,
+	}
+}
+
+func (h *HealthServices) CheckReadiness(ctx context.Context) *entities.Health {
+	health := &entities.Health{Status: entities.HealthUp}
+
+	// Database
+	sqlDB, err := h.db.DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+	health.AddCheck("database", err)
+
+	// Cache
+	health.AddCheck("cache", h.cache.WithContext(ctx).Ping())
+
+	return health
+}
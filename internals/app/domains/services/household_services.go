@@ -0,0 +1,433 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"gorm.io/gorm"
+)
+
+type HouseholdServices struct {
+	db *gorm.DB
+}
+
+func NewHouseholdServices(db *gorm.DB) *HouseholdServices {
+	return &HouseholdServices{
+		db: db,
+	}
+}
+
+func (h *HouseholdServices) CreateHousehold(ctx context.Context, household *entities.Household) error {
+	ctx, span := tracing.Start(ctx, "HouseholdServices.CreateHousehold")
+	defer span.End()
+
+	tx := h.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Check the payer
+	payer := new(entities.Member)
+	if err := tx.First(payer, household.PayerID).Error; err != nil {
+		tx.Rollback()
+		return entities.NewValidationError(i18n.MsgPayerInvalid)
+	}
+	if payer.HouseholdID != nil {
+		tx.Rollback()
+		return entities.NewConflictError(i18n.MsgPayerInHousehold)
+	}
+	if payer.IsMinor(time.Now()) {
+		tx.Rollback()
+		return entities.NewValidationError(i18n.MsgPayerMinor)
+	}
+
+	if err := tx.Create(household).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// The payer joins the household
+	if err := tx.
+		Model(entities.Member{}).
+		Where("id = ?", payer.ID).
+		Update("household_id", household.ID).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (h *HouseholdServices) GetAllHouseholds(ctx context.Context) ([]entities.Household, error) {
+	ctx, span := tracing.Start(ctx, "HouseholdServices.GetAllHouseholds")
+	defer span.End()
+
+	var households []entities.Household
+	if err := h.db.WithContext(ctx).
+		Preload("Members").
+		Find(&households).
+		Error; err != nil {
+		return nil, err
+	}
+	return households, nil
+}
+
+func (h *HouseholdServices) GetHouseholdById(ctx context.Context, id uint) (*entities.Household, error) {
+	ctx, span := tracing.Start(ctx, "HouseholdServices.GetHouseholdById")
+	defer span.End()
+
+	household := new(entities.Household)
+	if err := h.db.WithContext(ctx).
+		Preload("Members").
+		Preload("Members.Contacts").
+		Preload("Members.Address").
+		First(household, id).
+		Error; err != nil {
+		return nil, err
+	}
+	return household, nil
+}
+
+func (h *HouseholdServices) UpdateHousehold(ctx context.Context, id uint, household *entities.UpdateHousehold) (*entities.Household, error) {
+	ctx, span := tracing.Start(ctx, "HouseholdServices.UpdateHousehold")
+	defer span.End()
+
+	tx := h.db.WithContext(ctx).Begin()
+
+	// Check the new payer
+	if household.PayerID != 0 {
+		payer := new(entities.Member)
+		if err := tx.
+			Where("id = ? AND household_id = ?", household.PayerID, id).
+			First(payer).
+			Error; err != nil {
+			tx.Rollback()
+			return nil, entities.NewValidationError(i18n.MsgPayerNotInHousehold)
+		}
+		if payer.IsMinor(time.Now()) {
+			tx.Rollback()
+			return nil, entities.NewValidationError(i18n.MsgPayerMinor)
+		}
+
+		// The payer is the one the others inherit from
+		if err := tx.
+			Model(payer).
+			Updates(map[string]interface{}{
+				"inherits_contacts": false,
+				"inherits_address":  false,
+			}).
+			Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.
+		Model(entities.Household{}).
+		Where("id = ?", id).
+		Updates(household).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if household.PayerID != 0 {
+		if err := syncPayerContacts(tx, id); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return h.GetHouseholdById(ctx, id)
+}
+
+func (h *HouseholdServices) DeleteHousehold(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "HouseholdServices.DeleteHousehold")
+	defer span.End()
+
+	tx := h.db.WithContext(ctx).Begin()
+	if err := tx.
+		Model(entities.Member{}).
+		Where("household_id = ?", id).
+		Updates(map[string]interface{}{
+			"household_id":        nil,
+			"inherits_contacts":   false,
+			"inherits_address":    false,
+			"guardian_id":         nil,
+			"guardian_consent_at": nil,
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Delete(&entities.Household{}, id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (h *HouseholdServices) AddHouseholdMember(ctx context.Context, id uint, hm *entities.HouseholdMember) (*entities.Member, error) {
+	ctx, span := tracing.Start(ctx, "HouseholdServices.AddHouseholdMember")
+	defer span.End()
+
+	household := new(entities.Household)
+	if err := h.db.WithContext(ctx).First(household, id).Error; err != nil {
+		return nil, err
+	}
+
+	member := new(entities.Member)
+	if err := h.db.WithContext(ctx).First(member, hm.MemberID).Error; err != nil {
+		return nil, entities.NewValidationError(i18n.MsgHouseholdMemberInvalid)
+	}
+	if member.HouseholdID != nil {
+		return nil, entities.NewConflictError(i18n.MsgMemberInHousehold)
+	}
+
+	if err := h.consentGuardian(ctx, household, member, hm.GuardianID, hm.GuardianConsent); err != nil {
+		return nil, err
+	}
+
+	tx := h.db.WithContext(ctx).Begin()
+	if err := tx.
+		Model(member).
+		Updates(map[string]interface{}{
+			"household_id":        household.ID,
+			"inherits_contacts":   hm.InheritContacts,
+			"inherits_address":    hm.InheritAddress,
+			"guardian_id":         member.GuardianID,
+			"guardian_consent_at": member.GuardianConsentAt,
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := syncPayerContacts(tx, household.ID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	updated := new(entities.Member)
+	if err := h.db.WithContext(ctx).
+		Preload("Contacts").
+		Preload("Address").
+		First(updated, member.ID).
+		Error; err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (h *HouseholdServices) RemoveHouseholdMember(ctx context.Context, id uint, member_id uint) error {
+	ctx, span := tracing.Start(ctx, "HouseholdServices.RemoveHouseholdMember")
+	defer span.End()
+
+	household := new(entities.Household)
+	if err := h.db.WithContext(ctx).First(household, id).Error; err != nil {
+		return err
+	}
+
+	if household.PayerID == member_id {
+		return entities.NewValidationError(i18n.MsgPayerRemoval)
+	}
+
+	result := h.db.WithContext(ctx).
+		Model(entities.Member{}).
+		Where("id = ? AND household_id = ?", member_id, id).
+		Updates(map[string]interface{}{
+			"household_id":        nil,
+			"inherits_contacts":   false,
+			"inherits_address":    false,
+			"guardian_id":         nil,
+			"guardian_consent_at": nil,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (h *HouseholdServices) PrepareHouseholdMember(ctx context.Context, m *entities.Member) error {
+	ctx, span := tracing.Start(ctx, "HouseholdServices.PrepareHouseholdMember")
+	defer span.End()
+
+	// Members can join a household later
+	if m.HouseholdID == nil {
+		m.InheritsContacts = false
+		m.InheritsAddress = false
+		m.GuardianID = nil
+		m.GuardianConsentAt = nil
+		return nil
+	}
+
+	household := new(entities.Household)
+	if err := h.db.WithContext(ctx).First(household, *m.HouseholdID).Error; err != nil {
+		return entities.NewValidationError(i18n.MsgHouseholdInvalid)
+	}
+
+	if err := h.consentGuardian(ctx, household, m, m.GuardianID, m.GuardianConsent); err != nil {
+		return err
+	}
+
+	// Inherit the missing contacts and address from the payer
+	m.InheritsContacts = m.InheritsContacts || m.Contacts == nil
+	m.InheritsAddress = m.InheritsAddress || m.Address == nil
+	if m.InheritsContacts || m.InheritsAddress {
+		payer, err := h.getPayer(ctx, household)
+		if err != nil {
+			return err
+		}
+
+		if m.InheritsContacts && payer.Contacts != nil {
+			if m.Contacts == nil {
+				m.Contacts = new(entities.Contacts)
+			}
+			m.Contacts.Phone = payer.Contacts.Phone
+			m.Contacts.Email = payer.Contacts.Email
+		}
+
+		if m.InheritsAddress && payer.Address != nil {
+			m.Address = &entities.Address{
+				Country: payer.Address.Country,
+				City:    payer.Address.City,
+				Street:  payer.Address.Street,
+			}
+		}
+	}
+
+	return nil
+}
+
+// consentGuardian records the consent of the guardian of a minor joining the
+// household, the payer unless another adult member is given.
+func (h *HouseholdServices) consentGuardian(ctx context.Context, household *entities.Household, member *entities.Member, guardian_id *uint, consent bool) error {
+	member.GuardianID = nil
+	member.GuardianConsentAt = nil
+	if !member.IsMinor(time.Now()) {
+		return nil
+	}
+
+	if !consent {
+		return entities.NewValidationError(i18n.MsgGuardianConsentRequired)
+	}
+
+	guardianID := household.PayerID
+	if guardian_id != nil {
+		guardianID = *guardian_id
+	}
+	if err := h.checkGuardian(ctx, household.ID, guardianID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	member.GuardianID = &guardianID
+	member.GuardianConsentAt = &now
+	return nil
+}
+
+// getPayer retrieves the payer of the household with contacts and address.
+func (h *HouseholdServices) getPayer(ctx context.Context, household *entities.Household) (*entities.Member, error) {
+	payer := new(entities.Member)
+	if err := h.db.WithContext(ctx).
+		Preload("Contacts").
+		Preload("Address").
+		First(payer, household.PayerID).
+		Error; err != nil {
+		return nil, err
+	}
+	return payer, nil
+}
+
+// checkGuardian checks that the guardian is an adult member of the household.
+func (h *HouseholdServices) checkGuardian(ctx context.Context, household_id uint, guardian_id uint) error {
+	guardian := new(entities.Member)
+	if err := h.db.WithContext(ctx).
+		Where("id = ? AND household_id = ?", guardian_id, household_id).
+		First(guardian).
+		Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.NewValidationError(i18n.MsgGuardianNotInHousehold)
+		}
+		return err
+	}
+
+	if guardian.IsMinor(time.Now()) {
+		return entities.NewValidationError(i18n.MsgGuardianMinor)
+	}
+
+	return nil
+}
+
+// syncPayerContacts copies the contacts and the address of the payer of the
+// household to the members inheriting them.
+func syncPayerContacts(tx *gorm.DB, household_id uint) error {
+	household := new(entities.Household)
+	if err := tx.First(household, household_id).Error; err != nil {
+		return err
+	}
+
+	payer := new(entities.Member)
+	if err := tx.
+		Preload("Contacts").
+		Preload("Address").
+		First(payer, household.PayerID).
+		Error; err != nil {
+		return err
+	}
+
+	inheriting := func(column string) *gorm.DB {
+		return tx.
+			Model(entities.Member{}).
+			Select("id").
+			Where("household_id = ? AND id <> ? AND "+column+" = ?", household.ID, payer.ID, true)
+	}
+
+	if payer.Contacts != nil {
+		if err := tx.
+			Model(entities.Contacts{}).
+			Where("id IN (?)", inheriting("inherits_contacts")).
+			Updates(map[string]interface{}{
+				"phone": payer.Contacts.Phone,
+				"email": payer.Contacts.Email,
+			}).
+			Error; err != nil {
+			return err
+		}
+	}
+
+	if payer.Address != nil {
+		if err := tx.
+			Model(entities.Address{}).
+			Where("id IN (?)", inheriting("inherits_address")).
+			Updates(map[string]interface{}{
+				"country": payer.Address.Country,
+				"city":    payer.Address.City,
+				"street":  payer.Address.Street,
+			}).
+			Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
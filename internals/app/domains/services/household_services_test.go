@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+	"gorm.io/gorm"
+)
+
+// newTestHousehold creates a household paid by the member.
+func newTestHousehold(t *testing.T, db *gorm.DB, payer *entities.Member, discount float32) *entities.Household {
+	t.Helper()
+
+	household := &entities.Household{Name: payer.Surname, PayerID: payer.ID, DiscountPercent: discount}
+	if err := services.NewHouseholdServices(db).CreateHousehold(context.Background(), household); err != nil {
+		t.Fatalf("creating the household: %v", err)
+	}
+	return household
+}
+
+func TestUpdateSubscriptionAppliesHouseholdDiscount(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	payer := newTestMember(t, db, "Mario")
+	newTestHousehold(t, db, payer, 10)
+	members := services.NewMemberServices(db, secondary.NewEventBus())
+
+	start := time.Now()
+	subscription := &entities.Subscription{Type: "mensile", StartDate: start, IsActive: new(bool), Price: 50}
+	subscription.AddEndDate()
+	if err := members.CreateMemberSubscription(ctx, payer.ID, subscription, entities.OverlapReject); err != nil {
+		t.Fatalf("creating the subscription: %v", err)
+	}
+
+	update := &entities.UpdateSubscription{Type: "mensile", StartDate: start, IsActive: subscription.IsActive, Price: 100}
+	update.AddEndDate()
+	updated, err := members.UpdateSubscription(ctx, payer.ID, subscription.ID, update)
+	if err != nil {
+		t.Fatalf("updating the subscription: %v", err)
+	}
+	if len(updated) != 1 || updated[0].Price != 90 || updated[0].OriginalPrice != 100 || updated[0].Discount != 10 {
+		t.Fatalf("updated = %+v, want price 90 of 100 with a discount of 10", updated)
+	}
+}
+
+func TestHouseholdMinorNeedsGuardianConsent(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	payer := newTestMember(t, db, "Mario")
+	household := newTestHousehold(t, db, payer, 0)
+	households := services.NewHouseholdServices(db)
+
+	minor := func() *entities.Member {
+		return &entities.Member{
+			Name:        "Luca",
+			Surname:     "Rossi",
+			DateOfBirth: time.Now().AddDate(-10, 0, 0),
+			HouseholdID: &household.ID,
+			GuardianID:  &payer.ID,
+		}
+	}
+
+	// On creation
+	member := minor()
+	if err := households.PrepareHouseholdMember(ctx, member); !errors.Is(err, entities.ErrValidation) {
+		t.Fatalf("preparing a minor without consent = %v, want a validation error", err)
+	}
+	member = minor()
+	member.GuardianID = nil
+	member.GuardianConsent = true
+	if err := households.PrepareHouseholdMember(ctx, member); err != nil {
+		t.Fatalf("preparing a minor with consent: %v", err)
+	}
+	if member.GuardianID == nil || *member.GuardianID != payer.ID || member.GuardianConsentAt == nil {
+		t.Fatalf("guardian = %v at %v, want the payer %d", member.GuardianID, member.GuardianConsentAt, payer.ID)
+	}
+
+	// On joining
+	existing := minor()
+	existing.HouseholdID = nil
+	existing.GuardianID = nil
+	existing.Contacts = &entities.Contacts{Phone: "3330000000"}
+	existing.Address = &entities.Address{Country: "IT", City: "Roma", Street: "Via Roma 2"}
+	if err := services.NewMemberServices(db, secondary.NewEventBus()).CreateMember(ctx, existing); err != nil {
+		t.Fatalf("creating the minor: %v", err)
+	}
+	if _, err := households.AddHouseholdMember(ctx, household.ID, &entities.HouseholdMember{MemberID: existing.ID}); !errors.Is(err, entities.ErrValidation) {
+		t.Fatalf("adding a minor without consent = %v, want a validation error", err)
+	}
+	if _, err := households.AddHouseholdMember(ctx, household.ID, &entities.HouseholdMember{MemberID: existing.ID, GuardianConsent: true}); err != nil {
+		t.Fatalf("adding a minor with consent: %v", err)
+	}
+}
+
+func TestInheritedContactsFollowThePayer(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	payer := newTestMember(t, db, "Mario")
+	partner := newTestMember(t, db, "Anna")
+	child := newTestMember(t, db, "Luca")
+	household := newTestHousehold(t, db, payer, 0)
+	households := services.NewHouseholdServices(db)
+
+	if _, err := households.AddHouseholdMember(ctx, household.ID, &entities.HouseholdMember{MemberID: partner.ID}); err != nil {
+		t.Fatalf("adding the partner: %v", err)
+	}
+	added, err := households.AddHouseholdMember(ctx, household.ID, &entities.HouseholdMember{MemberID: child.ID, InheritContacts: true, InheritAddress: true})
+	if err != nil {
+		t.Fatalf("adding the child: %v", err)
+	}
+	if added.Contacts.Email != payer.Contacts.Email {
+		t.Fatalf("child email = %q, want the one of the payer %q", added.Contacts.Email, payer.Contacts.Email)
+	}
+
+	// The partner pays from now on, from another address
+	if err := db.Model(partner.Address).Update("street", "Via Milano 3").Error; err != nil {
+		t.Fatalf("moving the partner: %v", err)
+	}
+	if _, err := households.UpdateHousehold(ctx, household.ID, &entities.UpdateHousehold{PayerID: partner.ID}); err != nil {
+		t.Fatalf("changing the payer: %v", err)
+	}
+
+	updated := new(entities.Member)
+	if err := db.Preload("Contacts").Preload("Address").First(updated, child.ID).Error; err != nil {
+		t.Fatalf("loading the child: %v", err)
+	}
+	if updated.Contacts.Email != partner.Contacts.Email || updated.Address.Street != "Via Milano 3" {
+		t.Fatalf("child contacts = %+v, address = %+v, want the ones of the partner", updated.Contacts, updated.Address)
+	}
+}
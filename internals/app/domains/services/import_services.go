@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"gorm.io/gorm"
+)
+
+type ImportServices struct {
+	db     *gorm.DB
+	events ports.EventBus
+}
+
+func NewImportServices(db *gorm.DB, events ports.EventBus) *ImportServices {
+	return &ImportServices{
+		db:     db,
+		events: events,
+	}
+}
+
+func (i *ImportServices) ImportMembers(ctx context.Context, rows [][]string, columns entities.ImportColumns, dryRun bool) (*entities.ImportReport, error) {
+	ctx, span := tracing.Start(ctx, "ImportServices.ImportMembers")
+	defer span.End()
+
+	if len(rows) < 2 {
+		return nil, entities.NewValidationError(i18n.MsgImportEmpty)
+	}
+
+	index, err := columns.Index(rows[0])
+	if err != nil {
+		return nil, err
+	}
+
+	phones, emails, err := i.existingContacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.ImportReport{
+		DryRun: dryRun,
+		Errors: entities.ImportRowErrors{},
+	}
+	for n, row := range rows[1:] {
+		// Rows are numbered as in the spreadsheet, after the header
+		line := n + 2
+		if isEmptyRow(row) {
+			continue
+		}
+		report.Rows++
+
+		member, errs := columns.ParseMember(index, row)
+		if len(errs) == 0 {
+			var validationErrors entities.ValidationErrors
+			if errors.As(member.Validate(), &validationErrors) {
+				for _, e := range validationErrors {
+					errs = append(errs, entities.NewImportRowError(entities.ImportField(e.Field), e.MessageCode, e.Args...))
+				}
+			}
+		}
+
+		// Duplicates of the archive or of a previous row
+		if phone := normalizePhone(member.Contacts.Phone); phone != "" {
+			if previous, ok := phones[phone]; ok {
+				errs = append(errs, duplicateError(entities.ImportPhone, previous))
+			} else {
+				phones[phone] = line
+			}
+		}
+		if email := member.Contacts.Email; email != "" {
+			if previous, ok := emails[email]; ok {
+				errs = append(errs, duplicateError(entities.ImportEmail, previous))
+			} else {
+				emails[email] = line
+			}
+		}
+
+		for _, e := range errs {
+			e.Row = line
+			report.Errors = append(report.Errors, e)
+		}
+		if len(errs) == 0 {
+			report.Members = append(report.Members, *member)
+		}
+	}
+
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	tx := i.db.WithContext(ctx).Begin()
+	for n := range report.Members {
+		if err := tx.Create(&report.Members[n]).Error; err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	for n := range report.Members {
+		i.events.Publish(ctx, &entities.MemberCreated{Member: &report.Members[n]})
+	}
+	report.Imported = len(report.Members)
+	return report, nil
+}
+
+// existingContacts returns the phones and the emails of the members in the
+// archive, the value is 0 as they come from no row of the file.
+func (i *ImportServices) existingContacts(ctx context.Context) (map[string]int, map[string]int, error) {
+	var contacts []entities.Contacts
+	if err := i.db.WithContext(ctx).
+		Joins("JOIN members ON members.id = contacts.id AND members.deleted_at IS NULL").
+		Find(&contacts).
+		Error; err != nil {
+		return nil, nil, err
+	}
+
+	phones := make(map[string]int, len(contacts))
+	emails := make(map[string]int, len(contacts))
+	for _, contact := range contacts {
+		if phone := normalizePhone(contact.Phone); phone != "" {
+			phones[phone] = 0
+		}
+		if email := strings.ToLower(strings.TrimSpace(contact.Email)); email != "" {
+			emails[email] = 0
+		}
+	}
+
+	return phones, emails, nil
+}
+
+// duplicateError returns the error of a phone or email already in the archive,
+// previous is 0, or in a previous row.
+func duplicateError(field string, previous int) entities.ImportRowError {
+	archived, duplicate := i18n.MsgImportEmailArchived, i18n.MsgImportEmailDuplicate
+	if field == entities.ImportPhone {
+		archived, duplicate = i18n.MsgImportPhoneArchived, i18n.MsgImportPhoneDuplicate
+	}
+
+	if previous == 0 {
+		return entities.NewImportRowError(field, archived)
+	}
+	return entities.NewImportRowError(field, duplicate, previous)
+}
+
+// normalizePhone keeps the digits of a phone number and its leading +.
+func normalizePhone(phone string) string {
+	phone = strings.TrimSpace(phone)
+	normalized := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+
+	if normalized != "" && strings.HasPrefix(phone, "+") {
+		return "+" + normalized
+	}
+	return normalized
+}
+
+func isEmptyRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -1,44 +1,109 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
 	"gorm.io/gorm"
 )
 
 type MemberServices struct {
-	db *gorm.DB
+	db     *gorm.DB
+	events ports.EventBus
 }
 
-func NewMemberServices(db *gorm.DB) *MemberServices {
+func NewMemberServices(db *gorm.DB, events ports.EventBus) *MemberServices {
 	return &MemberServices{
-		db: db,
+		db:     db,
+		events: events,
 	}
 }
 
-func (m *MemberServices) CreateMember(member *entities.Member) error {
-	tx := m.db.Begin()
+func (m *MemberServices) CreateMember(ctx context.Context, member *entities.Member) error {
+	ctx, span := tracing.Start(ctx, "MemberServices.CreateMember")
+	defer span.End()
+
+	return m.createMember(ctx, member, nil)
+}
+
+// createMember creates the member and runs then, if given, in the same
+// transaction.
+func (m *MemberServices) createMember(ctx context.Context, member *entities.Member, then func(tx *gorm.DB) error) error {
+	// A new member has no subscriptions to overlap with
+	for _, sub := range member.Subscription {
+		if err := expiredSubscriptionErrors(sub.EndDate, sub.IsActive, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	tx := m.db.WithContext(ctx).Begin()
+
+	// Apply the family-plan pricing and the promotions
+	redemptions := make([]*entities.PromotionRedemption, len(member.Subscription))
+	for i := range member.Subscription {
+		if err := m.applyHouseholdDiscount(tx, member.HouseholdID, &member.Subscription[i]); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		redemption, err := applyPromotion(tx, member.Subscription[i].PromotionCode, 0, &member.Subscription[i])
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		redemptions[i] = redemption
+	}
+
 	if err := tx.Create(member).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
 
+	for i := range member.Subscription {
+		if err := recordRedemption(tx, redemptions[i], &member.Subscription[i]); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if then != nil {
+		if err := then(tx); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return err
 	}
 
+	m.events.Publish(ctx, &entities.MemberCreated{Member: member})
 	return nil
 }
 
-func (m *MemberServices) UpdateMember(id uint, member *entities.UpdateMember) error {
-	return m.db.
+func (m *MemberServices) UpdateMember(ctx context.Context, id uint, member *entities.UpdateMember) error {
+	ctx, span := tracing.Start(ctx, "MemberServices.UpdateMember")
+	defer span.End()
+
+	return m.db.WithContext(ctx).
 		Model(entities.Member{}).
 		Where("id = ?", id).
 		Updates(member).Error
 }
 
-func (m *MemberServices) GetAllMembers() ([]entities.Member, error) {
+func (m *MemberServices) GetAllMembers(ctx context.Context) ([]entities.Member, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.GetAllMembers")
+	defer span.End()
+
 	var members []entities.Member
-	if err := m.db.
+	if err := m.db.WithContext(ctx).
 		Preload("Contacts").
 		Preload("Address").
 		Preload("Subscription", "is_active = true").
@@ -49,9 +114,12 @@ func (m *MemberServices) GetAllMembers() ([]entities.Member, error) {
 	return members, nil
 }
 
-func (m *MemberServices) GetMemberById(id uint) (entities.Member, error) {
+func (m *MemberServices) GetMemberById(ctx context.Context, id uint) (entities.Member, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.GetMemberById")
+	defer span.End()
+
 	var member entities.Member
-	if err := m.db.
+	if err := m.db.WithContext(ctx).
 		Preload("Contacts").
 		Preload("Address").
 		Preload("Subscription", "is_active = true").
@@ -62,28 +130,120 @@ func (m *MemberServices) GetMemberById(id uint) (entities.Member, error) {
 	return member, nil
 }
 
-func (m *MemberServices) DeleteMember(id uint) error {
+func (m *MemberServices) DeleteMember(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "MemberServices.DeleteMember")
+	defer span.End()
+
+	tx := m.db.WithContext(ctx).Begin()
+
+	// The subscriptions deleted before the member are not restored with it
+	if err := tx.
+		Model(entities.Subscription{}).
+		Where("user_id = ?", id).
+		Updates(map[string]interface{}{
+			"deleted":             time.Now(),
+			"deleted_with_member": true,
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	member := new(entities.Member)
 	member.ID = id
-	return m.db.
-		Select("Contacts", "Address", "Subscription").
+	if err := tx.
+		Select("Contacts", "Address").
 		Delete(member).
-		Error
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	m.events.Publish(ctx, &entities.MemberDeleted{MemberID: id})
+	return nil
 }
 
-func (m *MemberServices) CreateMemberSubscription(user_id uint, subscription *entities.Subscription) error {
+func (m *MemberServices) CreateMemberSubscription(ctx context.Context, user_id uint, subscription *entities.Subscription, policy entities.OverlapPolicy) error {
+	ctx, span := tracing.Start(ctx, "MemberServices.CreateMemberSubscription")
+	defer span.End()
+
 	subscription.UserID = user_id
-	return m.db.
+
+	tx := m.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	// Apply the family-plan pricing
+	member := new(entities.Member)
+	if err := tx.First(member, user_id).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := m.applyHouseholdDiscount(tx, member.HouseholdID, subscription); err != nil {
+		tx.Rollback()
+		return err
+	}
+	redemption, err := applyPromotion(tx, subscription.PromotionCode, user_id, subscription)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Queue the subscription after the ones it overlaps
+	if policy == entities.OverlapQueue {
+		for {
+			overlaps, err := m.overlappingSubscriptions(tx, user_id, 0, subscription.StartDate, subscription.EndDate)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+			if len(overlaps) == 0 {
+				break
+			}
+			subscription.Reschedule(lastEndDate(overlaps))
+		}
+	}
+
+	if err := m.validateSubscriptionPeriod(tx, user_id, 0, subscription.StartDate, subscription.EndDate, subscription.IsActive); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.
 		Model(entities.Subscription{}).
 		Create(subscription).
-		Error
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := recordRedemption(tx, redemption, subscription); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	m.events.Publish(ctx, &entities.SubscriptionCreated{Subscription: subscription})
+	return nil
 }
 
-func (m *MemberServices) GetAllSubscriptions(id uint) ([]entities.Subscription, error) {
+func (m *MemberServices) GetAllSubscriptions(ctx context.Context, id uint) ([]entities.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.GetAllSubscriptions")
+	defer span.End()
+
 	var subscriptions []entities.Subscription
-	if err := m.db.
+	if err := m.db.WithContext(ctx).
 		Model(entities.Subscription{}).
 		Where("user_id = ?", id).
+		Order("start_date").
 		Find(&subscriptions).
 		Error; err != nil {
 		return nil, err
@@ -91,9 +251,12 @@ func (m *MemberServices) GetAllSubscriptions(id uint) ([]entities.Subscription,
 	return subscriptions, nil
 }
 
-func (m *MemberServices) GetSubscriptionById(id uint, sub_id uint) ([]entities.Subscription, error) {
+func (m *MemberServices) GetSubscriptionById(ctx context.Context, id uint, sub_id uint) ([]entities.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.GetSubscriptionById")
+	defer span.End()
+
 	var subscriptions []entities.Subscription
-	if err := m.db.
+	if err := m.db.WithContext(ctx).
 		Model(entities.Subscription{}).
 		Where("user_id = ? AND id = ?", id, sub_id).
 		First(&subscriptions).
@@ -103,22 +266,681 @@ func (m *MemberServices) GetSubscriptionById(id uint, sub_id uint) ([]entities.S
 	return subscriptions, nil
 }
 
-func (m *MemberServices) UpdateSubscription(user_id uint, sub_id uint, subscription *entities.UpdateSubscription) ([]entities.Subscription, error) {
-	var subscriptions []entities.Subscription
-	if err := m.db.
-		Model(entities.Subscription{}).
+func (m *MemberServices) UpdateSubscription(ctx context.Context, user_id uint, sub_id uint, subscription *entities.UpdateSubscription) ([]entities.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.UpdateSubscription")
+	defer span.End()
+
+	tx := m.db.WithContext(ctx).Begin()
+
+	if err := m.validateSubscriptionPeriod(tx, user_id, sub_id, subscription.StartDate, subscription.EndDate, subscription.IsActive); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	stored := new(entities.Subscription)
+	if err := tx.
 		Where("user_id = ? AND id = ?", user_id, sub_id).
+		First(stored).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Apply the family-plan pricing and the promotion again
+	pricing, err := m.repriceSubscription(tx, stored, subscription.Price)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	subscription.Price = pricing.Price
+
+	if err := tx.
+		Model(stored).
 		Updates(subscription).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.
+		Model(stored).
+		Updates(map[string]interface{}{
+			"original_price": pricing.OriginalPrice,
+			"discount":       pricing.Discount,
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var subscriptions []entities.Subscription
+	if err := tx.
+		Where("user_id = ? AND id = ?", user_id, sub_id).
 		Find(&subscriptions).
 		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		return nil, err
 	}
 	return subscriptions, nil
 }
 
-func (m *MemberServices) DeleteSubscription(user_id uint, sub_id uint) error {
-	return m.db.
+// repriceSubscription applies the discounts of the subscription to a new
+// price, before discounts, and updates the redemption of its promotion.
+func (m *MemberServices) repriceSubscription(tx *gorm.DB, subscription *entities.Subscription, price float32) (*entities.Subscription, error) {
+	pricing := &entities.Subscription{Price: price, OriginalPrice: price}
+
+	member := new(entities.Member)
+	if err := tx.First(member, subscription.UserID).Error; err != nil {
+		return nil, err
+	}
+	if err := m.applyHouseholdDiscount(tx, member.HouseholdID, pricing); err != nil {
+		return nil, err
+	}
+
+	if subscription.PromotionID == nil {
+		return pricing, nil
+	}
+
+	// The promotion was already redeemed, even if it's no longer valid
+	promotion := new(entities.Promotion)
+	if err := tx.Unscoped().First(promotion, *subscription.PromotionID).Error; err != nil {
+		return nil, err
+	}
+	discount := promotion.DiscountFor(pricing.Price)
+	pricing.ApplyDiscount(discount)
+
+	if err := tx.
+		Model(entities.PromotionRedemption{}).
+		Where("subscription_id = ?", subscription.ID).
+		Updates(map[string]interface{}{
+			"original_price": pricing.OriginalPrice,
+			"discount":       discount,
+			"final_price":    pricing.Price,
+		}).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return pricing, nil
+}
+
+func (m *MemberServices) DeleteSubscription(ctx context.Context, user_id uint, sub_id uint) error {
+	ctx, span := tracing.Start(ctx, "MemberServices.DeleteSubscription")
+	defer span.End()
+
+	return m.db.WithContext(ctx).
 		Where("user_id = ? AND id = ?", user_id, sub_id).
 		Delete(&entities.Subscription{}).
 		Error
-}
\ No newline at end of file
+}
+
+func (m *MemberServices) RenewSubscription(ctx context.Context, user_id uint, sub_id uint, renew *entities.RenewSubscription) (*entities.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.RenewSubscription")
+	defer span.End()
+
+	next, err := m.commitRenewal(ctx, user_id, sub_id, renew)
+	if err != nil {
+		return nil, err
+	}
+
+	m.events.Publish(ctx, &entities.SubscriptionCreated{Subscription: next})
+	return next, nil
+}
+
+// commitRenewal renews a subscription in its own transaction.
+func (m *MemberServices) commitRenewal(ctx context.Context, user_id uint, sub_id uint, renew *entities.RenewSubscription) (*entities.Subscription, error) {
+	tx := m.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	next, err := m.renewSubscription(tx, user_id, sub_id, renew)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+func (m *MemberServices) ProcessAutoRenewals(ctx context.Context, before time.Time) ([]entities.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.ProcessAutoRenewals")
+	defer span.End()
+
+	// Whatever their status, as the expired ones may be deactivated first
+	var expiring []entities.Subscription
+	if err := m.db.WithContext(ctx).
+		Model(entities.Subscription{}).
+		Where("auto_renew = true AND end_date <= ?", before).
+		Where("id NOT IN (?)", m.db.WithContext(ctx).Model(entities.Subscription{}).Select("previous_id").Where("previous_id IS NOT NULL")).
+		Find(&expiring).
+		Error; err != nil {
+		return nil, err
+	}
+
+	var renewed []entities.Subscription
+	for _, sub := range expiring {
+		next, err := m.commitRenewal(ctx, sub.UserID, sub.ID, &entities.RenewSubscription{})
+		if err != nil {
+			slog.Error("Error renewing subscription", "subscription_id", sub.ID, "error", err)
+			continue
+		}
+		m.events.Publish(ctx, &entities.SubscriptionCreated{Subscription: next, AutoRenewal: true})
+		renewed = append(renewed, *next)
+	}
+
+	return renewed, nil
+}
+
+func (m *MemberServices) RefreshSubscriptionsStatus(ctx context.Context, now time.Time) ([]entities.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.RefreshSubscriptionsStatus")
+	defer span.End()
+
+	tx := m.db.WithContext(ctx).Begin()
+
+	// Only renewals and queued subscriptions are activated, other inactive
+	// subscriptions were disabled by hand
+	if err := tx.
+		Model(entities.Subscription{}).
+		Where("is_active = false AND (previous_id IS NOT NULL OR queued = true) AND start_date <= ? AND end_date > ?", now, now).
+		Updates(map[string]interface{}{
+			"is_active": true,
+			"queued":    false,
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var expired []entities.Subscription
+	if err := tx.
+		Where("is_active = true AND end_date <= ?", now).
+		Find(&expired).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if len(expired) == 0 {
+		return nil, tx.Commit().Error
+	}
+
+	ids := make([]uint, len(expired))
+	for i := range expired {
+		ids[i] = expired[i].ID
+		*expired[i].IsActive = false
+	}
+	if err := tx.
+		Model(entities.Subscription{}).
+		Where("id IN ?", ids).
+		Update("is_active", false).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	for i := range expired {
+		m.events.Publish(ctx, &entities.SubscriptionExpired{Subscription: &expired[i]})
+	}
+	return expired, nil
+}
+
+func (m *MemberServices) renewSubscription(tx *gorm.DB, user_id uint, sub_id uint, renew *entities.RenewSubscription) (*entities.Subscription, error) {
+	previous := new(entities.Subscription)
+	if err := tx.
+		Where("user_id = ? AND id = ?", user_id, sub_id).
+		First(previous).
+		Error; err != nil {
+		return nil, err
+	}
+
+	// Check if the subscription was already renewed
+	var renewals int64
+	if err := tx.
+		Model(entities.Subscription{}).
+		Where("previous_id = ?", previous.ID).
+		Count(&renewals).
+		Error; err != nil {
+		return nil, err
+	}
+	if renewals > 0 {
+		return nil, entities.NewConflictError(i18n.MsgSubscriptionAlreadyRenewed)
+	}
+
+	next := previous.NextSubscription(renew.Price)
+	if renew.AutoRenew != nil {
+		*next.AutoRenew = *renew.AutoRenew
+	}
+
+	// Apply the family-plan pricing and the promotion
+	member := new(entities.Member)
+	if err := tx.First(member, user_id).Error; err != nil {
+		return nil, err
+	}
+	if err := m.applyHouseholdDiscount(tx, member.HouseholdID, next); err != nil {
+		return nil, err
+	}
+	redemption, err := applyPromotion(tx, renew.PromotionCode, user_id, next)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.validateSubscriptionPeriod(tx, user_id, 0, next.StartDate, next.EndDate, next.IsActive); err != nil {
+		return nil, err
+	}
+
+	// Renewed concurrently, after the check above
+	if err := tx.Create(next).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, entities.NewConflictError(i18n.MsgSubscriptionAlreadyRenewed)
+		}
+		return nil, err
+	}
+
+	if err := recordRedemption(tx, redemption, next); err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+func (m *MemberServices) GetDeletedMembers(ctx context.Context) ([]entities.Member, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.GetDeletedMembers")
+	defer span.End()
+
+	var members []entities.Member
+	if err := m.db.WithContext(ctx).
+		Unscoped().
+		Preload("Contacts", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Preload("Address", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Find(&members).
+		Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (m *MemberServices) RestoreMember(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "MemberServices.RestoreMember")
+	defer span.End()
+
+	member := new(entities.Member)
+	if err := m.db.WithContext(ctx).
+		Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(member).
+		Error; err != nil {
+		return err
+	}
+
+	tx := m.db.WithContext(ctx).Begin()
+	if err := tx.
+		Unscoped().
+		Model(member).
+		Update("deleted_at", nil).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, model := range []interface{}{entities.Contacts{}, entities.Address{}} {
+		if err := tx.
+			Unscoped().
+			Model(model).
+			Where("id = ?", id).
+			Update("deleted", nil).
+			Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.
+		Unscoped().
+		Model(entities.Subscription{}).
+		Where("user_id = ? AND deleted_with_member = ?", id, true).
+		Updates(map[string]interface{}{
+			"deleted":             nil,
+			"deleted_with_member": false,
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (m *MemberServices) GetDeletedSubscriptions(ctx context.Context, user_id uint) ([]entities.Subscription, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.GetDeletedSubscriptions")
+	defer span.End()
+
+	var subscriptions []entities.Subscription
+	if err := m.db.WithContext(ctx).
+		Unscoped().
+		Where("user_id = ? AND deleted IS NOT NULL", user_id).
+		Order("deleted DESC").
+		Find(&subscriptions).
+		Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (m *MemberServices) RestoreSubscription(ctx context.Context, user_id uint, sub_id uint) error {
+	ctx, span := tracing.Start(ctx, "MemberServices.RestoreSubscription")
+	defer span.End()
+
+	subscription := new(entities.Subscription)
+	if err := m.db.WithContext(ctx).
+		Unscoped().
+		Where("user_id = ? AND id = ? AND deleted IS NOT NULL", user_id, sub_id).
+		First(subscription).
+		Error; err != nil {
+		return err
+	}
+
+	// Check the other subscriptions of the member
+	overlaps, err := m.overlappingSubscriptions(m.db.WithContext(ctx), user_id, sub_id, subscription.StartDate, subscription.EndDate)
+	if err != nil {
+		return err
+	}
+	if len(overlaps) > 0 {
+		var errs entities.ValidationErrors
+		errs.Add("start_date", "overlap", i18n.MsgSubscriptionOverlaps, overlaps[0].ID)
+		return errs
+	}
+
+	return m.db.WithContext(ctx).
+		Unscoped().
+		Model(subscription).
+		Updates(map[string]interface{}{
+			"deleted":             nil,
+			"deleted_with_member": false,
+		}).
+		Error
+}
+
+func (m *MemberServices) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "MemberServices.PurgeDeleted")
+	defer span.End()
+
+	var purged int64
+	tx := m.db.WithContext(ctx).Begin()
+
+	var memberIDs, subscriptionIDs []uint
+	if err := tx.
+		Unscoped().
+		Model(entities.Member{}).
+		Where("deleted_at < ?", before).
+		Pluck("id", &memberIDs).
+		Error; err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if err := tx.
+		Unscoped().
+		Model(entities.Subscription{}).
+		Where("user_id IN ? OR deleted < ?", memberIDs, before).
+		Pluck("id", &subscriptionIDs).
+		Error; err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	// The rows referring to the members, before the members
+	if err := m.purgeReferences(tx, memberIDs, subscriptionIDs); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	// Members and everything they own
+	for _, model := range []interface{}{entities.Contacts{}, entities.Address{}} {
+		result := tx.Unscoped().Where("id IN ?", memberIDs).Delete(model)
+		if result.Error != nil {
+			tx.Rollback()
+			return 0, result.Error
+		}
+		purged += result.RowsAffected
+	}
+
+	result := tx.Unscoped().Where("id IN ?", subscriptionIDs).Delete(&entities.Subscription{})
+	if result.Error != nil {
+		tx.Rollback()
+		return 0, result.Error
+	}
+	purged += result.RowsAffected
+
+	result = tx.Unscoped().Where("id IN ?", memberIDs).Delete(&entities.Member{})
+	if result.Error != nil {
+		tx.Rollback()
+		return 0, result.Error
+	}
+	purged += result.RowsAffected
+
+	return purged, tx.Commit().Error
+}
+
+// purgeReferences purges, or anonymizes, the rows referring to the members
+// and the subscriptions about to be purged.
+func (m *MemberServices) purgeReferences(tx *gorm.DB, memberIDs []uint, subscriptionIDs []uint) error {
+	if err := m.purgeHouseholdPayers(tx, memberIDs); err != nil {
+		return err
+	}
+
+	// Consents and notifications are about the member only
+	for _, model := range []interface{}{entities.Consent{}, entities.Notification{}} {
+		if err := tx.Where("member_id IN ?", memberIDs).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	// Guests are kept without the member they were converted to or referred by
+	for _, column := range []string{"member_id", "sponsor_id"} {
+		if err := tx.
+			Unscoped().
+			Model(entities.Guest{}).
+			Where(column+" IN ?", memberIDs).
+			Update(column, nil).
+			Error; err != nil {
+			return err
+		}
+	}
+
+	// Redemptions are kept, anonymized, for the promotion reports
+	if err := tx.
+		Model(entities.PromotionRedemption{}).
+		Where("member_id IN ?", memberIDs).
+		Update("member_id", 0).
+		Error; err != nil {
+		return err
+	}
+	if err := tx.
+		Model(entities.PromotionRedemption{}).
+		Where("subscription_id IN ?", subscriptionIDs).
+		Update("subscription_id", 0).
+		Error; err != nil {
+		return err
+	}
+
+	// Events sent to the webhooks
+	var deliveries []entities.WebhookDelivery
+	if err := tx.Where("member_id IN ?", memberIDs).Find(&deliveries).Error; err != nil {
+		return err
+	}
+	for i := range deliveries {
+		if err := deliveries[i].Erase(); err != nil {
+			return err
+		}
+		if err := tx.Model(&deliveries[i]).Update("payload", deliveries[i].Payload).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// purgeHouseholdPayers gives the households paid by the purged members to
+// their oldest adult member left, or deletes them when none is left.
+func (m *MemberServices) purgeHouseholdPayers(tx *gorm.DB, memberIDs []uint) error {
+	var households []entities.Household
+	if err := tx.Unscoped().Where("payer_id IN ?", memberIDs).Find(&households).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, household := range households {
+		var members []entities.Member
+		if err := tx.
+			Where("household_id = ? AND id NOT IN ?", household.ID, memberIDs).
+			Order("id").
+			Find(&members).
+			Error; err != nil {
+			return err
+		}
+
+		payer := slices.IndexFunc(members, func(member entities.Member) bool {
+			return !member.IsMinor(now)
+		})
+		if payer >= 0 {
+			if err := tx.Unscoped().Model(&household).Update("payer_id", members[payer].ID).Error; err != nil {
+				return err
+			}
+			if err := tx.
+				Model(&members[payer]).
+				Updates(map[string]interface{}{
+					"inherits_contacts": false,
+					"inherits_address":  false,
+				}).
+				Error; err != nil {
+				return err
+			}
+			if err := syncPayerContacts(tx, household.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// The deleted members left the household too
+		if err := tx.
+			Unscoped().
+			Model(entities.Member{}).
+			Where("household_id = ?", household.ID).
+			Updates(map[string]interface{}{
+				"household_id":      nil,
+				"inherits_contacts": false,
+				"inherits_address":  false,
+			}).
+			Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&household).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// overlappingSubscriptions returns the subscriptions of the member, other than
+// sub_id, overlapping the given period. Subscriptions disabled by hand free
+// their period, while the ones not started yet, the renewals and the queued
+// ones hold it as they are activated when they start.
+func (m *MemberServices) overlappingSubscriptions(tx *gorm.DB, user_id uint, sub_id uint, start time.Time, end time.Time) ([]entities.Subscription, error) {
+	var subscriptions []entities.Subscription
+	if err := tx.
+		Model(entities.Subscription{}).
+		Where("user_id = ? AND id != ?", user_id, sub_id).
+		Where("start_date < ? AND end_date > ?", end, start).
+		Where("is_active = ? OR start_date > ? OR previous_id IS NOT NULL OR queued = ?", true, time.Now(), true).
+		Order("start_date").
+		Find(&subscriptions).
+		Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// validateSubscriptionPeriod checks the period of a subscription against the
+// other subscriptions of the member.
+//
+// Returns entities.ValidationErrors when the period is not valid.
+func (m *MemberServices) validateSubscriptionPeriod(tx *gorm.DB, user_id uint, sub_id uint, start time.Time, end time.Time, isActive *bool) error {
+	var errs entities.ValidationErrors
+	if err := expiredSubscriptionErrors(end, isActive, time.Now()); err != nil {
+		errs = append(errs, err...)
+	}
+
+	overlaps, err := m.overlappingSubscriptions(tx, user_id, sub_id, start, end)
+	if err != nil {
+		return err
+	}
+	for _, sub := range overlaps {
+		errs.Add(
+			"start_date",
+			"overlap",
+			i18n.MsgSubscriptionOverlapsPeriod,
+			sub.ID,
+			sub.StartDate.Format("02/01/2006"),
+			sub.EndDate.Format("02/01/2006"),
+		)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// expiredSubscriptionErrors checks that an active subscription is not already expired.
+func expiredSubscriptionErrors(end time.Time, isActive *bool, now time.Time) entities.ValidationErrors {
+	if isActive == nil || !*isActive || end.IsZero() || end.After(now) {
+		return nil
+	}
+
+	var errs entities.ValidationErrors
+	errs.Add("end_date", "expired", i18n.MsgSubscriptionExpired)
+	return errs
+}
+
+// applyHouseholdDiscount applies the family-plan discount of the household to the subscription price.
+func (m *MemberServices) applyHouseholdDiscount(tx *gorm.DB, household_id *uint, subscription *entities.Subscription) error {
+	if household_id == nil {
+		return nil
+	}
+
+	household := new(entities.Household)
+	if err := tx.First(household, *household_id).Error; err != nil {
+		return err
+	}
+
+	subscription.ApplyDiscount(household.DiscountFor(subscription.Price))
+	return nil
+}
+
+// lastEndDate returns the latest end date of the given subscriptions.
+func lastEndDate(subscriptions []entities.Subscription) time.Time {
+	var last time.Time
+	for _, sub := range subscriptions {
+		if sub.EndDate.After(last) {
+			last = sub.EndDate
+		}
+	}
+	return last
+}
@@ -0,0 +1,263 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+	"gorm.io/gorm"
+)
+
+func TestRestoreMemberRestoresOnlyItsCascade(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	member := newTestMember(t, db, "Mario")
+	members := services.NewMemberServices(db, secondary.NewEventBus())
+
+	start := time.Now().AddDate(-1, 0, 0)
+	old := &entities.Subscription{UserID: member.ID, Type: "mensile", StartDate: start, EndDate: start.AddDate(0, 1, 0), IsActive: new(bool), Price: 50}
+	current := &entities.Subscription{UserID: member.ID, Type: "annuale", StartDate: start, EndDate: start.AddDate(1, 0, 0), IsActive: new(bool), Price: 400}
+	for _, subscription := range []*entities.Subscription{old, current} {
+		if err := db.Create(subscription).Error; err != nil {
+			t.Fatalf("creating the subscription: %v", err)
+		}
+	}
+
+	// Deleted on its own, just before the member
+	if err := members.DeleteSubscription(ctx, member.ID, old.ID); err != nil {
+		t.Fatalf("deleting the subscription: %v", err)
+	}
+	if err := members.DeleteMember(ctx, member.ID); err != nil {
+		t.Fatalf("deleting the member: %v", err)
+	}
+	if err := members.RestoreMember(ctx, member.ID); err != nil {
+		t.Fatalf("restoring the member: %v", err)
+	}
+
+	subscriptions, err := members.GetAllSubscriptions(ctx, member.ID)
+	if err != nil {
+		t.Fatalf("loading the subscriptions: %v", err)
+	}
+	if len(subscriptions) != 1 || subscriptions[0].ID != current.ID {
+		t.Fatalf("subscriptions = %+v, want only %d", subscriptions, current.ID)
+	}
+}
+
+func TestPurgeDeletedHouseholdPayer(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	payer := newTestMember(t, db, "Mario")
+	partner := newTestMember(t, db, "Anna")
+	members := services.NewMemberServices(db, secondary.NewEventBus())
+
+	household := &entities.Household{Name: "Rossi", PayerID: payer.ID}
+	if err := services.NewHouseholdServices(db).CreateHousehold(ctx, household); err != nil {
+		t.Fatalf("creating the household: %v", err)
+	}
+	if err := db.Model(partner).Update("household_id", household.ID).Error; err != nil {
+		t.Fatalf("adding the partner to the household: %v", err)
+	}
+
+	// Records referring to the payer
+	guest := &entities.Guest{Name: "Luca", Surname: "Bianchi", SponsorID: &payer.ID}
+	records := []interface{}{
+		&entities.Consent{MemberID: payer.ID, Type: "privacy", PolicyVersion: "1", GivenAt: time.Now(), CollectedBy: 1},
+		&entities.Notification{MemberID: payer.ID, Rule: entities.RuleBirthday, Channel: entities.ChannelEmail, Recipient: payer.Contacts.Email, Status: entities.NotificationSent, DedupKey: "compleanno:2026:1:email"},
+		&entities.PromotionRedemption{PromotionID: 1, MemberID: payer.ID, SubscriptionID: 1, Discount: 10},
+		guest,
+	}
+	for _, record := range records {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("creating %T: %v", record, err)
+		}
+	}
+
+	if err := members.DeleteMember(ctx, payer.ID); err != nil {
+		t.Fatalf("deleting the payer: %v", err)
+	}
+	if _, err := members.PurgeDeleted(ctx, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("purging the deleted members: %v", err)
+	}
+
+	stored := new(entities.Household)
+	if err := db.First(stored, household.ID).Error; err != nil {
+		t.Fatalf("loading the household: %v", err)
+	}
+	if stored.PayerID != partner.ID {
+		t.Errorf("payer = %d, want the partner %d", stored.PayerID, partner.ID)
+	}
+
+	for _, model := range []interface{}{entities.Consent{}, entities.Notification{}, entities.PromotionRedemption{}} {
+		var count int64
+		if err := db.Model(model).Where("member_id = ?", payer.ID).Count(&count).Error; err != nil {
+			t.Fatalf("counting %T: %v", model, err)
+		}
+		if count != 0 {
+			t.Errorf("%T of the payer = %d after the purge, want 0", model, count)
+		}
+	}
+
+	if err := db.First(guest, guest.ID).Error; err != nil {
+		t.Fatalf("loading the guest: %v", err)
+	}
+	if guest.SponsorID != nil {
+		t.Errorf("guest sponsor = %d after the purge, want none", *guest.SponsorID)
+	}
+}
+
+func TestPurgeDeletedLastHouseholdMember(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	payer := newTestMember(t, db, "Mario")
+	members := services.NewMemberServices(db, secondary.NewEventBus())
+
+	household := &entities.Household{Name: "Rossi", PayerID: payer.ID}
+	if err := services.NewHouseholdServices(db).CreateHousehold(ctx, household); err != nil {
+		t.Fatalf("creating the household: %v", err)
+	}
+
+	if err := members.DeleteMember(ctx, payer.ID); err != nil {
+		t.Fatalf("deleting the payer: %v", err)
+	}
+	if _, err := members.PurgeDeleted(ctx, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("purging the deleted members: %v", err)
+	}
+
+	var count int64
+	if err := db.Unscoped().Model(entities.Household{}).Where("id = ?", household.ID).Count(&count).Error; err != nil {
+		t.Fatalf("counting the households: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("households = %d after purging their only member, want 0", count)
+	}
+}
+
+func TestRenewSubscriptionOnce(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	member := newTestMember(t, db, "Mario")
+	members := services.NewMemberServices(db, secondary.NewEventBus())
+
+	start := time.Now().AddDate(0, -1, 0)
+	subscription := &entities.Subscription{UserID: member.ID, Type: "mensile", StartDate: start, EndDate: start.AddDate(0, 1, 0), IsActive: new(bool), Price: 50}
+	if err := db.Create(subscription).Error; err != nil {
+		t.Fatalf("creating the subscription: %v", err)
+	}
+
+	if _, err := members.RenewSubscription(ctx, member.ID, subscription.ID, &entities.RenewSubscription{}); err != nil {
+		t.Fatalf("renewing the subscription: %v", err)
+	}
+	if _, err := members.RenewSubscription(ctx, member.ID, subscription.ID, &entities.RenewSubscription{}); !errors.Is(err, entities.ErrConflict) {
+		t.Fatalf("renewing again = %v, want a conflict", err)
+	}
+
+	// A renewal racing with the first one is refused by the database
+	duplicate := subscription.NextSubscription(0)
+	if err := db.Create(duplicate).Error; !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("creating a second renewal = %v, want a duplicated key", err)
+	}
+}
+
+func TestOverlapIgnoresDisabledSubscriptions(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	member := newTestMember(t, db, "Mario")
+	members := services.NewMemberServices(db, secondary.NewEventBus())
+
+	// Disabled by hand after it started
+	start := time.Now().AddDate(0, 0, -10)
+	disabled := &entities.Subscription{UserID: member.ID, Type: "mensile", StartDate: start, EndDate: start.AddDate(0, 1, 0), IsActive: new(bool), Price: 50}
+	// Not started yet
+	next := start.AddDate(0, 2, 0)
+	pending := &entities.Subscription{UserID: member.ID, Type: "mensile", StartDate: next, EndDate: next.AddDate(0, 1, 0), IsActive: new(bool), Price: 50}
+	for _, subscription := range []*entities.Subscription{disabled, pending} {
+		if err := db.Create(subscription).Error; err != nil {
+			t.Fatalf("creating the subscription: %v", err)
+		}
+	}
+
+	replacement := &entities.Subscription{Type: "mensile", StartDate: time.Now(), IsActive: new(bool), Price: 50}
+	replacement.AddEndDate()
+	if err := members.CreateMemberSubscription(ctx, member.ID, replacement, entities.OverlapReject); err != nil {
+		t.Fatalf("creating a subscription over a disabled one: %v", err)
+	}
+
+	overlapping := &entities.Subscription{Type: "mensile", StartDate: next.AddDate(0, 0, 5), IsActive: new(bool), Price: 50}
+	overlapping.AddEndDate()
+	var validationErrors entities.ValidationErrors
+	if err := members.CreateMemberSubscription(ctx, member.ID, overlapping, entities.OverlapReject); !errors.As(err, &validationErrors) {
+		t.Fatalf("creating a subscription over a pending one = %v, want validation errors", err)
+	}
+}
+
+func TestQueuedSubscriptionIsActivatedWhenItStarts(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	member := newTestMember(t, db, "Mario")
+	members := services.NewMemberServices(db, secondary.NewEventBus())
+
+	current := &entities.Subscription{Type: "mensile", StartDate: time.Now().AddDate(0, 0, -5), IsActive: new(bool), Price: 50}
+	current.AddEndDate()
+	if err := members.CreateMemberSubscription(ctx, member.ID, current, entities.OverlapReject); err != nil {
+		t.Fatalf("creating the current subscription: %v", err)
+	}
+
+	queued := &entities.Subscription{Type: "mensile", StartDate: time.Now(), IsActive: new(bool), Price: 50}
+	queued.AddEndDate()
+	if err := members.CreateMemberSubscription(ctx, member.ID, queued, entities.OverlapQueue); err != nil {
+		t.Fatalf("queueing the subscription: %v", err)
+	}
+	if !queued.Queued || *queued.IsActive || !queued.StartDate.Equal(current.EndDate) {
+		t.Fatalf("queued = %+v, want it inactive from %v", queued, current.EndDate)
+	}
+
+	// The day after the current subscription ended
+	if _, err := members.RefreshSubscriptionsStatus(ctx, current.EndDate.AddDate(0, 0, 1)); err != nil {
+		t.Fatalf("refreshing the subscriptions: %v", err)
+	}
+
+	stored := new(entities.Subscription)
+	if err := db.First(stored, queued.ID).Error; err != nil {
+		t.Fatalf("loading the queued subscription: %v", err)
+	}
+	if !*stored.IsActive || stored.Queued {
+		t.Fatalf("queued subscription active = %v, queued = %v after it started, want it active", *stored.IsActive, stored.Queued)
+	}
+}
+
+func TestProcessAutoRenewalsRenewsDeactivatedSubscriptions(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	member := newTestMember(t, db, "Mario")
+	members := services.NewMemberServices(db, secondary.NewEventBus())
+
+	autoRenew := true
+	start := time.Now().AddDate(0, -1, -1)
+	subscription := &entities.Subscription{UserID: member.ID, Type: "mensile", StartDate: start, EndDate: start.AddDate(0, 1, 0), IsActive: new(bool), Price: 50, AutoRenew: &autoRenew}
+	*subscription.IsActive = true
+	if err := db.Create(subscription).Error; err != nil {
+		t.Fatalf("creating the subscription: %v", err)
+	}
+
+	// The status refresh ran before the renewals
+	if expired, err := members.RefreshSubscriptionsStatus(ctx, time.Now()); err != nil || len(expired) != 1 {
+		t.Fatalf("refreshing the subscriptions = %d, %v, want 1 expired", len(expired), err)
+	}
+
+	renewed, err := members.ProcessAutoRenewals(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("processing the renewals: %v", err)
+	}
+	if len(renewed) != 1 || renewed[0].PreviousID == nil || *renewed[0].PreviousID != subscription.ID {
+		t.Fatalf("renewed = %+v, want the renewal of %d", renewed, subscription.ID)
+	}
+
+	// Not renewed twice
+	if renewed, err := members.ProcessAutoRenewals(ctx, time.Now()); err != nil || len(renewed) != 0 {
+		t.Fatalf("processing the renewals again = %d, %v, want none", len(renewed), err)
+	}
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"gorm.io/gorm"
+)
+
+// Failed notifications are retried up to this number of attempts
+const maxNotificationAttempts = 3
+
+type NotificationServices struct {
+	db      *gorm.DB
+	privacy ports.PrivacyServices
+}
+
+func NewNotificationServices(db *gorm.DB, privacy ports.PrivacyServices) *NotificationServices {
+	return &NotificationServices{
+		db:      db,
+		privacy: privacy,
+	}
+}
+
+// notificationTarget is a member to notify about an occurrence of a rule.
+type notificationTarget struct {
+	member       *entities.Member
+	subscription *entities.Subscription
+	occurrence   string
+}
+
+func (n *NotificationServices) SendNotifications(ctx context.Context, rules []entities.NotificationRule, channels []ports.NotificationChannel, now time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "NotificationServices.SendNotifications")
+	defer span.End()
+
+	sent := 0
+	for i := range rules {
+		targets, err := n.targets(ctx, &rules[i], now)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, target := range targets {
+			count, err := n.notify(ctx, &rules[i], channels, target)
+			sent += count
+			if err != nil {
+				return sent, err
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+func (n *NotificationServices) GetMemberNotifications(ctx context.Context, member_id uint) ([]entities.Notification, error) {
+	ctx, span := tracing.Start(ctx, "NotificationServices.GetMemberNotifications")
+	defer span.End()
+
+	var notifications []entities.Notification
+	if err := n.db.WithContext(ctx).
+		Where("member_id = ?", member_id).
+		Order("created_at desc").
+		Find(&notifications).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+func (n *NotificationServices) UpdatePreferences(ctx context.Context, member_id uint, preferences *entities.NotificationPreferences) (*entities.Contacts, error) {
+	ctx, span := tracing.Start(ctx, "NotificationServices.UpdatePreferences")
+	defer span.End()
+
+	contacts := new(entities.Contacts)
+	if err := n.db.WithContext(ctx).First(contacts, member_id).Error; err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if preferences.NotifyByEmail != nil {
+		updates["notify_by_email"] = *preferences.NotifyByEmail
+	}
+	if preferences.NotifyBySMS != nil {
+		updates["notify_by_sms"] = *preferences.NotifyBySMS
+	}
+
+	if err := n.db.WithContext(ctx).Model(contacts).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+// targets returns the members to notify for a rule.
+func (n *NotificationServices) targets(ctx context.Context, rule *entities.NotificationRule, now time.Time) ([]notificationTarget, error) {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch rule.Name {
+	case entities.RuleSubscriptionExpiring:
+		return n.subscriptionTargets(ctx, day.AddDate(0, 0, rule.Days))
+	case entities.RuleSubscriptionExpired:
+		return n.subscriptionTargets(ctx, day.AddDate(0, 0, -rule.Days))
+	case entities.RuleBirthday:
+		return n.birthdayTargets(ctx, day)
+	default:
+		return nil, fmt.Errorf("unknown notification rule %s", rule.Name)
+	}
+}
+
+// subscriptionTargets returns the members whose last subscription ends on
+// the given day and is not renewed automatically.
+func (n *NotificationServices) subscriptionTargets(ctx context.Context, day time.Time) ([]notificationTarget, error) {
+	var subscriptions []entities.Subscription
+	if err := n.db.WithContext(ctx).
+		Where("end_date >= ? AND end_date < ?", day, day.AddDate(0, 0, 1)).
+		Where("auto_renew IS NULL OR auto_renew = false").
+		Where("NOT EXISTS (SELECT 1 FROM subscriptions AS later WHERE later.user_id = subscriptions.user_id AND later.end_date > subscriptions.end_date AND later.deleted IS NULL)").
+		Find(&subscriptions).
+		Error; err != nil {
+		return nil, err
+	}
+	if len(subscriptions) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, len(subscriptions))
+	for i, sub := range subscriptions {
+		ids[i] = sub.UserID
+	}
+
+	var members []entities.Member
+	if err := n.db.WithContext(ctx).
+		Preload("Contacts").
+		Where("id IN ? AND erased_at IS NULL", ids).
+		Find(&members).
+		Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]*entities.Member, len(members))
+	for i := range members {
+		byID[members[i].ID] = &members[i]
+	}
+
+	var targets []notificationTarget
+	for i := range subscriptions {
+		member, ok := byID[subscriptions[i].UserID]
+		if !ok {
+			continue
+		}
+		targets = append(targets, notificationTarget{
+			member:       member,
+			subscription: &subscriptions[i],
+			occurrence:   fmt.Sprintf("subscription-%d", subscriptions[i].ID),
+		})
+	}
+
+	return targets, nil
+}
+
+// birthdayTargets returns the members born on the given day, the members
+// born on February 29 are notified on February 28 in the other years.
+func (n *NotificationServices) birthdayTargets(ctx context.Context, day time.Time) ([]notificationTarget, error) {
+	var members []entities.Member
+	if err := n.db.WithContext(ctx).
+		Preload("Contacts").
+		Where("erased_at IS NULL").
+		Find(&members).
+		Error; err != nil {
+		return nil, err
+	}
+
+	leap := time.Date(day.Year(), time.February, 29, 0, 0, 0, 0, day.Location()).Month() == time.February
+
+	var targets []notificationTarget
+	for i := range members {
+		month, date := members[i].DateOfBirth.Month(), members[i].DateOfBirth.Day()
+		if month == time.February && date == 29 && !leap {
+			date = 28
+		}
+		if month != day.Month() || date != day.Day() {
+			continue
+		}
+
+		targets = append(targets, notificationTarget{
+			member:     &members[i],
+			occurrence: fmt.Sprintf("%d", day.Year()),
+		})
+	}
+
+	return targets, nil
+}
+
+// notify sends the notification of a rule to a member on every channel the
+// member accepts.
+func (n *NotificationServices) notify(ctx context.Context, rule *entities.NotificationRule, channels []ports.NotificationChannel, target notificationTarget) (int, error) {
+	member := target.member
+	if member.Contacts == nil {
+		return 0, nil
+	}
+
+	// Consents
+	for _, consent := range []string{entities.ConsentPrivacy, rule.Consent} {
+		if consent == "" {
+			continue
+		}
+		given, err := n.privacy.HasConsent(ctx, member.ID, consent)
+		if err != nil {
+			return 0, err
+		}
+		if !given {
+			return 0, nil
+		}
+	}
+
+	subject, body, err := rule.Render(&entities.NotificationData{
+		Member:       member,
+		Subscription: target.subscription,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, channel := range channels {
+		recipient := member.Contacts.Recipient(channel.Name())
+		if recipient == "" {
+			continue
+		}
+
+		key := entities.NotificationKey(rule.Name, target.occurrence, member.ID, channel.Name())
+		notification := new(entities.Notification)
+		err := n.db.WithContext(ctx).Where("dedup_key = ?", key).First(notification).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return sent, err
+		}
+		if notification.Status == entities.NotificationSent || notification.Attempts >= maxNotificationAttempts {
+			continue
+		}
+
+		notification.DedupKey = key
+		notification.MemberID = member.ID
+		notification.Rule = rule.Name
+		notification.Channel = channel.Name()
+		notification.Recipient = recipient
+		notification.Subject = subject
+		notification.Body = body
+		notification.Attempts++
+
+		notification.Status = entities.NotificationSent
+		notification.Error = ""
+		if err := channel.Send(notification); err != nil {
+			slog.Error("Error sending notification", "rule", rule.Name, "member_id", member.ID, "channel", channel.Name(), "error", err)
+			notification.Status = entities.NotificationFailed
+			notification.Error = err.Error()
+		} else {
+			sent++
+		}
+
+		if err := n.db.WithContext(ctx).Save(notification).Error; err != nil {
+			return sent, err
+		}
+	}
+
+	return sent, nil
+}
@@ -1,92 +1,99 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
-	"os"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
 type PermissionsService struct {
-	db            *gorm.DB
-	rolesServices ports.RolesServices
+	db     *gorm.DB
+	events ports.EventBus
+	system entities.SystemConfig
+
+	// Tables of the database, they change only with the migrations at startup
+	tables   []string
+	tablesMu sync.Mutex
 }
 
-func NewPermissionsService(db *gorm.DB, rolesServices ports.RolesServices) *PermissionsService {
+func NewPermissionsService(db *gorm.DB, events ports.EventBus, system entities.SystemConfig) *PermissionsService {
 	return &PermissionsService{
-		db:            db,
-		rolesServices: rolesServices,
+		db:     db,
+		events: events,
+		system: system,
 	}
 }
 
-func (p *PermissionsService) ValidateNewPermission(perm *entities.Permissions) error {
+func (p *PermissionsService) ValidateNewPermission(ctx context.Context, perm *entities.Permissions) error {
+	ctx, span := tracing.Start(ctx, "PermissionsService.ValidateNewPermission")
+	defer span.End()
+
+	// Check the required fields and the actions
+	if err := perm.Validate(); err != nil {
+		return err
+	}
+
+	var errs entities.ValidationErrors
+
 	// Check if the permission already exists
-	exists, err := p.CheckPermissionExists(perm.TableName, perm.RoleId)
+	exists, err := p.CheckPermissionExists(ctx, perm.TableName, perm.RoleId)
 	if err != nil {
-		return fmt.Errorf("errore nel controllo dell'esistenza del permesso")
+		return fmt.Errorf("errore nel controllo dell'esistenza del permesso: %w", err)
 	}
 	if exists {
-		return fmt.Errorf("i permessi per questa tabella e ruolo sono già presenti")
-	}
-	// Check if the role exists
-	if perm.RoleId == 0 {
-		return fmt.Errorf("il ruolo è obbligatorio")
+		errs.Add("table_name", entities.ValidationDuplicate, i18n.MsgPermissionDuplicate)
 	}
 
 	// Check role in the database
 	role := &entities.Roles{}
-	if err := p.db.First(role, perm.RoleId).Error; err != nil {
-		return fmt.Errorf("il ruolo non è valido")
-	}
-
-	// Check if the table_name exists
-	if perm.TableName == "" {
-		return fmt.Errorf("la tabella è obbligatoria")
+	if err := p.db.WithContext(ctx).First(role, perm.RoleId).Error; err != nil {
+		errs.Add("role_id", entities.ValidationInvalid, i18n.MsgPermissionRoleNotExists)
 	}
 
 	// Check if the table exists
-	if !p.db.Migrator().HasTable(perm.TableName) {
-		return fmt.Errorf("la tabella non è valida")
-	}
-	// Check if the action is valid
-	if (perm.Create != nil && *perm.Create > uint(1)) ||
-		(perm.Update != nil && *perm.Update > uint(2)) ||
-		(perm.Read != nil && *perm.Read > uint(2)) ||
-		(perm.Delete != nil && *perm.Delete > uint(2)) {
-		return fmt.Errorf("assegnare i permessi correttamente")
+	if !p.db.WithContext(ctx).Migrator().HasTable(perm.TableName) {
+		errs.Add("table_name", entities.ValidationInvalid, i18n.MsgPermissionTableNotExists)
 	}
 
-	return nil
+	return errs.Err()
 }
 
 func (p *PermissionsService) ValidateUpdatePermission(perm *entities.UpdatePermissions) error {
-	// Check if the action is valid
-	if (perm.Create != nil && *perm.Create > uint(1)) ||
-		(perm.Update != nil && *perm.Update > uint(2)) ||
-		(perm.Read != nil && *perm.Read > uint(2)) ||
-		(perm.Delete != nil && *perm.Delete > uint(2)) {
-		return fmt.Errorf("assegnare i permessi correttamente")
+	return perm.Validate()
+}
+
+func (p *PermissionsService) CreatePermission(ctx context.Context, perm *entities.Permissions) error {
+	ctx, span := tracing.Start(ctx, "PermissionsService.CreatePermission")
+	defer span.End()
+
+	if err := p.db.WithContext(ctx).Create(perm).Error; err != nil {
+		return err
 	}
 
+	p.events.Publish(ctx, &entities.PermissionsChanged{PermissionID: perm.ID})
 	return nil
 }
 
-func (p *PermissionsService) CreatePermission(perm *entities.Permissions) error {
-	return p.db.Create(perm).Error
-}
+func (p *PermissionsService) GetPermission(ctx context.Context, id uint) (*entities.Permissions, error) {
+	ctx, span := tracing.Start(ctx, "PermissionsService.GetPermission")
+	defer span.End()
 
-func (p *PermissionsService) GetPermission(id uint) (*entities.Permissions, error) {
-	systemRoleName := os.Getenv("SYS_ROLE_NAME")
+	systemRoleName := p.system.RoleName
 
 	perm := &entities.Permissions{}
-	err := p.db.
+	err := p.db.WithContext(ctx).
 		Joins("JOIN roles ON roles.id = permissions.role_id").
 		Where("roles.name != ? AND permissions.id = ?", systemRoleName, id).
 		First(perm).
@@ -95,50 +102,74 @@ func (p *PermissionsService) GetPermission(id uint) (*entities.Permissions, erro
 	return perm, err
 }
 
-func (p *PermissionsService) GetAllPermissions() ([]entities.Permissions, error) {
-	systemRoleName := os.Getenv("SYS_ROLE_NAME")
+func (p *PermissionsService) GetAllPermissions(ctx context.Context) ([]entities.Permissions, error) {
+	ctx, span := tracing.Start(ctx, "PermissionsService.GetAllPermissions")
+	defer span.End()
+
+	systemRoleName := p.system.RoleName
 
 	perms := []entities.Permissions{}
-	return perms, p.db.
+	return perms, p.db.WithContext(ctx).
 		Joins("JOIN roles ON roles.id = permissions.role_id").
 		Where("roles.name != ?", systemRoleName).
 		Find(&perms).Error
 }
 
-func (p *PermissionsService) GetPermissionsByRole(roleId uint) ([]entities.Permissions, error) {
-	systemRoleName := os.Getenv("SYS_ROLE_NAME")
+func (p *PermissionsService) GetPermissionsByRole(ctx context.Context, roleId uint) ([]entities.Permissions, error) {
+	ctx, span := tracing.Start(ctx, "PermissionsService.GetPermissionsByRole")
+	defer span.End()
+
+	systemRoleName := p.system.RoleName
 
 	perms := []entities.Permissions{}
-	return perms, p.db.
+	return perms, p.db.WithContext(ctx).
 		Joins("JOIN roles ON roles.id = permissions.role_id").
 		Where("roles.name != ? AND permissions.role_id = ?", systemRoleName, roleId).
 		Find(&perms).Error
 }
 
-func (p *PermissionsService) GetPermissionsByTable(table_name string) ([]entities.Permissions, error) {
+func (p *PermissionsService) GetPermissionsByTable(ctx context.Context, table_name string) ([]entities.Permissions, error) {
+	ctx, span := tracing.Start(ctx, "PermissionsService.GetPermissionsByTable")
+	defer span.End()
+
 	perms := []entities.Permissions{}
-	return perms, p.db.Where("table_name = ?", table_name).Find(&perms).Error
+	return perms, p.db.WithContext(ctx).Where("table_name = ?", table_name).Find(&perms).Error
 }
 
-func (p *PermissionsService) UpdatePermission(id uint, perm *entities.UpdatePermissions) (*entities.Permissions, error) {
-	if err := p.db.
+func (p *PermissionsService) UpdatePermission(ctx context.Context, id uint, perm *entities.UpdatePermissions) (*entities.Permissions, error) {
+	ctx, span := tracing.Start(ctx, "PermissionsService.UpdatePermission")
+	defer span.End()
+
+	if err := p.db.WithContext(ctx).
 		Model(&entities.Permissions{}).
 		Where("id = ?", id).
 		Updates(perm).Error; err != nil {
 		return nil, err
 	}
 
-	return p.GetPermission(id)
+	p.events.Publish(ctx, &entities.PermissionsChanged{PermissionID: id})
+	return p.GetPermission(ctx, id)
 }
 
-func (p *PermissionsService) DeletePermission(id uint) error {
-	return p.db.Delete(&entities.Permissions{}, id).Error
+func (p *PermissionsService) DeletePermission(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "PermissionsService.DeletePermission")
+	defer span.End()
+
+	if err := p.db.WithContext(ctx).Delete(&entities.Permissions{}, id).Error; err != nil {
+		return err
+	}
+
+	p.events.Publish(ctx, &entities.PermissionsChanged{PermissionID: id})
+	return nil
 }
 
-func (p *PermissionsService) HasPermission(table_name string, roleId uint, action string) (uint, error) {
+func (p *PermissionsService) HasPermission(ctx context.Context, table_name string, roleId uint, action string) (uint, error) {
+	ctx, span := tracing.Start(ctx, "PermissionsService.HasPermission")
+	defer span.End()
+
 	var perm uint
 
-	if err := p.db.
+	if err := p.db.WithContext(ctx).
 		Model(&entities.Permissions{}).
 		Select(action).
 		Where("table_name = ? AND role_id = ?", table_name, roleId).
@@ -149,9 +180,12 @@ func (p *PermissionsService) HasPermission(table_name string, roleId uint, actio
 	return perm, nil
 }
 
-func (p *PermissionsService) CheckPermissionExists(table_name string, roleId uint) (bool, error) {
+func (p *PermissionsService) CheckPermissionExists(ctx context.Context, table_name string, roleId uint) (bool, error) {
+	ctx, span := tracing.Start(ctx, "PermissionsService.CheckPermissionExists")
+	defer span.End()
+
 	var count int64
-	err := p.db.Model(&entities.Permissions{}).
+	err := p.db.WithContext(ctx).Model(&entities.Permissions{}).
 		Where("table_name = ? AND role_id = ?", table_name, roleId).
 		Count(&count).Error
 	if err != nil {
@@ -160,11 +194,28 @@ func (p *PermissionsService) CheckPermissionExists(table_name string, roleId uin
 	return count > 0, nil
 }
 
-func (p *PermissionsService) GetTableList() ([]string, error) {
-	return p.db.Migrator().GetTables()
+func (p *PermissionsService) GetTableList(ctx context.Context) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "PermissionsService.GetTableList")
+	defer span.End()
+
+	p.tablesMu.Lock()
+	defer p.tablesMu.Unlock()
+
+	if p.tables == nil {
+		tables, err := p.db.WithContext(ctx).Migrator().GetTables()
+		if err != nil {
+			return nil, err
+		}
+		p.tables = tables
+	}
+
+	return slices.Clone(p.tables), nil
 }
 
 func (p *PermissionsService) GetRequestedActionAndTable(c *fiber.Ctx) (action string, table string) {
+	ctx, span := tracing.Start(c.UserContext(), "PermissionsService.GetRequestedActionAndTable")
+	defer span.End()
+
 	method := c.Method()
 	endpoint := c.Path()
 
@@ -194,20 +245,38 @@ func (p *PermissionsService) GetRequestedActionAndTable(c *fiber.Ctx) (action st
 		}
 	}
 
+	if len(result) == 0 {
+		return action, ""
+	}
+
+	// Sub-resource actions (e.g. /subscriptions/:sub_id/renew) are checked
+	// against the closest table in the endpoint
+	if tables, err := p.GetTableList(ctx); err == nil {
+		for i := len(result) - 1; i >= 0; i-- {
+			if slices.Contains(tables, result[i]) {
+				return action, result[i]
+			}
+		}
+	}
+
 	// Clean the endpoint
 	table = result[len(result)-1]
 	return action, table
 }
 
 func (p *PermissionsService) CreateSystemPermissions() error {
-	roleName := os.Getenv("SYS_ROLE_NAME")
+	ctx, span := tracing.Start(context.Background(), "PermissionsService.CreateSystemPermissions")
+	defer span.End()
+
+	roleName := p.system.RoleName
 	if roleName == "" {
-		log.Fatal("SYS_ROLE_NAME not found in .env file")
-		return errors.New("SYS_ROLE_NAME not found in .env file")
+		log.Fatal("SYS_ROLE_NAME not configured")
+		return errors.New("SYS_ROLE_NAME not configured")
 	}
 
 	// Get the system role
-	role, err := p.rolesServices.GetRoleByName(roleName)
+	role := new(entities.Roles)
+	err := p.db.WithContext(ctx).Where("name = ?", roleName).First(role).Error
 	if err != nil {
 		log.Fatal("Error getting system role: ", err)
 		return err
@@ -215,14 +284,14 @@ func (p *PermissionsService) CreateSystemPermissions() error {
 
 	// Check if permissions exists and how many
 	var permissions_count int64
-	err = p.db.Model(&entities.Permissions{}).Where("role_id = ?", role.ID).Count(&permissions_count).Error
+	err = p.db.WithContext(ctx).Model(&entities.Permissions{}).Where("role_id = ?", role.ID).Count(&permissions_count).Error
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		log.Fatal("Error checking if Permissions exists: ", err)
 		return err
 	}
 
 	// Get the list of tables
-	tables, err := p.GetTableList()
+	tables, err := p.GetTableList(ctx)
 	if err != nil {
 		log.Fatal("Error getting tables: ", err)
 		return err
@@ -231,7 +300,7 @@ func (p *PermissionsService) CreateSystemPermissions() error {
 	// Check the lenght between the tables and the permissions
 	if permissions_count < int64(len(tables)) {
 		// start transaction
-		tx := p.db.Begin()
+		tx := p.db.WithContext(ctx).Begin()
 		if tx.Error != nil {
 			log.Fatal("Error starting transaction: ", tx.Error)
 			return tx.Error
@@ -243,7 +312,7 @@ func (p *PermissionsService) CreateSystemPermissions() error {
 
 		for _, table := range tables {
 			// Check if the permission already exists
-			exists, err := p.CheckPermissionExists(table, role.ID)
+			exists, err := p.CheckPermissionExists(ctx, table, role.ID)
 			if err != nil {
 				tx.Rollback()
 				log.Fatal("Error checking if Permissions exists: ", err)
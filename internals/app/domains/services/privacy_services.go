@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"gorm.io/gorm"
+)
+
+type PrivacyServices struct {
+	db *gorm.DB
+}
+
+func NewPrivacyServices(db *gorm.DB) *PrivacyServices {
+	return &PrivacyServices{
+		db: db,
+	}
+}
+
+func (p *PrivacyServices) ExportMember(ctx context.Context, id uint) (*entities.MemberExport, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyServices.ExportMember")
+	defer span.End()
+
+	unscoped := func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}
+
+	export := &entities.MemberExport{
+		ExportedAt: time.Now(),
+	}
+
+	if err := p.db.WithContext(ctx).
+		Unscoped().
+		Preload("Contacts", unscoped).
+		Preload("Address", unscoped).
+		Preload("Subscription", unscoped).
+		First(&export.Member, id).
+		Error; err != nil {
+		return nil, err
+	}
+
+	if export.Member.HouseholdID != nil {
+		household := new(entities.Household)
+		if err := p.db.WithContext(ctx).First(household, *export.Member.HouseholdID).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		} else if err == nil {
+			export.Household = household
+		}
+	}
+
+	if err := p.db.WithContext(ctx).
+		Where("member_id = ?", id).
+		Find(&export.PromotionRedemptions).
+		Error; err != nil {
+		return nil, err
+	}
+
+	guest := new(entities.Guest)
+	if err := p.db.WithContext(ctx).Unscoped().Preload("Passes", unscoped).Where("member_id = ?", id).First(guest).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	} else if err == nil {
+		export.GuestProfile = guest
+	}
+
+	if err := p.db.WithContext(ctx).
+		Unscoped().
+		Where("sponsor_id = ?", id).
+		Find(&export.SponsoredGuests).
+		Error; err != nil {
+		return nil, err
+	}
+
+	if err := p.db.WithContext(ctx).
+		Where("member_id = ?", id).
+		Order("given_at").
+		Find(&export.Consents).
+		Error; err != nil {
+		return nil, err
+	}
+
+	if err := p.db.WithContext(ctx).
+		Where("member_id = ?", id).
+		Order("created_at").
+		Find(&export.Notifications).
+		Error; err != nil {
+		return nil, err
+	}
+
+	if err := memberAudits(p.db.WithContext(ctx), id).
+		Order("created_at").
+		Find(&export.Changes).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+func (p *PrivacyServices) EraseMember(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "PrivacyServices.EraseMember")
+	defer span.End()
+
+	member := new(entities.Member)
+	if err := p.db.WithContext(ctx).Unscoped().First(member, id).Error; err != nil {
+		return err
+	}
+	if member.ErasedAt != nil {
+		return entities.NewConflictError(i18n.MsgMemberAlreadyAnonymized)
+	}
+
+	// Keep the year of birth for the statistics
+	now := time.Now()
+	birthYear := time.Date(member.DateOfBirth.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tx := p.db.WithContext(ctx).Begin()
+	if err := tx.
+		Unscoped().
+		Model(member).
+		Updates(map[string]interface{}{
+			"name":          entities.ErasedName,
+			"surname":       fmt.Sprintf("#%d", member.ID),
+			"gender":        "",
+			"date_of_birth": birthYear,
+			"erased_at":     now,
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.
+		Unscoped().
+		Model(entities.Contacts{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"phone": "", "email": ""}).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// The country is kept for tax purposes
+	if err := tx.
+		Unscoped().
+		Model(entities.Address{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"city": "", "street": ""}).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// The guest the member was converted from
+	if err := tx.
+		Unscoped().
+		Model(entities.Guest{}).
+		Where("member_id = ?", id).
+		Updates(map[string]interface{}{
+			"name":    entities.ErasedName,
+			"surname": fmt.Sprintf("#%d", member.ID),
+			"phone":   "",
+			"email":   "",
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// The notifications sent, the rule and the channel are kept for the statistics
+	if err := tx.
+		Model(entities.Notification{}).
+		Where("member_id = ?", id).
+		Updates(map[string]interface{}{
+			"recipient": "",
+			"subject":   "",
+			"body":      "",
+			"error":     "",
+		}).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// The events sent, or to be sent, to the webhooks
+	var deliveries []entities.WebhookDelivery
+	if err := tx.Where("member_id = ?", id).Find(&deliveries).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for i := range deliveries {
+		if err := deliveries[i].Erase(); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Model(&deliveries[i]).Update("payload", deliveries[i].Payload).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// The recorded changes hold the previous personal data
+	if err := memberAudits(tx, id).
+		Update("changes", nil).
+		Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// memberAudits selects the audits of the records of the member: the member,
+// its contacts and address, the guest it was converted from and its consents.
+func memberAudits(db *gorm.DB, id uint) *gorm.DB {
+	guests := db.Unscoped().Model(entities.Guest{}).Select("id").Where("member_id = ?", id)
+	consents := db.Model(entities.Consent{}).Select("id").Where("member_id = ?", id)
+
+	return db.
+		Model(entities.Audit{}).
+		Where("entity IN ? AND entity_id = ?", []string{"members", "contacts", "addresses"}, id).
+		Or("entity = ? AND entity_id IN (?)", "guests", guests).
+		Or("entity = ? AND entity_id IN (?)", "consents", consents)
+}
+
+func (p *PrivacyServices) GetMemberConsents(ctx context.Context, member_id uint) ([]entities.Consent, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyServices.GetMemberConsents")
+	defer span.End()
+
+	var consents []entities.Consent
+	if err := p.db.WithContext(ctx).
+		Where("member_id = ?", member_id).
+		Order("given_at desc").
+		Find(&consents).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return consents, nil
+}
+
+func (p *PrivacyServices) GiveConsent(ctx context.Context, consent *entities.Consent) error {
+	ctx, span := tracing.Start(ctx, "PrivacyServices.GiveConsent")
+	defer span.End()
+
+	if consent.GivenAt.IsZero() {
+		consent.GivenAt = time.Now()
+	}
+
+	tx := p.db.WithContext(ctx).Begin()
+
+	active := new(entities.Consent)
+	err := tx.
+		Where("member_id = ? AND type = ? AND revoked_at IS NULL", consent.MemberID, consent.Type).
+		First(active).
+		Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return err
+	}
+
+	// Replace the consent given to a previous version of the policy
+	if err == nil {
+		if active.PolicyVersion == consent.PolicyVersion {
+			tx.Rollback()
+			return entities.NewConflictError(i18n.MsgConsentAlreadyGiven, consent.Type, consent.PolicyVersion)
+		}
+
+		if err := tx.
+			Model(active).
+			Updates(map[string]interface{}{
+				"revoked_at": consent.GivenAt,
+				"revoked_by": consent.CollectedBy,
+			}).
+			Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Create(consent).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+func (p *PrivacyServices) RevokeConsent(ctx context.Context, member_id uint, consent_id uint, user_id uint) (*entities.Consent, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyServices.RevokeConsent")
+	defer span.End()
+
+	consent := new(entities.Consent)
+	if err := p.db.WithContext(ctx).
+		Where("id = ? AND member_id = ?", consent_id, member_id).
+		First(consent).
+		Error; err != nil {
+		return nil, err
+	}
+
+	if !consent.IsActive() {
+		return nil, entities.NewConflictError(i18n.MsgConsentAlreadyRevoked)
+	}
+
+	now := time.Now()
+	consent.RevokedAt = &now
+	consent.RevokedBy = &user_id
+	if err := p.db.WithContext(ctx).
+		Model(consent).
+		Updates(map[string]interface{}{
+			"revoked_at": consent.RevokedAt,
+			"revoked_by": consent.RevokedBy,
+		}).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return consent, nil
+}
+
+func (p *PrivacyServices) HasConsent(ctx context.Context, member_id uint, consentType string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "PrivacyServices.HasConsent")
+	defer span.End()
+
+	var count int64
+	if err := p.db.WithContext(ctx).
+		Model(&entities.Consent{}).
+		Where("member_id = ? AND type = ? AND revoked_at IS NULL", member_id, consentType).
+		Count(&count).
+		Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,132 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+)
+
+func TestEraseMemberAnonymizesNotifications(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	member := newTestMember(t, db, "Mario")
+	privacy := services.NewPrivacyServices(db)
+
+	notification := &entities.Notification{
+		MemberID:  member.ID,
+		Rule:      entities.RuleBirthday,
+		Channel:   entities.ChannelEmail,
+		Recipient: member.Contacts.Email,
+		Subject:   "Buon compleanno Mario",
+		Body:      "Ciao Mario Rossi",
+		Status:    entities.NotificationFailed,
+		Error:     "mailbox " + member.Contacts.Email + " not found",
+		DedupKey:  "compleanno:2026:1:email",
+	}
+	if err := db.Create(notification).Error; err != nil {
+		t.Fatalf("creating the notification: %v", err)
+	}
+
+	export, err := privacy.ExportMember(ctx, member.ID)
+	if err != nil {
+		t.Fatalf("exporting the member: %v", err)
+	}
+	if len(export.Notifications) != 1 || export.Notifications[0].Recipient != member.Contacts.Email {
+		t.Fatalf("export notifications = %+v, want the notification sent to %s", export.Notifications, member.Contacts.Email)
+	}
+
+	if err := privacy.EraseMember(ctx, member.ID); err != nil {
+		t.Fatalf("erasing the member: %v", err)
+	}
+
+	erased := new(entities.Notification)
+	if err := db.First(erased, notification.ID).Error; err != nil {
+		t.Fatalf("loading the notification: %v", err)
+	}
+	for field, value := range map[string]string{
+		"recipient": erased.Recipient,
+		"subject":   erased.Subject,
+		"body":      erased.Body,
+		"error":     erased.Error,
+	} {
+		if value != "" {
+			t.Errorf("%s = %q after the erasure, want empty", field, value)
+		}
+	}
+	if erased.Rule != entities.RuleBirthday || erased.Channel != entities.ChannelEmail {
+		t.Errorf("rule and channel = %q, %q, want them kept", erased.Rule, erased.Channel)
+	}
+
+	export, err = privacy.ExportMember(ctx, member.ID)
+	if err != nil {
+		t.Fatalf("exporting the erased member: %v", err)
+	}
+	for _, n := range export.Notifications {
+		if strings.Contains(n.Body, "Mario") {
+			t.Errorf("exported notification body %q holds the erased name", n.Body)
+		}
+	}
+}
+
+func TestEraseMemberClearsAudits(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	member := newTestMember(t, db, "Mario")
+	privacy := services.NewPrivacyServices(db)
+
+	guest := &entities.Guest{Name: "Mario", Surname: "Rossi", Phone: member.Contacts.Phone, MemberID: &member.ID}
+	consent := &entities.Consent{MemberID: member.ID, Type: "privacy", PolicyVersion: "1", GivenAt: time.Now(), CollectedBy: 1}
+	for _, record := range []interface{}{guest, consent} {
+		if err := db.Create(record).Error; err != nil {
+			t.Fatalf("creating %T: %v", record, err)
+		}
+	}
+
+	personal := map[string]entities.AuditChange{
+		"name":  {After: "Mario"},
+		"phone": {After: member.Contacts.Phone},
+		"email": {After: member.Contacts.Email},
+	}
+	audits := []entities.Audit{
+		{Action: entities.AuditCreate, Entity: "members", EntityID: member.ID, Changes: personal},
+		{Action: entities.AuditUpdate, Entity: "contacts", EntityID: member.ID, Changes: personal},
+		{Action: entities.AuditCreate, Entity: "guests", EntityID: guest.ID, Changes: personal},
+		{Action: entities.AuditCreate, Entity: "consents", EntityID: consent.ID, Changes: personal},
+	}
+	if err := db.Create(&audits).Error; err != nil {
+		t.Fatalf("creating the audits: %v", err)
+	}
+
+	export, err := privacy.ExportMember(ctx, member.ID)
+	if err != nil {
+		t.Fatalf("exporting the member: %v", err)
+	}
+	if len(export.Changes) != len(audits) {
+		t.Fatalf("exported changes = %d, want %d", len(export.Changes), len(audits))
+	}
+
+	if err := privacy.EraseMember(ctx, member.ID); err != nil {
+		t.Fatalf("erasing the member: %v", err)
+	}
+
+	var stored []entities.Audit
+	if err := db.Find(&stored).Error; err != nil {
+		t.Fatalf("loading the audits: %v", err)
+	}
+	for _, audit := range stored {
+		encoded, err := json.Marshal(audit.Changes)
+		if err != nil {
+			t.Fatalf("encoding the changes: %v", err)
+		}
+		for _, value := range []string{"Mario", member.Contacts.Phone, member.Contacts.Email} {
+			if strings.Contains(string(encoded), value) {
+				t.Errorf("%s audit %d holds %q after the erasure", audit.Entity, audit.ID, value)
+			}
+		}
+	}
+}
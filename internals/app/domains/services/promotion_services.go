@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"gorm.io/gorm"
+)
+
+type PromotionServices struct {
+	db *gorm.DB
+}
+
+func NewPromotionServices(db *gorm.DB) *PromotionServices {
+	return &PromotionServices{
+		db: db,
+	}
+}
+
+func (p *PromotionServices) CreatePromotion(ctx context.Context, promotion *entities.Promotion) error {
+	ctx, span := tracing.Start(ctx, "PromotionServices.CreatePromotion")
+	defer span.End()
+
+	promotion.Code = entities.NormalizeCode(promotion.Code)
+	promotion.Uses = 0
+	return p.db.WithContext(ctx).
+		Create(promotion).
+		Error
+}
+
+func (p *PromotionServices) GetAllPromotions(ctx context.Context) ([]entities.Promotion, error) {
+	ctx, span := tracing.Start(ctx, "PromotionServices.GetAllPromotions")
+	defer span.End()
+
+	var promotions []entities.Promotion
+	if err := p.db.WithContext(ctx).
+		Order("valid_from DESC").
+		Find(&promotions).
+		Error; err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (p *PromotionServices) GetPromotion(ctx context.Context, id uint) (*entities.Promotion, error) {
+	ctx, span := tracing.Start(ctx, "PromotionServices.GetPromotion")
+	defer span.End()
+
+	promotion := new(entities.Promotion)
+	if err := p.db.WithContext(ctx).First(promotion, id).Error; err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+func (p *PromotionServices) UpdatePromotion(ctx context.Context, id uint, promotion *entities.UpdatePromotion) (*entities.Promotion, error) {
+	ctx, span := tracing.Start(ctx, "PromotionServices.UpdatePromotion")
+	defer span.End()
+
+	tx := p.db.WithContext(ctx).Begin()
+
+	// The values not updated are the stored ones
+	stored := new(entities.Promotion)
+	if err := tx.First(stored, id).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	stored.ApplyUpdate(promotion)
+	if err := stored.Validate(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Every updatable field is written, the cleared ones too
+	if err := tx.
+		Model(stored).
+		Select("description", "value", "valid_from", "valid_until", "max_uses", "eligible_types", "first_time_only").
+		Updates(stored).
+		Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return p.GetPromotion(ctx, id)
+}
+
+func (p *PromotionServices) DeletePromotion(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "PromotionServices.DeletePromotion")
+	defer span.End()
+
+	return p.db.WithContext(ctx).Delete(&entities.Promotion{}, id).Error
+}
+
+func (p *PromotionServices) GetPromotionRedemptions(ctx context.Context, id uint) ([]entities.PromotionRedemption, error) {
+	ctx, span := tracing.Start(ctx, "PromotionServices.GetPromotionRedemptions")
+	defer span.End()
+
+	var redemptions []entities.PromotionRedemption
+	if err := p.db.WithContext(ctx).
+		Where("promotion_id = ?", id).
+		Order("created_at DESC").
+		Find(&redemptions).
+		Error; err != nil {
+		return nil, err
+	}
+	return redemptions, nil
+}
+
+func (p *PromotionServices) GetRedemptionsReport(ctx context.Context) ([]entities.PromotionReport, error) {
+	ctx, span := tracing.Start(ctx, "PromotionServices.GetRedemptionsReport")
+	defer span.End()
+
+	var report []entities.PromotionReport
+	if err := p.db.WithContext(ctx).
+		Model(&entities.Promotion{}).
+		Select(`promotions.id AS promotion_id,
+			promotions.code AS code,
+			COUNT(promotion_redemptions.id) AS redemptions,
+			COALESCE(SUM(promotion_redemptions.discount), 0) AS total_discount,
+			COALESCE(SUM(promotion_redemptions.final_price), 0) AS total_revenue`).
+		Joins("LEFT JOIN promotion_redemptions ON promotion_redemptions.promotion_id = promotions.id").
+		Group("promotions.id, promotions.code").
+		Order("redemptions DESC").
+		Scan(&report).
+		Error; err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// applyPromotion applies the promotion with the given code to the subscription
+// price. An empty code applies nothing.
+//
+// Parameters:
+//   - tx: the transaction creating the subscription.
+//   - code: the promotion code.
+//   - member_id: the ID of the member, 0 for a new member.
+//   - subscription: the subscription to discount.
+//
+// Returns:
+//   - *entities.PromotionRedemption: the redemption to record, with the discount of the promotion only, nil if none.
+//   - error: entities.ValidationErrors if the promotion can't be applied.
+func applyPromotion(tx *gorm.DB, code string, member_id uint, subscription *entities.Subscription) (*entities.PromotionRedemption, error) {
+	// Keep track of the price before discounts
+	if subscription.OriginalPrice == 0 {
+		subscription.OriginalPrice = subscription.Price
+	}
+
+	code = entities.NormalizeCode(code)
+	if code == "" {
+		return nil, nil
+	}
+
+	promotion := new(entities.Promotion)
+	if err := tx.Where("code = ?", code).First(promotion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, promotionError("not_found", i18n.MsgPromotionCodeNotExists)
+		}
+		return nil, err
+	}
+
+	if !promotion.IsValidAt(time.Now()) {
+		return nil, promotionError("expired", i18n.MsgPromotionCodeExpired)
+	}
+
+	if promotion.IsExhausted() {
+		return nil, promotionError("exhausted", i18n.MsgPromotionCodeExhausted)
+	}
+
+	if !promotion.IsEligible(subscription.Type) {
+		return nil, promotionError("not_eligible", i18n.MsgPromotionCodeNotEligible)
+	}
+
+	// First-time promotions are for members without subscriptions
+	if promotion.FirstTimeOnly && member_id != 0 {
+		var subscriptions int64
+		if err := tx.
+			Model(entities.Subscription{}).
+			Where("user_id = ?", member_id).
+			Count(&subscriptions).
+			Error; err != nil {
+			return nil, err
+		}
+		if subscriptions > 0 {
+			return nil, promotionError("first_time_only", i18n.MsgPromotionCodeFirstTimeOnly)
+		}
+	}
+
+	// The subscription discount also holds the family-plan one
+	discount := promotion.DiscountFor(subscription.Price)
+	subscription.ApplyDiscount(discount)
+	subscription.PromotionID = &promotion.ID
+	return &entities.PromotionRedemption{
+		PromotionID: promotion.ID,
+		Discount:    discount,
+	}, nil
+}
+
+// recordRedemption records the use of the promotion on a created subscription.
+// A nil redemption records nothing.
+func recordRedemption(tx *gorm.DB, redemption *entities.PromotionRedemption, subscription *entities.Subscription) error {
+	if redemption == nil {
+		return nil
+	}
+
+	// Count the use only if the limit is not reached in the meantime
+	result := tx.
+		Model(&entities.Promotion{}).
+		Where("id = ? AND (max_uses IS NULL OR uses < max_uses)", redemption.PromotionID).
+		Update("uses", gorm.Expr("uses + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return promotionError("exhausted", i18n.MsgPromotionCodeExhausted)
+	}
+
+	redemption.SubscriptionID = subscription.ID
+	redemption.MemberID = subscription.UserID
+	redemption.OriginalPrice = subscription.OriginalPrice
+	redemption.FinalPrice = subscription.Price
+	return tx.Create(redemption).Error
+}
+
+func promotionError(code string, message string) entities.ValidationErrors {
+	var errs entities.ValidationErrors
+	errs.Add("promotion_code", code, message)
+	return errs
+}
@@ -0,0 +1,50 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+)
+
+func TestUpdatePromotionClearsLimits(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	promotions := services.NewPromotionServices(db)
+
+	maxUses := uint(10)
+	promotion := &entities.Promotion{
+		Code:          "ESTATE",
+		Kind:          entities.PromotionPercentage,
+		Value:         20,
+		ValidFrom:     time.Now(),
+		ValidUntil:    time.Now().AddDate(0, 1, 0),
+		MaxUses:       &maxUses,
+		EligibleTypes: []string{"mensile"},
+	}
+	if err := promotions.CreatePromotion(ctx, promotion); err != nil {
+		t.Fatalf("creating the promotion: %v", err)
+	}
+
+	unlimited := uint(0)
+	validUntil := promotion.ValidFrom.AddDate(0, 0, 7)
+	updated, err := promotions.UpdatePromotion(ctx, promotion.ID, &entities.UpdatePromotion{MaxUses: &unlimited, EligibleTypes: []string{}, ValidUntil: validUntil})
+	if err != nil {
+		t.Fatalf("updating the promotion: %v", err)
+	}
+
+	if updated.MaxUses != nil {
+		t.Errorf("max uses = %d, want unlimited", *updated.MaxUses)
+	}
+	if len(updated.EligibleTypes) != 0 {
+		t.Errorf("eligible types = %v, want every type", updated.EligibleTypes)
+	}
+	if !updated.ValidUntil.Equal(validUntil) {
+		t.Errorf("valid until = %v, want %v", updated.ValidUntil, validUntil)
+	}
+	if updated.Value != promotion.Value || updated.Code != promotion.Code {
+		t.Errorf("updated = %+v, want the other values kept", updated)
+	}
+}
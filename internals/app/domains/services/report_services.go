@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"gorm.io/gorm"
+)
+
+// Average days of a month, to spread the subscription prices
+const daysPerMonth = 365.25 / 12
+
+type ReportServices struct {
+	db    *gorm.DB
+	cache ports.CacheAdapters
+}
+
+func NewReportServices(db *gorm.DB, cache ports.CacheAdapters) *ReportServices {
+	return &ReportServices{
+		db:    db,
+		cache: cache,
+	}
+}
+
+// memberHistory is the subscription history of a member.
+type memberHistory struct {
+	first         time.Time // Start of the first subscription
+	last          time.Time // End of the last subscription
+	subscriptions []entities.Subscription
+}
+
+// activeAt checks if the member has a subscription at the given time.
+func (h *memberHistory) activeAt(t time.Time) bool {
+	for _, sub := range h.subscriptions {
+		if isActiveAt(&sub, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReportServices) GetRevenueReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error) {
+	ctx, span := tracing.Start(ctx, "ReportServices.GetRevenueReport")
+	defer span.End()
+
+	return r.cached(ctx, "revenue", period, func(subscriptions []entities.Subscription, now time.Time) interface{} {
+		revenues := []entities.MonthlyRevenue{}
+		for _, month := range pastMonths(period, now) {
+			t := monthSnapshot(month, now)
+			revenue := entities.MonthlyRevenue{Month: month.Format(entities.ReportMonthLayout)}
+			for i := range subscriptions {
+				if isActiveAt(&subscriptions[i], t) {
+					revenue.Subscriptions++
+					revenue.MRR += monthlyPrice(&subscriptions[i])
+				}
+			}
+			revenue.MRR = round2(revenue.MRR)
+			revenues = append(revenues, revenue)
+		}
+		return revenues
+	})
+}
+
+func (r *ReportServices) GetGrowthReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error) {
+	ctx, span := tracing.Start(ctx, "ReportServices.GetGrowthReport")
+	defer span.End()
+
+	return r.cached(ctx, "growth", period, func(subscriptions []entities.Subscription, now time.Time) interface{} {
+		histories := memberHistories(subscriptions)
+
+		growth := []entities.MemberGrowth{}
+		for _, month := range pastMonths(period, now) {
+			next := month.AddDate(0, 1, 0)
+			row := entities.MemberGrowth{Month: month.Format(entities.ReportMonthLayout)}
+			for _, history := range histories {
+				if inRange(history.first, month, next) {
+					row.New++
+				}
+				if inRange(history.last, month, next) && !history.last.After(now) {
+					row.Churned++
+				}
+			}
+			row.Net = row.New - row.Churned
+			growth = append(growth, row)
+		}
+		return growth
+	})
+}
+
+func (r *ReportServices) GetActiveMembersReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error) {
+	ctx, span := tracing.Start(ctx, "ReportServices.GetActiveMembersReport")
+	defer span.End()
+
+	return r.cached(ctx, "active", period, func(subscriptions []entities.Subscription, now time.Time) interface{} {
+		histories := memberHistories(subscriptions)
+
+		active := []entities.ActiveMembers{}
+		for _, month := range pastMonths(period, now) {
+			t := monthSnapshot(month, now)
+			row := entities.ActiveMembers{Month: month.Format(entities.ReportMonthLayout)}
+			for _, history := range histories {
+				if history.activeAt(t) {
+					row.Members++
+				}
+			}
+			active = append(active, row)
+		}
+		return active
+	})
+}
+
+func (r *ReportServices) GetSubscriptionMixReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error) {
+	ctx, span := tracing.Start(ctx, "ReportServices.GetSubscriptionMixReport")
+	defer span.End()
+
+	return r.cached(ctx, "mix", period, func(subscriptions []entities.Subscription, now time.Time) interface{} {
+		types := make(map[string]*entities.SubscriptionMix)
+		total := 0
+		for _, sub := range subscriptions {
+			if !inRange(sub.StartDate, period.From, period.End()) {
+				continue
+			}
+			if types[sub.Type] == nil {
+				types[sub.Type] = &entities.SubscriptionMix{Type: sub.Type}
+			}
+			types[sub.Type].Subscriptions++
+			types[sub.Type].Revenue += sub.Price
+			total++
+		}
+
+		mix := []entities.SubscriptionMix{}
+		for _, row := range types {
+			row.Share = round2(float32(row.Subscriptions) / float32(total) * 100)
+			row.Revenue = round2(row.Revenue)
+			mix = append(mix, *row)
+		}
+		sort.Slice(mix, func(i, j int) bool {
+			if mix[i].Subscriptions != mix[j].Subscriptions {
+				return mix[i].Subscriptions > mix[j].Subscriptions
+			}
+			return mix[i].Type < mix[j].Type
+		})
+		return mix
+	})
+}
+
+func (r *ReportServices) GetLifetimeReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error) {
+	ctx, span := tracing.Start(ctx, "ReportServices.GetLifetimeReport")
+	defer span.End()
+
+	return r.cached(ctx, "lifetime", period, func(subscriptions []entities.Subscription, now time.Time) interface{} {
+		var lifetime entities.MemberLifetime
+		var months, churnedMonths float64
+		for _, history := range memberHistories(subscriptions) {
+			if !inRange(history.first, period.From, period.End()) {
+				continue
+			}
+
+			end := history.last
+			if end.After(now) {
+				end = now
+			} else {
+				lifetime.Churned++
+				churnedMonths += monthsBetween(history.first, end)
+			}
+
+			lifetime.Members++
+			months += monthsBetween(history.first, end)
+		}
+
+		if lifetime.Members > 0 {
+			lifetime.AverageMonths = round2(float32(months / float64(lifetime.Members)))
+		}
+		if lifetime.Churned > 0 {
+			lifetime.ChurnedAverageMonths = round2(float32(churnedMonths / float64(lifetime.Churned)))
+		}
+		return lifetime
+	})
+}
+
+func (r *ReportServices) GetRetentionReport(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error) {
+	ctx, span := tracing.Start(ctx, "ReportServices.GetRetentionReport")
+	defer span.End()
+
+	return r.cached(ctx, "retention", period, func(subscriptions []entities.Subscription, now time.Time) interface{} {
+		histories := memberHistories(subscriptions)
+		months := pastMonths(period, now)
+
+		cohorts := []entities.RetentionCohort{}
+		for i, month := range months {
+			next := month.AddDate(0, 1, 0)
+
+			var members []*memberHistory
+			for _, history := range histories {
+				if inRange(history.first, month, next) {
+					members = append(members, history)
+				}
+			}
+
+			cohort := entities.RetentionCohort{
+				Month:     month.Format(entities.ReportMonthLayout),
+				Members:   len(members),
+				Retention: []float32{},
+			}
+			for _, later := range months[i:] {
+				if len(members) == 0 {
+					break
+				}
+
+				t := monthSnapshot(later, now)
+				retained := 0
+				for _, history := range members {
+					if history.activeAt(t) {
+						retained++
+					}
+				}
+				cohort.Retention = append(cohort.Retention, round2(float32(retained)/float32(len(members))*100))
+			}
+			cohorts = append(cohorts, cohort)
+		}
+		return cohorts
+	})
+}
+
+// cached returns the report from the cache or computes it from the subscriptions.
+func (r *ReportServices) cached(ctx context.Context, name string, period *entities.ReportPeriod, compute func(subscriptions []entities.Subscription, now time.Time) interface{}) (*entities.Report, error) {
+	report := entities.NewReport(name, period)
+	if err := r.cache.WithContext(ctx).GetCacheFromData(report); err == nil {
+		return report, nil
+	}
+
+	var subscriptions []entities.Subscription
+	if err := r.db.WithContext(ctx).
+		Select("id", "user_id", "type", "start_date", "end_date", "price").
+		Order("start_date").
+		Find(&subscriptions).
+		Error; err != nil {
+		return nil, err
+	}
+
+	report.GeneratedAt = time.Now()
+	report.Data = compute(subscriptions, report.GeneratedAt)
+
+	// The report is returned even if it can't be cached
+	if err := r.cache.WithContext(ctx).SetCache(report); err != nil {
+		slog.Warn("Error caching report", "report", name, "error", err)
+	}
+
+	return report, nil
+}
+
+// memberHistories groups the subscriptions by member.
+func memberHistories(subscriptions []entities.Subscription) map[uint]*memberHistory {
+	histories := make(map[uint]*memberHistory)
+	for _, sub := range subscriptions {
+		history, ok := histories[sub.UserID]
+		if !ok {
+			history = &memberHistory{first: sub.StartDate}
+			histories[sub.UserID] = history
+		}
+
+		if sub.StartDate.Before(history.first) {
+			history.first = sub.StartDate
+		}
+		if sub.EndDate.After(history.last) {
+			history.last = sub.EndDate
+		}
+		history.subscriptions = append(history.subscriptions, sub)
+	}
+	return histories
+}
+
+// pastMonths returns the months of the period already started.
+func pastMonths(period *entities.ReportPeriod, now time.Time) []time.Time {
+	var months []time.Time
+	for _, month := range period.Months() {
+		if month.After(now) {
+			break
+		}
+		months = append(months, month)
+	}
+	return months
+}
+
+// monthSnapshot returns the end of the month, or now for the current month.
+func monthSnapshot(month time.Time, now time.Time) time.Time {
+	end := month.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	if end.After(now) {
+		return now
+	}
+	return end
+}
+
+func isActiveAt(s *entities.Subscription, t time.Time) bool {
+	return !s.StartDate.After(t) && s.EndDate.After(t)
+}
+
+func inRange(t time.Time, from time.Time, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
+
+// monthlyPrice spreads the price of a subscription over the months it lasts,
+// rounded to a tenth so that calendar months of 28 to 31 days count as one.
+func monthlyPrice(s *entities.Subscription) float32 {
+	months := math.Round(monthsBetween(s.StartDate, s.EndDate)*10) / 10
+	return s.Price / float32(math.Max(months, 1))
+}
+
+func monthsBetween(from time.Time, to time.Time) float64 {
+	return to.Sub(from).Hours() / 24 / daysPerMonth
+}
+
+func round2(value float32) float32 {
+	return float32(math.Round(float64(value)*100) / 100)
+}
@@ -1,35 +1,52 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"log"
-	"os"
 
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
 	"gorm.io/gorm"
 )
 
 type RolesServices struct {
-	db *gorm.DB
+	db     *gorm.DB
+	events ports.EventBus
+	system entities.SystemConfig
 }
 
-func NewRolesServices(db *gorm.DB) *RolesServices {
+func NewRolesServices(db *gorm.DB, events ports.EventBus, system entities.SystemConfig) *RolesServices {
 	return &RolesServices{
-		db: db,
+		db:     db,
+		events: events,
+		system: system,
 	}
 }
 
-func (r *RolesServices) CreateRole(role *entities.Roles) error {
-	return r.db.
+func (r *RolesServices) CreateRole(ctx context.Context, role *entities.Roles) error {
+	ctx, span := tracing.Start(ctx, "RolesServices.CreateRole")
+	defer span.End()
+
+	if err := r.db.WithContext(ctx).
 		Create(role).
-		Error
+		Error; err != nil {
+		return err
+	}
+
+	r.events.Publish(ctx, &entities.RoleCreated{Role: role})
+	return nil
 }
 
-func (r *RolesServices) GetAllRoles() ([]entities.Roles, error) {
-	systemRoleName := os.Getenv("SYS_ROLE_NAME")
+func (r *RolesServices) GetAllRoles(ctx context.Context) ([]entities.Roles, error) {
+	ctx, span := tracing.Start(ctx, "RolesServices.GetAllRoles")
+	defer span.End()
+
+	systemRoleName := r.system.RoleName
 
 	var roles []entities.Roles
-	if err := r.db.
+	if err := r.db.WithContext(ctx).
 		Preload("Users", func(db *gorm.DB) *gorm.DB {
 			return db.Omit("password")
 		}).
@@ -40,11 +57,14 @@ func (r *RolesServices) GetAllRoles() ([]entities.Roles, error) {
 	return roles, nil
 }
 
-func (r *RolesServices) GetRole(id uint) (*entities.Roles, error) {
-	systemRoleName := os.Getenv("SYS_ROLE_NAME")
+func (r *RolesServices) GetRole(ctx context.Context, id uint) (*entities.Roles, error) {
+	ctx, span := tracing.Start(ctx, "RolesServices.GetRole")
+	defer span.End()
+
+	systemRoleName := r.system.RoleName
 
 	var role entities.Roles
-	if err := r.db.
+	if err := r.db.WithContext(ctx).
 		Preload("Users", func(db *gorm.DB) *gorm.DB {
 			return db.Omit("password")
 		}).
@@ -56,9 +76,12 @@ func (r *RolesServices) GetRole(id uint) (*entities.Roles, error) {
 	return &role, nil
 }
 
-func (r *RolesServices) GetRoleByName(name string) (*entities.Roles, error) {
+func (r *RolesServices) GetRoleByName(ctx context.Context, name string) (*entities.Roles, error) {
+	ctx, span := tracing.Start(ctx, "RolesServices.GetRoleByName")
+	defer span.End()
+
 	var role entities.Roles
-	if err := r.db.
+	if err := r.db.WithContext(ctx).
 		Preload("Users", func(db *gorm.DB) *gorm.DB {
 			return db.Omit("password")
 		}).
@@ -70,11 +93,14 @@ func (r *RolesServices) GetRoleByName(name string) (*entities.Roles, error) {
 	return &role, nil
 }
 
-func (r *RolesServices) GetRolePermissions(roleID uint) ([]entities.Permissions, error) {
-	systemRoleName := os.Getenv("SYS_ROLE_NAME")
+func (r *RolesServices) GetRolePermissions(ctx context.Context, roleID uint) ([]entities.Permissions, error) {
+	ctx, span := tracing.Start(ctx, "RolesServices.GetRolePermissions")
+	defer span.End()
+
+	systemRoleName := r.system.RoleName
 
 	var permissions []entities.Permissions
-	if err := r.db.
+	if err := r.db.WithContext(ctx).
 		Joins("JOIN roles ON roles.id = permissions.role_id").
 		Where("roles.name != ? AND permissions.role_id = ?", systemRoleName, roleID).
 		Find(&permissions).
@@ -84,35 +110,54 @@ func (r *RolesServices) GetRolePermissions(roleID uint) ([]entities.Permissions,
 	return permissions, nil
 }
 
-func (r *RolesServices) UpdateRole(id uint, role *entities.UpdateRoles) error {
-	systemRoleName := os.Getenv("SYS_ROLE_NAME")
-	return r.db.
+func (r *RolesServices) UpdateRole(ctx context.Context, id uint, role *entities.UpdateRoles) error {
+	ctx, span := tracing.Start(ctx, "RolesServices.UpdateRole")
+	defer span.End()
+
+	systemRoleName := r.system.RoleName
+	if err := r.db.WithContext(ctx).
 		Model(&entities.Roles{}).
 		Where("id = ? AND name != ?", id, systemRoleName).
-		Updates(role).Error
+		Updates(role).Error; err != nil {
+		return err
+	}
+
+	r.events.Publish(ctx, &entities.RoleUpdated{RoleID: id})
+	return nil
 }
 
-func (r *RolesServices) DeleteRole(id uint) error {
-	systemRoleName := os.Getenv("SYS_ROLE_NAME")
+func (r *RolesServices) DeleteRole(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "RolesServices.DeleteRole")
+	defer span.End()
 
-	return r.db.
+	systemRoleName := r.system.RoleName
+
+	if err := r.db.WithContext(ctx).
 		Where("name != ?", systemRoleName).
 		Delete(&entities.Roles{}, id).
-		Error
+		Error; err != nil {
+		return err
+	}
+
+	r.events.Publish(ctx, &entities.RoleDeleted{RoleID: id})
+	return nil
 }
 
 func (r *RolesServices) CreateSystemRole() error {
-	roleName := os.Getenv("SYS_ROLE_NAME")
+	ctx, span := tracing.Start(context.Background(), "RolesServices.CreateSystemRole")
+	defer span.End()
+
+	roleName := r.system.RoleName
 
 	// Check if role ID and name are provided
 	if roleName == "" {
-		log.Fatal("System role ID or name not provided in .env file")
+		log.Fatal("System role name not configured")
 		return errors.New("system role ID or name not provided")
 	}
 
 	// Check if role exists
 	var role_count int64
-	err := r.db.Model(&entities.Roles{}).Where("name = ?", roleName).Count(&role_count).Error
+	err := r.db.WithContext(ctx).Model(&entities.Roles{}).Where("name = ?", roleName).Count(&role_count).Error
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		log.Fatal("Error checking role existence: ", err)
 		return err
@@ -120,7 +165,7 @@ func (r *RolesServices) CreateSystemRole() error {
 
 	if role_count == 0 {
 		// start transaction
-		tx := r.db.Begin()
+		tx := r.db.WithContext(ctx).Begin()
 		if tx.Error != nil {
 			log.Fatal("Error starting transaction: ", tx.Error)
 			return tx.Error
@@ -146,10 +191,13 @@ func (r *RolesServices) CreateSystemRole() error {
 	return nil
 }
 
-func (r *RolesServices) GetSystemRole() (*entities.Roles, error) {
-	roleName := os.Getenv("SYS_ROLE_NAME")
+func (r *RolesServices) GetSystemRole(ctx context.Context) (*entities.Roles, error) {
+	ctx, span := tracing.Start(ctx, "RolesServices.GetSystemRole")
+	defer span.End()
+
+	roleName := r.system.RoleName
 	var role entities.Roles
-	if err := r.db.
+	if err := r.db.WithContext(ctx).
 		Where("name = ?", roleName).
 		First(&role).
 		Error; err != nil {
@@ -158,10 +206,13 @@ func (r *RolesServices) GetSystemRole() (*entities.Roles, error) {
 	return &role, nil
 }
 
-func (r *RolesServices) IsSystemRole(roleID uint) bool {
-	roleName := os.Getenv("SYS_ROLE_NAME")
+func (r *RolesServices) IsSystemRole(ctx context.Context, roleID uint) bool {
+	ctx, span := tracing.Start(ctx, "RolesServices.IsSystemRole")
+	defer span.End()
+
+	roleName := r.system.RoleName
 	var role entities.Roles
-	if err := r.db.
+	if err := r.db.WithContext(ctx).
 		Where("name = ?", roleName).
 		First(&role).
 		Error; err != nil {
@@ -0,0 +1,47 @@
+package services_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/configs"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+	"gorm.io/gorm"
+)
+
+// newTestDB returns a migrated database in a temporary directory.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := configs.InitializeSQLite(entities.DatabaseConfig{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("opening the database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// newTestMember creates an adult member with contacts and address.
+func newTestMember(t *testing.T, db *gorm.DB, name string) *entities.Member {
+	t.Helper()
+
+	member := &entities.Member{
+		Name:        name,
+		Surname:     "Rossi",
+		DateOfBirth: time.Date(1990, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Contacts:    &entities.Contacts{Phone: "3331234567", Email: name + "@example.com"},
+		Address:     &entities.Address{Country: "IT", City: "Roma", Street: "Via Roma 1"},
+	}
+	if err := services.NewMemberServices(db, secondary.NewEventBus()).CreateMember(context.Background(), member); err != nil {
+		t.Fatalf("creating the member: %v", err)
+	}
+	return member
+}
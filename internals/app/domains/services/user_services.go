@@ -1,13 +1,15 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
-	"os"
+	"log/slog"
 
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
 	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
 	"github.com/gofiber/fiber/v2"
 	"golang.org/x/crypto/bcrypt"
@@ -15,57 +17,81 @@ import (
 )
 
 type UserServices struct {
-	db           *gorm.DB
-	cache        ports.CacheAdapters
-	roleServices ports.RolesServices
+	db     *gorm.DB
+	cache  ports.CacheAdapters
+	events ports.EventBus
+	system entities.SystemConfig
 }
 
-func NewUserServices(db *gorm.DB, cache ports.CacheAdapters, roleServices ports.RolesServices) *UserServices {
+func NewUserServices(db *gorm.DB, cache ports.CacheAdapters, events ports.EventBus, system entities.SystemConfig) *UserServices {
 	return &UserServices{
-		db:           db,
-		cache:        cache,
-		roleServices: roleServices,
+		db:     db,
+		cache:  cache,
+		events: events,
+		system: system,
 	}
 }
 
 func (s *UserServices) EcnrypPassword(password string) (string, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		log.Printf("@EcnrypPassword Error hashing password: %v", err)
+		slog.Error("Error hashing password", "error", err)
 		return "", err
 	}
 	return string(hashedPassword), nil
 }
 
-func (s *UserServices) ComparePassword(userID uint, password string) error {
+func (s *UserServices) ComparePassword(ctx context.Context, userID uint, password string) error {
+	ctx, span := tracing.Start(ctx, "UserServices.ComparePassword")
+	defer span.End()
+
 	var user entities.User
-	if err := s.db.Model(&user).Where("id = ?", userID).First(&user).Error; err != nil {
+	if err := s.db.WithContext(ctx).Model(&user).Where("id = ?", userID).First(&user).Error; err != nil {
 		return err
 	}
 
 	return bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 }
 
-func (s *UserServices) CreateUser(user *entities.User) error {
-	return s.db.
+func (s *UserServices) CreateUser(ctx context.Context, user *entities.User) error {
+	ctx, span := tracing.Start(ctx, "UserServices.CreateUser")
+	defer span.End()
+
+	if err := s.db.WithContext(ctx).
 		Model(user).
 		Create(user).
-		Error
+		Error; err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, &entities.UserCreated{UserID: user.ID, RoleID: user.RoleID})
+	return nil
 }
 
-func (s *UserServices) DeleteUser(u *entities.User) error {
-	return s.db.
+func (s *UserServices) DeleteUser(ctx context.Context, u *entities.User) error {
+	ctx, span := tracing.Start(ctx, "UserServices.DeleteUser")
+	defer span.End()
+
+	if err := s.db.WithContext(ctx).
 		Model(u).
 		Where("id = ?", u.ID).
 		Delete(u).
-		Error
+		Error; err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, &entities.UserDeleted{UserID: u.ID})
+	return nil
 }
 
-func (s *UserServices) GetAllUsers() ([]entities.User, error) {
-	systemUserEmail := os.Getenv("SYS_USER_EMAIL")
+func (s *UserServices) GetAllUsers(ctx context.Context) ([]entities.User, error) {
+	ctx, span := tracing.Start(ctx, "UserServices.GetAllUsers")
+	defer span.End()
+
+	systemUserEmail := s.system.UserEmail
 
 	var users []entities.User
-	return users, s.db.
+	return users, s.db.WithContext(ctx).
 		Model(&users).
 		Preload("Role").
 		Omit("password").
@@ -74,10 +100,13 @@ func (s *UserServices) GetAllUsers() ([]entities.User, error) {
 		Error
 }
 
-func (s *UserServices) GetUserById(u *entities.User) error {
-	systemUserEmail := os.Getenv("SYS_USER_EMAIL")
+func (s *UserServices) GetUserById(ctx context.Context, u *entities.User) error {
+	ctx, span := tracing.Start(ctx, "UserServices.GetUserById")
+	defer span.End()
 
-	return s.db.
+	systemUserEmail := s.system.UserEmail
+
+	return s.db.WithContext(ctx).
 		Model(u).
 		Preload("Role").
 		Omit("password").
@@ -86,9 +115,12 @@ func (s *UserServices) GetUserById(u *entities.User) error {
 		Error
 }
 
-func (s *UserServices) GetUserForLogin(id uint) (*entities.User, error) {
+func (s *UserServices) GetUserForLogin(ctx context.Context, id uint) (*entities.User, error) {
+	ctx, span := tracing.Start(ctx, "UserServices.GetUserForLogin")
+	defer span.End()
+
 	user := &entities.User{}
-	return user, s.db.
+	return user, s.db.WithContext(ctx).
 		Model(user).
 		Preload("Role").
 		Omit("password").
@@ -97,9 +129,12 @@ func (s *UserServices) GetUserForLogin(id uint) (*entities.User, error) {
 		Error
 }
 
-func (s *UserServices) GetUserByEmail(email string) (*entities.User, error) {
+func (s *UserServices) GetUserByEmail(ctx context.Context, email string) (*entities.User, error) {
+	ctx, span := tracing.Start(ctx, "UserServices.GetUserByEmail")
+	defer span.End()
+
 	user := &entities.User{}
-	if err := s.db.
+	if err := s.db.WithContext(ctx).
 		Model(user).
 		Preload("Role").
 		Omit("password").
@@ -112,13 +147,16 @@ func (s *UserServices) GetUserByEmail(email string) (*entities.User, error) {
 	return user, nil
 }
 
-func (s *UserServices) UpdateUser(id uint, u *entities.UpdateUser) (*entities.User, error) {
-	if err := s.db.Model(&entities.User{}).Where("id = ?", id).Updates(u).Error; err != nil {
+func (s *UserServices) UpdateUser(ctx context.Context, id uint, u *entities.UpdateUser) (*entities.User, error) {
+	ctx, span := tracing.Start(ctx, "UserServices.UpdateUser")
+	defer span.End()
+
+	if err := s.db.WithContext(ctx).Model(&entities.User{}).Where("id = ?", id).Updates(u).Error; err != nil {
 		return nil, err
 	}
 
 	user := &entities.User{}
-	if err := s.db.
+	if err := s.db.WithContext(ctx).
 		Model(user).
 		Where("id = ?", id).
 		Preload("Role").
@@ -132,6 +170,9 @@ func (s *UserServices) UpdateUser(id uint, u *entities.UpdateUser) (*entities.Us
 }
 
 func (s *UserServices) SetSession(c *fiber.Ctx, user *entities.User) error {
+	ctx, span := tracing.Start(c.UserContext(), "UserServices.SetSession")
+	defer span.End()
+
 	//Generate random token
 	token, err := utils.GenerateRandomToken(64)
 	if err != nil {
@@ -142,38 +183,60 @@ func (s *UserServices) SetSession(c *fiber.Ctx, user *entities.User) error {
 	session := user.NewSession(c, token)
 
 	//Set session in cache
-	if err := s.cache.SetCache(&session); err != nil {
+	if err := s.cache.WithContext(ctx).SetCache(&session); err != nil {
 		return err
 	}
 
 	//Set cookie
 	c.Cookie(user.NewAuthCookie(token))
 
+	s.events.Publish(ctx, &entities.UserLoggedIn{
+		UserID:    user.ID,
+		Email:     user.Email,
+		IPAddress: session.IPAddress,
+	})
 	return nil
 }
 
-func (u *UserServices) GetSessionByToken(token string) (*entities.Session, error) {
+func (s *UserServices) LoginFailed(c *fiber.Ctx, email string) {
+	s.events.Publish(c.UserContext(), &entities.UserLoginFailed{
+		Email:     email,
+		IPAddress: c.IP(),
+	})
+}
+
+func (s *UserServices) CountSessions(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "UserServices.CountSessions")
+	defer span.End()
+
+	return s.cache.WithContext(ctx).CountCacheKeys(&entities.Session{})
+}
+
+func (u *UserServices) GetSessionByToken(ctx context.Context, token string) (*entities.Session, error) {
+	ctx, span := tracing.Start(ctx, "UserServices.GetSessionByToken")
+	defer span.End()
+
 	// Create session
 	session := &entities.Session{
 		Token: token,
 	}
 
 	// Get all keys for the token
-	keys, err := u.cache.GetCacheKeys(session)
+	keys, err := u.cache.WithContext(ctx).GetCacheKeys(session)
 	if err != nil {
-		log.Printf("@GetUserSessionByToken: Error getting keys: %v", err)
+		slog.Error("Error getting session keys", "error", err)
 		return nil, err
 	}
 
 	// Check if 1 key is found, 1 to void multiple keys
 	if len(keys) != 1 {
-		log.Printf("@GetUserSessionByToken: No key or multiple keys found for pattern")
+		slog.Debug("No session or multiple sessions found for the token")
 		return nil, fmt.Errorf("no key or multiple keys found for pattern")
 	}
 
 	// Get the session from Redis with key
-	if err := u.cache.GetCacheFromKey(keys[0], session); err != nil {
-		log.Printf("@GetUserSessionByToken: Error getting session: %v", err)
+	if err := u.cache.WithContext(ctx).GetCacheFromKey(keys[0], session); err != nil {
+		slog.Error("Error getting session", "error", err)
 		return nil, err
 	}
 
@@ -181,6 +244,9 @@ func (u *UserServices) GetSessionByToken(token string) (*entities.Session, error
 }
 
 func (u *UserServices) DeleteSession(c *fiber.Ctx, id uint) error {
+	ctx, span := tracing.Start(c.UserContext(), "UserServices.DeleteSession")
+	defer span.End()
+
 	// Get authorization token and create session
 	token := c.Cookies("Authorization")
 	session := entities.Session{
@@ -189,8 +255,8 @@ func (u *UserServices) DeleteSession(c *fiber.Ctx, id uint) error {
 	}
 
 	// Remove the session from Redis
-	if err := u.cache.DelCache(&session); err != nil {
-		log.Printf("@DeleteSession: Error removing session: %v", err)
+	if err := u.cache.WithContext(ctx).DelCache(&session); err != nil {
+		slog.ErrorContext(c.UserContext(), "Error removing session", "error", err)
 		return err
 	}
 
@@ -200,14 +266,17 @@ func (u *UserServices) DeleteSession(c *fiber.Ctx, id uint) error {
 }
 
 func (u *UserServices) DeleteAllSessions(c *fiber.Ctx, id uint) error {
+	ctx, span := tracing.Start(c.UserContext(), "UserServices.DeleteAllSessions")
+	defer span.End()
+
 	// Create session
 	session := entities.Session{
 		UserID: id,
 	}
 
 	// Delete the sessions from Redis
-	if err := u.cache.DelCacheMultiple(&session); err != nil {
-		log.Printf("@DeleteAllSessions: Error removing session: %v", err)
+	if err := u.cache.WithContext(ctx).DelCacheMultiple(&session); err != nil {
+		slog.ErrorContext(c.UserContext(), "Error removing sessions", "user_id", id, "error", err)
 		return err
 	}
 
@@ -215,17 +284,21 @@ func (u *UserServices) DeleteAllSessions(c *fiber.Ctx, id uint) error {
 }
 
 func (u *UserServices) CreateSystemUser() error {
-	email := os.Getenv("SYS_USER_EMAIL")
-	password := os.Getenv("SYS_USER_PWD")
-	roleName := os.Getenv("SYS_ROLE_NAME")
+	ctx, span := tracing.Start(context.Background(), "UserServices.CreateSystemUser")
+	defer span.End()
+
+	email := u.system.UserEmail
+	password := u.system.UserPassword
+	roleName := u.system.RoleName
 
 	if email == "" || password == "" || roleName == "" {
-		log.Fatal("SYS_USER_EMAIL, SYS_USER_PWD or SYS_ROLE_NAME not found in .env file")
-		return errors.New("SYS_USER_EMAIL, SYS_USER_PWD or SYS_ROLE_NAME not found in .env file")
+		log.Fatal("SYS_USER_EMAIL, SYS_USER_PWD or SYS_ROLE_NAME not configured")
+		return errors.New("SYS_USER_EMAIL, SYS_USER_PWD or SYS_ROLE_NAME not configured")
 	}
 
 	// Get system role
-	role, err := u.roleServices.GetRoleByName(roleName)
+	role := new(entities.Roles)
+	err := u.db.WithContext(ctx).Where("name = ?", roleName).First(role).Error
 	if err != nil {
 		log.Fatal("Error getting system role: ", err)
 		return err
@@ -233,7 +306,7 @@ func (u *UserServices) CreateSystemUser() error {
 
 	// check if user exists
 	var user_count int64
-	err = u.db.Model(&entities.User{}).Where("email = ?", email).Count(&user_count).Error
+	err = u.db.WithContext(ctx).Model(&entities.User{}).Where("email = ?", email).Count(&user_count).Error
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		log.Fatal("Error checking user existence: ", err)
 		return err
@@ -241,7 +314,7 @@ func (u *UserServices) CreateSystemUser() error {
 
 	if user_count == 0 {
 		// start transaction
-		tx := u.db.Begin()
+		tx := u.db.WithContext(ctx).Begin()
 		if tx.Error != nil {
 			log.Fatal("Error starting transaction: ", tx.Error)
 			return tx.Error
@@ -279,10 +352,13 @@ func (u *UserServices) CreateSystemUser() error {
 	return nil
 }
 
-func (u *UserServices) IsSystemUser(id uint) bool {
-	email := os.Getenv("SYS_USER_EMAIL")
+func (u *UserServices) IsSystemUser(ctx context.Context, id uint) bool {
+	ctx, span := tracing.Start(ctx, "UserServices.IsSystemUser")
+	defer span.End()
+
+	email := u.system.UserEmail
 	var user entities.User
-	if err := u.db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := u.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
 		return false
 	}
 	return user.ID == id
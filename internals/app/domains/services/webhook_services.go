@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/goccy/go-json"
+	"gorm.io/gorm"
+)
+
+const (
+	// Length of the generated secrets, in bytes
+	webhookSecretLength = 32
+	// Deliveries sent by each run
+	deliveriesBatchSize = 100
+	// Deliveries returned by the delivery log
+	maxWebhookDeliveries = 500
+)
+
+type WebhookServices struct {
+	db     *gorm.DB
+	client ports.WebhookAdapters
+}
+
+func NewWebhookServices(db *gorm.DB, client ports.WebhookAdapters) *WebhookServices {
+	return &WebhookServices{
+		db:     db,
+		client: client,
+	}
+}
+
+func (w *WebhookServices) CreateWebhook(ctx context.Context, webhook *entities.Webhook) error {
+	ctx, span := tracing.Start(ctx, "WebhookServices.CreateWebhook")
+	defer span.End()
+
+	if webhook.Secret == "" {
+		secret, err := utils.GenerateRandomToken(webhookSecretLength)
+		if err != nil {
+			return err
+		}
+		webhook.Secret = secret
+	}
+
+	return w.db.WithContext(ctx).Create(webhook).Error
+}
+
+func (w *WebhookServices) GetAllWebhooks(ctx context.Context) ([]entities.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookServices.GetAllWebhooks")
+	defer span.End()
+
+	var webhooks []entities.Webhook
+	if err := w.db.WithContext(ctx).Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (w *WebhookServices) GetWebhookById(ctx context.Context, id uint) (*entities.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookServices.GetWebhookById")
+	defer span.End()
+
+	webhook := new(entities.Webhook)
+	if err := w.db.WithContext(ctx).First(webhook, id).Error; err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (w *WebhookServices) UpdateWebhook(ctx context.Context, id uint, webhook *entities.UpdateWebhook) (*entities.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookServices.UpdateWebhook")
+	defer span.End()
+
+	updates := map[string]interface{}{}
+	if webhook.URL != "" {
+		updates["url"] = webhook.URL
+	}
+	if webhook.Description != "" {
+		updates["description"] = webhook.Description
+	}
+	if webhook.Events != nil {
+		events, err := json.Marshal(webhook.Events)
+		if err != nil {
+			return nil, err
+		}
+		updates["events"] = string(events)
+	}
+	if webhook.Active != nil {
+		updates["active"] = *webhook.Active
+	}
+
+	if err := w.db.WithContext(ctx).
+		Model(entities.Webhook{}).
+		Where("id = ?", id).
+		Updates(updates).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return w.GetWebhookById(ctx, id)
+}
+
+func (w *WebhookServices) DeleteWebhook(ctx context.Context, id uint) error {
+	ctx, span := tracing.Start(ctx, "WebhookServices.DeleteWebhook")
+	defer span.End()
+
+	return w.db.WithContext(ctx).Delete(&entities.Webhook{}, id).Error
+}
+
+func (w *WebhookServices) Publish(ctx context.Context, event string, data interface{}) error {
+	ctx, span := tracing.Start(ctx, "WebhookServices.Publish")
+	defer span.End()
+
+	webhooks, err := w.GetAllWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Member whose personal data is in the payload
+	var memberID *uint
+	if memberEvent, ok := data.(entities.MemberEvent); ok {
+		id := memberEvent.EventMemberID()
+		memberID = &id
+	}
+
+	var payload []byte
+	now := time.Now()
+	for _, webhook := range webhooks {
+		if !webhook.Subscribed(event) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(entities.WebhookEvent{
+				Event:     event,
+				CreatedAt: now,
+				Data:      data,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := w.db.WithContext(ctx).Create(&entities.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			MemberID:      memberID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        entities.DeliveryPending,
+			NextAttemptAt: &now,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *WebhookServices) ProcessDeliveries(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "WebhookServices.ProcessDeliveries")
+	defer span.End()
+
+	var deliveries []entities.WebhookDelivery
+	if err := w.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", entities.DeliveryPending, now).
+		Order("next_attempt_at").
+		Limit(deliveriesBatchSize).
+		Find(&deliveries).
+		Error; err != nil {
+		return 0, err
+	}
+
+	webhooks := make(map[uint]*entities.Webhook)
+	delivered := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			webhook = new(entities.Webhook)
+			if err := w.db.WithContext(ctx).First(webhook, delivery.WebhookID).Error; err != nil {
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					return delivered, err
+				}
+				webhook = nil
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		if webhook == nil {
+			delivery.Status = entities.DeliveryFailed
+			delivery.NextAttemptAt = nil
+			delivery.Error = "webhook eliminato"
+		} else if status, err := w.deliver(webhook, delivery, now); err != nil {
+			slog.Warn("Error delivering webhook event", "delivery_id", delivery.ID, "webhook_id", webhook.ID, "error", err)
+			delivery.Failed(status, err, now)
+		} else {
+			delivery.Delivered(status, now)
+			delivered++
+		}
+
+		// Only the outcome, the payload may have been erased in the meantime
+		if err := w.db.WithContext(ctx).
+			Model(delivery).
+			Updates(map[string]interface{}{
+				"status":          delivery.Status,
+				"attempts":        delivery.Attempts,
+				"next_attempt_at": delivery.NextAttemptAt,
+				"response_status": delivery.ResponseStatus,
+				"error":           delivery.Error,
+				"delivered_at":    delivery.DeliveredAt,
+			}).
+			Error; err != nil {
+			return delivered, err
+		}
+	}
+
+	return delivered, nil
+}
+
+// deliver posts a delivery with its signature.
+func (w *WebhookServices) deliver(webhook *entities.Webhook, delivery *entities.WebhookDelivery, now time.Time) (int, error) {
+	payload := []byte(delivery.Payload)
+	return w.client.Post(webhook.URL, map[string]string{
+		"X-Webhook-Event":     delivery.Event,
+		"X-Webhook-Delivery":  strconv.FormatUint(uint64(delivery.ID), 10),
+		"X-Webhook-Signature": webhook.Sign(payload, now),
+	}, payload)
+}
+
+func (w *WebhookServices) GetWebhookDeliveries(ctx context.Context, webhook_id uint, status string) ([]entities.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookServices.GetWebhookDeliveries")
+	defer span.End()
+
+	query := w.db.WithContext(ctx).Where("webhook_id = ?", webhook_id)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var deliveries []entities.WebhookDelivery
+	if err := query.
+		Order("created_at desc").
+		Limit(maxWebhookDeliveries).
+		Find(&deliveries).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *WebhookServices) RetryDelivery(ctx context.Context, webhook_id uint, delivery_id uint) (*entities.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookServices.RetryDelivery")
+	defer span.End()
+
+	delivery := new(entities.WebhookDelivery)
+	if err := w.db.WithContext(ctx).
+		Where("id = ? AND webhook_id = ?", delivery_id, webhook_id).
+		First(delivery).
+		Error; err != nil {
+		return nil, err
+	}
+
+	if delivery.Status == entities.DeliveryDelivered {
+		return nil, entities.NewConflictError(i18n.MsgDeliveryAlreadyDelivered)
+	}
+
+	now := time.Now()
+	delivery.Status = entities.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	if err := w.db.WithContext(ctx).Save(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
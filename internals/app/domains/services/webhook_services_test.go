@@ -0,0 +1,56 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
+)
+
+// postFunc is a webhook client calling a function for each post.
+type postFunc func(url string, headers map[string]string, payload []byte) (int, error)
+
+func (f postFunc) Post(url string, headers map[string]string, payload []byte) (int, error) {
+	return f(url, headers, payload)
+}
+
+func TestProcessDeliveriesKeepsErasedPayload(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	member := newTestMember(t, db, "Mario")
+	privacy := services.NewPrivacyServices(db)
+
+	// The member is erased while the delivery is in flight
+	webhooks := services.NewWebhookServices(db, postFunc(func(string, map[string]string, []byte) (int, error) {
+		if err := privacy.EraseMember(ctx, member.ID); err != nil {
+			t.Fatalf("erasing the member: %v", err)
+		}
+		return 200, nil
+	}))
+
+	webhook := &entities.Webhook{URL: "https://example.com/hook", Events: []string{entities.EventMemberCreated}}
+	if err := webhooks.CreateWebhook(ctx, webhook); err != nil {
+		t.Fatalf("creating the webhook: %v", err)
+	}
+	if err := webhooks.Publish(ctx, entities.EventMemberCreated, &entities.MemberCreated{Member: member}); err != nil {
+		t.Fatalf("publishing the event: %v", err)
+	}
+
+	if delivered, err := webhooks.ProcessDeliveries(ctx, time.Now().Add(time.Minute)); err != nil || delivered != 1 {
+		t.Fatalf("processing the deliveries = %d, %v, want 1 delivered", delivered, err)
+	}
+
+	delivery := new(entities.WebhookDelivery)
+	if err := db.Where("webhook_id = ?", webhook.ID).First(delivery).Error; err != nil {
+		t.Fatalf("loading the delivery: %v", err)
+	}
+	if delivery.Status != entities.DeliveryDelivered {
+		t.Errorf("status = %q, want %q", delivery.Status, entities.DeliveryDelivered)
+	}
+	if strings.Contains(delivery.Payload, member.Contacts.Email) {
+		t.Errorf("payload %s holds the email of the erased member", delivery.Payload)
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditsHandlers struct {
+	http          ports.HttpAdapters
+	auditServices ports.AuditServices
+}
+
+func NewAuditsHandlers(http ports.HttpAdapters, services ports.AuditServices) *AuditsHandlers {
+	return &AuditsHandlers{
+		http:          http,
+		auditServices: services,
+	}
+}
+
+// GetAuditLogs retrieves the audit logs filtered by the query parameters.
+func (h *AuditsHandlers) GetAuditLogs(c *fiber.Ctx) error {
+	filter := &entities.AuditFilter{
+		UserID:   uint(c.QueryInt("user_id")),
+		Action:   c.Query("action"),
+		Entity:   c.Query("entity"),
+		EntityID: uint(c.QueryInt("entity_id")),
+		Limit:    c.QueryInt("limit"),
+		Offset:   c.QueryInt("offset"),
+	}
+
+	// Parse the dates
+	var err error
+	if from := c.Query("from"); from != "" {
+		if filter.From, err = time.Parse(time.DateOnly, from); err != nil {
+			return h.http.BadRequest(c, i18n.MsgStartDateFormat)
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if filter.To, err = time.Parse(time.DateOnly, to); err != nil {
+			return h.http.BadRequest(c, i18n.MsgEndDateFormat)
+		}
+		// Include the whole day
+		filter.To = filter.To.AddDate(0, 0, 1)
+	}
+
+	audits, total, err := h.auditServices.GetAuditLogs(c.UserContext(), filter)
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgAuditsFetchError)
+	}
+
+	return h.http.Success(c, entities.AuditLogs{
+		Total: total,
+		Logs:  audits,
+	}, i18n.MsgAuditsFetched)
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Page of the Swagger UI, loading the specification from the given URL
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>%[1]s</title>
+	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+	<script>
+		window.ui = SwaggerUIBundle({ url: "%[2]s", dom_id: "#swagger-ui" });
+	</script>
+</body>
+</html>`
+
+type DocsHandlers struct {
+	spec *openapi.Document
+}
+
+// NewDocsHandlers creates a new DocsHandlers struct.
+func NewDocsHandlers(spec *openapi.Document) *DocsHandlers {
+	return &DocsHandlers{
+		spec: spec,
+	}
+}
+
+// GetSpec serves the OpenAPI specification of the API.
+func (h *DocsHandlers) GetSpec(c *fiber.Ctx) error {
+	return c.JSON(h.spec)
+}
+
+// GetSwaggerUI serves the Swagger UI of the specification.
+func (h *DocsHandlers) GetSwaggerUI(c *fiber.Ctx) error {
+	url := strings.TrimSuffix(c.Path(), "/") + "/openapi.json"
+	c.Type("html")
+	return c.SendString(fmt.Sprintf(swaggerUI, h.spec.Info.Title, url))
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Days ahead of the expiring subscriptions export
+const defaultExpiringDays = 30
+
+type ExportHandlers struct {
+	http           ports.HttpAdapters
+	tables         ports.TableAdapters
+	exportServices ports.ExportServices
+}
+
+func NewExportHandlers(http ports.HttpAdapters, tables ports.TableAdapters, services ports.ExportServices) *ExportHandlers {
+	return &ExportHandlers{
+		http:           http,
+		tables:         tables,
+		exportServices: services,
+	}
+}
+
+// ExportMembers exports the members matching the query parameters.
+func (h *ExportHandlers) ExportMembers(c *fiber.Ctx) error {
+	filter := &entities.MemberFilter{
+		Search:           c.Query("search"),
+		SubscriptionType: c.Query("subscription_type"),
+		HouseholdID:      uint(c.QueryInt("household_id")),
+	}
+	if active := c.Query("active"); active != "" {
+		value, err := strconv.ParseBool(active)
+		if err != nil {
+			return h.http.BadRequest(c, i18n.MsgActiveFilterInvalid)
+		}
+		filter.Active = &value
+	}
+
+	return h.export(c, "membri", "Membri", func(w ports.TableWriter) error {
+		return h.exportServices.ExportMembers(c.UserContext(), filter, w)
+	})
+}
+
+// ExportExpiringSubscriptions exports the subscriptions expiring in the next days.
+func (h *ExportHandlers) ExportExpiringSubscriptions(c *fiber.Ctx) error {
+	days := c.QueryInt("days", defaultExpiringDays)
+	if days <= 0 {
+		return h.http.BadRequest(c, i18n.MsgDaysPositive)
+	}
+
+	from := time.Now()
+	to := from.AddDate(0, 0, days)
+
+	title := fmt.Sprintf("Abbonamenti in scadenza entro il %s", to.Format("02/01/2006"))
+	return h.export(c, "abbonamenti_in_scadenza", title, func(w ports.TableWriter) error {
+		return h.exportServices.ExportExpiringSubscriptions(c.UserContext(), from, to, w)
+	})
+}
+
+// ExportRevenue exports the revenue of the period, by default the current year.
+func (h *ExportHandlers) ExportRevenue(c *fiber.Ctx) error {
+	now := time.Now()
+	from := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+	to := from.AddDate(1, 0, 0)
+
+	// Parse the dates
+	var err error
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse(time.DateOnly, value); err != nil {
+			return h.http.BadRequest(c, i18n.MsgStartDateFormat)
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse(time.DateOnly, value); err != nil {
+			return h.http.BadRequest(c, i18n.MsgEndDateFormat)
+		}
+		// Include the whole day
+		to = to.AddDate(0, 0, 1)
+	}
+	if !to.After(from) {
+		return h.http.BadRequest(c, i18n.MsgEndDateBeforeStart)
+	}
+
+	title := fmt.Sprintf("Incassi dal %s al %s", from.Format("02/01/2006"), to.AddDate(0, 0, -1).Format("02/01/2006"))
+	return h.export(c, "incassi", title, func(w ports.TableWriter) error {
+		return h.exportServices.ExportRevenue(c.UserContext(), from, to, w)
+	})
+}
+
+// export streams a table in the format of the query parameter, csv by default.
+func (h *ExportHandlers) export(c *fiber.Ctx, name string, title string, write func(w ports.TableWriter) error) error {
+	format := c.Query("format", entities.ExportCSV)
+	if err := entities.ValidateExportFormat(format); err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("%s_%s.%s", name, time.Now().Format(time.DateOnly), format)
+	return h.http.Stream(c, filename, func(w io.Writer) error {
+		table, err := h.tables.NewTableWriter(format, title, w)
+		if err != nil {
+			return err
+		}
+
+		if err := write(table); err != nil {
+			return err
+		}
+		return table.Close()
+	})
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GuestsHandlers struct {
+	parser            ports.ParserAdapters
+	http              ports.HttpAdapters
+	guestServices     ports.GuestServices
+	householdServices ports.HouseholdServices
+	audit             ports.AuditServices
+}
+
+func NewGuestsHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, services ports.GuestServices, householdServices ports.HouseholdServices, audit ports.AuditServices) *GuestsHandlers {
+	return &GuestsHandlers{
+		parser:            parser,
+		http:              http,
+		guestServices:     services,
+		householdServices: householdServices,
+		audit:             audit,
+	}
+}
+
+// CreateGuest handles the creation of a new guest.
+func (h *GuestsHandlers) CreateGuest(c *fiber.Ctx) error {
+	guest := new(entities.Guest)
+	if err := h.parser.ParseData(c, guest); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Validate guest
+	if err := guest.Validate(); err != nil {
+		return err
+	}
+
+	// Create guest
+	if err := h.guestServices.CreateGuest(c.UserContext(), guest); err != nil {
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditCreate, "guests", guest.ID, nil, guest)
+	return h.http.Success(c, []interface{}{guest}, i18n.MsgGuestCreated)
+}
+
+// GetGuests retrieves all guests from the database.
+func (h *GuestsHandlers) GetGuests(c *fiber.Ctx) error {
+	guests, err := h.guestServices.GetAllGuests(c.UserContext())
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgGuestsFetchError)
+	}
+
+	return h.http.Success(c, guests, i18n.MsgGuestsFetched)
+}
+
+// GetGuestById retrieves a guest by its ID from the database.
+func (h *GuestsHandlers) GetGuestById(c *fiber.Ctx) error {
+	// Get guest from fiber locals
+	guest := utils.GetLocalGuest(c)
+
+	return h.http.Success(c, []interface{}{guest}, i18n.MsgGuestFetched)
+}
+
+// UpdateGuest updates a guest in the database.
+func (h *GuestsHandlers) UpdateGuest(c *fiber.Ctx) error {
+	updatedGuest := new(entities.UpdateGuest)
+	if err := h.parser.ParseData(c, updatedGuest); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Get guest from fiber locals
+	guest := utils.GetLocalGuest(c)
+
+	// Update guest
+	updated, err := h.guestServices.UpdateGuest(c.UserContext(), guest.ID, updatedGuest)
+	if err != nil {
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditUpdate, "guests", guest.ID, guest, updated)
+	return h.http.Success(c, []interface{}{updated}, i18n.MsgGuestUpdated)
+}
+
+// DeleteGuest deletes a guest from the database.
+func (h *GuestsHandlers) DeleteGuest(c *fiber.Ctx) error {
+	// Get guest from fiber locals
+	guest := utils.GetLocalGuest(c)
+
+	// Delete guest
+	if err := h.guestServices.DeleteGuest(c.UserContext(), guest.ID); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgGuestDeleteError)
+	}
+
+	h.audit.LogChange(c, entities.AuditDelete, "guests", guest.ID, guest, nil)
+	return h.http.Success(c, nil, i18n.MsgGuestDeleted)
+}
+
+// CreateGuestPass handles the creation of a new pass for a guest.
+func (h *GuestsHandlers) CreateGuestPass(c *fiber.Ctx) error {
+	// Get guest from fiber locals
+	guest := utils.GetLocalGuest(c)
+	pass := new(entities.GuestPass)
+	if err := h.parser.ParseData(c, pass); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Validate pass
+	if err := pass.Validate(); err != nil {
+		return err
+	}
+
+	// Add validity
+	pass.AddValidity()
+
+	// Create pass
+	if err := h.guestServices.CreateGuestPass(c.UserContext(), guest.ID, pass); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgPassCreateError)
+	}
+
+	h.audit.LogChange(c, entities.AuditCreate, "guest_passes", pass.ID, nil, pass)
+	return h.http.Success(c, []interface{}{pass}, i18n.MsgPassCreated)
+}
+
+// GetGuestPasses retrieves all passes of a guest.
+func (h *GuestsHandlers) GetGuestPasses(c *fiber.Ctx) error {
+	// Get guest from fiber locals
+	guest := utils.GetLocalGuest(c)
+
+	passes, err := h.guestServices.GetGuestPasses(c.UserContext(), guest.ID)
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgPassesFetchError)
+	}
+
+	return h.http.Success(c, passes, i18n.MsgPassesFetched)
+}
+
+// RegisterGuestVisit uses one visit of a guest pass.
+func (h *GuestsHandlers) RegisterGuestVisit(c *fiber.Ctx) error {
+	// Get guest from fiber locals
+	guest := utils.GetLocalGuest(c)
+	pass_id := utils.GetUintParam(c, "pass_id")
+
+	if pass_id == 0 {
+		return h.http.BadRequest(c, i18n.MsgPassIDRequired)
+	}
+
+	pass, err := h.guestServices.RegisterVisit(c.UserContext(), guest.ID, pass_id)
+	if err != nil {
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditUpdate, "guest_passes", pass.ID, fiber.Map{"visits": pass.Visits - 1}, fiber.Map{"visits": pass.Visits})
+	return h.http.Success(c, []interface{}{pass}, i18n.MsgVisitRecorded)
+}
+
+// ConvertGuest promotes a guest into a full member.
+func (h *GuestsHandlers) ConvertGuest(c *fiber.Ctx) error {
+	// Get guest from fiber locals
+	guest := utils.GetLocalGuest(c)
+	member := new(entities.Member)
+	if err := h.parser.ParseData(c, member); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Fill the member with the guest data
+	guest.ToMember(member)
+
+	// Join household
+	if err := h.householdServices.PrepareHouseholdMember(c.UserContext(), member); err != nil {
+		return err
+	}
+
+	// Validate member
+	if err := member.Validate(); err != nil {
+		return h.http.ValidationFailed(c, i18n.MsgMemberInvalid, err)
+	}
+
+	// Add ending date
+	member.Subscription[0].AddEndDate()
+
+	// Convert guest
+	if err := h.guestServices.ConvertGuest(c.UserContext(), guest, member); err != nil {
+		var validationErrors entities.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return h.http.ValidationFailed(c, i18n.MsgSubscriptionInvalid, validationErrors)
+		}
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditCreate, "members", member.ID, nil, member)
+	h.audit.LogChange(c, entities.AuditUpdate, "guests", guest.ID, fiber.Map{"member_id": nil}, fiber.Map{"member_id": member.ID})
+	return h.http.Success(c, []interface{}{member}, i18n.MsgGuestEnrolled)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/metrics"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Time given to the database and the cache to answer the readiness checks
+const readinessTimeout = 2 * time.Second
+
+type HealthHandlers struct {
+	healthServices ports.HealthServices
+	registry       *metrics.Registry
+}
+
+// NewHealthHandlers creates a new HealthHandlers struct.
+func NewHealthHandlers(services ports.HealthServices, registry *metrics.Registry) *HealthHandlers {
+	return &HealthHandlers{
+		healthServices: services,
+		registry:       registry,
+	}
+}
+
+// GetLiveness answers while the server is running.
+func (h *HealthHandlers) GetLiveness(c *fiber.Ctx) error {
+	return c.JSON(entities.Health{Status: entities.HealthUp})
+}
+
+// GetReadiness answers 503 while the database or the cache can't be reached.
+func (h *HealthHandlers) GetReadiness(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), readinessTimeout)
+	defer cancel()
+
+	health := h.healthServices.CheckReadiness(ctx)
+	if health.Status != entities.HealthUp {
+		c.Status(fiber.StatusServiceUnavailable)
+	}
+	return c.JSON(health)
+}
+
+// GetMetrics serves the metrics in the Prometheus text format.
+func (h *HealthHandlers) GetMetrics(c *fiber.Ctx) error {
+	var body bytes.Buffer
+	if err := h.registry.Write(&body); err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, metrics.ContentType)
+	return c.Send(body.Bytes())
+}
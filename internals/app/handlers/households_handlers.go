@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type HouseholdsHandlers struct {
+	parser            ports.ParserAdapters
+	http              ports.HttpAdapters
+	householdServices ports.HouseholdServices
+	audit             ports.AuditServices
+}
+
+func NewHouseholdsHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, services ports.HouseholdServices, audit ports.AuditServices) *HouseholdsHandlers {
+	return &HouseholdsHandlers{
+		parser:            parser,
+		http:              http,
+		householdServices: services,
+		audit:             audit,
+	}
+}
+
+// CreateHousehold handles the creation of a new household.
+func (h *HouseholdsHandlers) CreateHousehold(c *fiber.Ctx) error {
+	household := new(entities.Household)
+	if err := h.parser.ParseData(c, household); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Validate household
+	if err := household.Validate(); err != nil {
+		return err
+	}
+
+	// Create household
+	if err := h.householdServices.CreateHousehold(c.UserContext(), household); err != nil {
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditCreate, "households", household.ID, nil, household)
+	return h.http.Success(c, []interface{}{household}, i18n.MsgHouseholdCreated)
+}
+
+// GetHouseholds retrieves all households from the database.
+func (h *HouseholdsHandlers) GetHouseholds(c *fiber.Ctx) error {
+	households, err := h.householdServices.GetAllHouseholds(c.UserContext())
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgHouseholdsFetchError)
+	}
+
+	return h.http.Success(c, households, i18n.MsgHouseholdsFetched)
+}
+
+// GetHouseholdById retrieves a household by its ID from the database.
+func (h *HouseholdsHandlers) GetHouseholdById(c *fiber.Ctx) error {
+	// Get household from fiber locals
+	household := utils.GetLocalHousehold(c)
+
+	return h.http.Success(c, []interface{}{household}, i18n.MsgHouseholdFetched)
+}
+
+// UpdateHousehold updates a household in the database.
+func (h *HouseholdsHandlers) UpdateHousehold(c *fiber.Ctx) error {
+	updatedHousehold := new(entities.UpdateHousehold)
+	if err := h.parser.ParseData(c, updatedHousehold); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Validate household
+	if err := updatedHousehold.Validate(); err != nil {
+		return err
+	}
+
+	// Get household from fiber locals
+	household := utils.GetLocalHousehold(c)
+
+	// Update household
+	updated, err := h.householdServices.UpdateHousehold(c.UserContext(), household.ID, updatedHousehold)
+	if err != nil {
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditUpdate, "households", household.ID, household, updated)
+	return h.http.Success(c, []interface{}{updated}, i18n.MsgHouseholdUpdated)
+}
+
+// DeleteHousehold deletes a household from the database.
+func (h *HouseholdsHandlers) DeleteHousehold(c *fiber.Ctx) error {
+	// Get household from fiber locals
+	household := utils.GetLocalHousehold(c)
+
+	// Delete household
+	if err := h.householdServices.DeleteHousehold(c.UserContext(), household.ID); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgHouseholdDeleteError)
+	}
+
+	h.audit.LogChange(c, entities.AuditDelete, "households", household.ID, household, nil)
+	return h.http.Success(c, nil, i18n.MsgHouseholdDeleted)
+}
+
+// AddHouseholdMember adds an existing member to a household.
+func (h *HouseholdsHandlers) AddHouseholdMember(c *fiber.Ctx) error {
+	householdMember := new(entities.HouseholdMember)
+	if err := h.parser.ParseData(c, householdMember); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Validate request
+	if err := householdMember.Validate(); err != nil {
+		return err
+	}
+
+	// Get household from fiber locals
+	household := utils.GetLocalHousehold(c)
+
+	// Add member
+	member, err := h.householdServices.AddHouseholdMember(c.UserContext(), household.ID, householdMember)
+	if err != nil {
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditUpdate, "members", member.ID, fiber.Map{"household_id": nil}, fiber.Map{"household_id": household.ID})
+	return h.http.Success(c, []interface{}{member}, i18n.MsgHouseholdMemberAdded)
+}
+
+// RemoveHouseholdMember removes a member from a household.
+func (h *HouseholdsHandlers) RemoveHouseholdMember(c *fiber.Ctx) error {
+	// Get household from fiber locals
+	household := utils.GetLocalHousehold(c)
+	member_id := utils.GetUintParam(c, "member_id")
+
+	if member_id == 0 {
+		return h.http.BadRequest(c, i18n.MsgMemberDeleteIDRequired)
+	}
+
+	// Remove member
+	if err := h.householdServices.RemoveHouseholdMember(c.UserContext(), household.ID, member_id); err != nil {
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditUpdate, "members", member_id, fiber.Map{"household_id": household.ID}, fiber.Map{"household_id": nil})
+	return h.http.Success(c, nil, i18n.MsgHouseholdMemberRemoved)
+}
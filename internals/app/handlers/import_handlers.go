@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImportHandlers struct {
+	http           ports.HttpAdapters
+	importServices ports.ImportServices
+	audit          ports.AuditServices
+}
+
+func NewImportHandlers(http ports.HttpAdapters, services ports.ImportServices, audit ports.AuditServices) *ImportHandlers {
+	return &ImportHandlers{
+		http:           http,
+		importServices: services,
+		audit:          audit,
+	}
+}
+
+// ImportMembers creates the members listed in an uploaded CSV or XLSX file.
+func (h *ImportHandlers) ImportMembers(c *fiber.Ctx) error {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return h.http.BadRequest(c, i18n.MsgImportFileRequired)
+	}
+
+	// Column mapping, the missing fields keep the template headers
+	columns := entities.DefaultImportColumns()
+	if mapping := c.FormValue("columns"); mapping != "" {
+		custom := entities.ImportColumns{}
+		if err := json.Unmarshal([]byte(mapping), &custom); err != nil {
+			return h.http.BadRequest(c, i18n.MsgImportColumnsInvalid)
+		}
+		columns = columns.Merge(custom)
+	}
+
+	dryRun := c.FormValue("dry_run") == "true"
+
+	// Read file
+	file, err := header.Open()
+	if err != nil {
+		return h.http.BadRequest(c, i18n.MsgImportFileReadError)
+	}
+	defer file.Close()
+
+	rows, err := utils.ReadTable(header.Filename, file)
+	if err != nil {
+		return err
+	}
+
+	// Import members
+	report, err := h.importServices.ImportMembers(c.UserContext(), rows, columns, dryRun)
+	if err != nil {
+		return err
+	}
+
+	if !dryRun && len(report.Errors) > 0 {
+		return h.http.ValidationFailed(c, i18n.MsgImportRejected, report.Errors)
+	}
+
+	if dryRun {
+		return h.http.Success(c, report, i18n.MsgImportChecked)
+	}
+
+	for i := range report.Members {
+		h.audit.LogChange(c, entities.AuditCreate, "members", report.Members[i].ID, nil, &report.Members[i])
+	}
+	return h.http.Success(c, report, i18n.MsgMembersImported)
+}
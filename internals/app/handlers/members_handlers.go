@@ -1,23 +1,31 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 type MembersHandlers struct {
-	parser         ports.ParserAdapters
-	http           ports.HttpAdapters
-	memberServices ports.MemberServices
+	parser            ports.ParserAdapters
+	http              ports.HttpAdapters
+	memberServices    ports.MemberServices
+	householdServices ports.HouseholdServices
+	audit             ports.AuditServices
 }
 
-func NewMembersHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, services ports.MemberServices) *MembersHandlers {
+func NewMembersHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, services ports.MemberServices, householdServices ports.HouseholdServices, audit ports.AuditServices) *MembersHandlers {
 	return &MembersHandlers{
-		parser:         parser,
-		http:           http,
-		memberServices: services,
+		parser:            parser,
+		http:              http,
+		memberServices:    services,
+		householdServices: householdServices,
+		audit:             audit,
 	}
 }
 
@@ -25,51 +33,65 @@ func NewMembersHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, se
 func (h *MembersHandlers) CreateMember(c *fiber.Ctx) error {
 	member := new(entities.Member)
 	if err := h.parser.ParseData(c, member); err != nil {
-		return h.http.BadRequest(c, "Errore nella gestione dei dati")
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Join household
+	if err := h.householdServices.PrepareHouseholdMember(c.UserContext(), member); err != nil {
+		return err
 	}
 
 	//Validate member
 	if err := member.Validate(); err != nil {
-		return h.http.BadRequest(c, err.Error())
+		return h.http.ValidationFailed(c, i18n.MsgMemberInvalid, err)
 	}
 
 	// Add ending date
 	member.Subscription[0].AddEndDate()
 
 	// Create member
-	if err := h.memberServices.CreateMember(member); err != nil {
-		return h.http.InternalServerError(c, "Errore nel creare il membro")
+	if err := h.memberServices.CreateMember(c.UserContext(), member); err != nil {
+		var validationErrors entities.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return h.http.ValidationFailed(c, i18n.MsgSubscriptionInvalid, validationErrors)
+		}
+		return err
 	}
 
-	return h.http.Success(c, []interface{}{member}, "Membro aggiunto!")
+	h.audit.LogChange(c, entities.AuditCreate, "members", member.ID, nil, member)
+	return h.http.Success(c, []interface{}{member}, i18n.MsgMemberCreated)
 }
 
 // UpdateMember updates a member in the database.
 func (h *MembersHandlers) UpdateMember(c *fiber.Ctx) error {
 	updatedMember := new(entities.UpdateMember)
 	if err := h.parser.ParseData(c, updatedMember); err != nil {
-		return h.http.BadRequest(c, "Errore nella gestione dei dati")
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
 	}
 
 	// get member from fiber locals
 	member := utils.GetLocalMember(c)
 
 	// update user
-	if err := h.memberServices.UpdateMember(member.ID, updatedMember); err != nil {
-		return h.http.InternalServerError(c, "Errore nell'aggiornare il membro")
+	if err := h.memberServices.UpdateMember(c.UserContext(), member.ID, updatedMember); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgMemberUpdateError)
+	}
+
+	if updated, err := h.memberServices.GetMemberById(c.UserContext(), member.ID); err == nil {
+		h.audit.LogChange(c, entities.AuditUpdate, "members", member.ID, member, &updated)
 	}
 
-	return h.http.Success(c, []interface{}{updatedMember}, "Membro aggiornato")
+	return h.http.Success(c, []interface{}{updatedMember}, i18n.MsgMemberUpdated)
 }
 
 // GetMembers retrieves all members from the database.
 func (h *MembersHandlers) GetMembers(c *fiber.Ctx) error {
-	members, err := h.memberServices.GetAllMembers()
+	members, err := h.memberServices.GetAllMembers(c.UserContext())
 	if err != nil {
-		return h.http.InternalServerError(c, "Errore nel recuperare i membri")
+		return h.http.InternalServerError(c, i18n.MsgMembersFetchError)
 	}
 
-	return h.http.Success(c, members, "Membri recuperati")
+	return h.http.Success(c, members, i18n.MsgMembersFetched)
 }
 
 // GetMemberById retrieves a member by their ID from the database.
@@ -77,7 +99,7 @@ func (h *MembersHandlers) GetMemberById(c *fiber.Ctx) error {
 	// Get member from fiber locals
 	member := utils.GetLocalMember(c)
 
-	return h.http.Success(c, []interface{}{member}, "Membro recuperato")
+	return h.http.Success(c, []interface{}{member}, i18n.MsgMemberFetched)
 }
 
 // DeleteMember deletes a member from the database.
@@ -86,11 +108,12 @@ func (h *MembersHandlers) DeleteMember(c *fiber.Ctx) error {
 	member := utils.GetLocalMember(c)
 
 	// Delete member
-	if err := h.memberServices.DeleteMember(member.ID); err != nil {
-		return h.http.InternalServerError(c, "Errore nel eliminare il membro")
+	if err := h.memberServices.DeleteMember(c.UserContext(), member.ID); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgMemberDeleteError)
 	}
 
-	return h.http.Success(c, nil, "Membro eliminato")
+	h.audit.LogChange(c, entities.AuditDelete, "members", member.ID, member, nil)
+	return h.http.Success(c, nil, i18n.MsgMemberDeleted)
 }
 
 // CreateMemberSubscription handles the creation of a new member subscription.
@@ -99,23 +122,71 @@ func (h *MembersHandlers) CreateMemberSubscription(c *fiber.Ctx) error {
 	member := utils.GetLocalMember(c)
 	subscription := new(entities.Subscription)
 	if err := h.parser.ParseData(c, subscription); err != nil {
-		return h.http.BadRequest(c, "Errore nella gestione dei dati")
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
 	}
 
 	// Validate subscription
 	if err := subscription.Validate(); err != nil {
-		return h.http.BadRequest(c, err.Error())
+		return h.http.ValidationFailed(c, i18n.MsgSubscriptionInvalid, err)
+	}
+
+	// Validate overlap policy
+	policy := entities.OverlapPolicy(c.Query("on_overlap", string(entities.OverlapReject)))
+	if err := policy.Validate(); err != nil {
+		return h.http.ValidationFailed(c, i18n.MsgSubscriptionInvalid, err)
 	}
 
 	// Add ending date
 	subscription.AddEndDate()
 
 	// Create subscription
-	if err := h.memberServices.CreateMemberSubscription(member.ID, subscription); err != nil {
-		return h.http.InternalServerError(c, "Errore nel creare l'iscrizione")
+	if err := h.memberServices.CreateMemberSubscription(c.UserContext(), member.ID, subscription, policy); err != nil {
+		var validationErrors entities.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return h.http.ValidationFailed(c, i18n.MsgSubscriptionInvalid, validationErrors)
+		}
+		return h.http.InternalServerError(c, i18n.MsgSubscriptionCreateError)
 	}
 
-	return h.http.Success(c, subscription, "Iscrizione creata")
+	h.audit.LogChange(c, entities.AuditCreate, "subscriptions", subscription.ID, nil, subscription)
+	return h.http.Success(c, subscription, i18n.MsgSubscriptionCreated)
+}
+
+// RenewMemberSubscription creates the subscription following an existing one.
+func (h *MembersHandlers) RenewMemberSubscription(c *fiber.Ctx) error {
+	// Get member from fiber locals
+	member := utils.GetLocalMember(c)
+	sub_id := utils.GetUintParam(c, "sub_id")
+
+	renew := new(entities.RenewSubscription)
+	if len(c.Body()) > 0 {
+		if err := h.parser.ParseData(c, renew); err != nil {
+			return h.http.BadRequest(c, i18n.MsgDataInvalid)
+		}
+	}
+
+	// Validate renewal
+	if err := renew.Validate(); err != nil {
+		return h.http.ValidationFailed(c, i18n.MsgRenewalInvalid, err)
+	}
+
+	// Get subrscription
+	if _, err := h.memberServices.GetSubscriptionById(c.UserContext(), member.ID, sub_id); err != nil {
+		return h.http.NotFound(c, i18n.MsgSubscriptionNotFound)
+	}
+
+	// Renew subscription
+	subscription, err := h.memberServices.RenewSubscription(c.UserContext(), member.ID, sub_id, renew)
+	if err != nil {
+		var validationErrors entities.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return h.http.ValidationFailed(c, i18n.MsgSubscriptionInvalid, validationErrors)
+		}
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditCreate, "subscriptions", subscription.ID, nil, subscription)
+	return h.http.Success(c, subscription, i18n.MsgSubscriptionRenewed)
 }
 
 // GetMemberSubscriptions retrieves all member subscriptions from the database.
@@ -124,12 +195,12 @@ func (h *MembersHandlers) GetMemberSubscriptions(c *fiber.Ctx) error {
 	member := utils.GetLocalMember(c)
 
 	// Get subrscriptions
-	subscriptions, err := h.memberServices.GetAllSubscriptions(member.ID)
+	subscriptions, err := h.memberServices.GetAllSubscriptions(c.UserContext(), member.ID)
 	if err != nil {
-		return h.http.NotFound(c, "Membros non trovato")
+		return h.http.NotFound(c, i18n.MsgMemberNotFound)
 	}
 
-	return h.http.Success(c, subscriptions, "Iscrizioni recuperate")
+	return h.http.Success(c, subscriptions, i18n.MsgSubscriptionsFetched)
 }
 
 // GetMemberSubscriptionById retrieves a member subscription by their ID from the database.
@@ -139,12 +210,12 @@ func (h *MembersHandlers) GetMemberSubscriptionById(c *fiber.Ctx) error {
 	sub_id := utils.GetUintParam(c, "sub_id")
 
 	// Get subrscription
-	subscription, err := h.memberServices.GetSubscriptionById(member.ID, sub_id)
+	subscription, err := h.memberServices.GetSubscriptionById(c.UserContext(), member.ID, sub_id)
 	if err != nil {
-		return h.http.NotFound(c, "Iscrizione non trovata")
+		return h.http.NotFound(c, i18n.MsgSubscriptionNotFound)
 	}
 
-	return h.http.Success(c, subscription, "Iscrizione recuperata")
+	return h.http.Success(c, subscription, i18n.MsgSubscriptionFetched)
 }
 
 // UpdateMemberSubscription updates a member subscription in the database.
@@ -154,24 +225,40 @@ func (h *MembersHandlers) UpdateMemberSubscription(c *fiber.Ctx) error {
 	sub_id := utils.GetUintParam(c, "sub_id")
 	subscription := new(entities.UpdateSubscription)
 	if err := h.parser.ParseData(c, subscription); err != nil {
-		return h.http.BadRequest(c, "Errore nella gestione dei dati")
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
 	}
 
 	// Validate subscription
 	if err := subscription.Validate(); err != nil {
-		return h.http.BadRequest(c, err.Error())
+		return h.http.ValidationFailed(c, i18n.MsgSubscriptionInvalid, err)
 	}
 
 	// Add ending date
 	subscription.AddEndDate()
 
+	// Get subrscription
+	previous, err := h.memberServices.GetSubscriptionById(c.UserContext(), member.ID, sub_id)
+	if err != nil {
+		return h.http.NotFound(c, i18n.MsgSubscriptionNotFound)
+	}
+
 	// Update subrscription
-	updatedSub, err := h.memberServices.UpdateSubscription(member.ID, sub_id, subscription)
+	updatedSub, err := h.memberServices.UpdateSubscription(c.UserContext(), member.ID, sub_id, subscription)
 	if err != nil {
-		return h.http.NotFound(c, "Iscrizione non trovata")
+		var validationErrors entities.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return h.http.ValidationFailed(c, i18n.MsgSubscriptionInvalid, validationErrors)
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return h.http.NotFound(c, i18n.MsgSubscriptionNotFound)
+		}
+		return err
 	}
 
-	return h.http.Success(c, updatedSub, "Iscrizione aggiornata")
+	if len(updatedSub) > 0 {
+		h.audit.LogChange(c, entities.AuditUpdate, "subscriptions", sub_id, &previous[0], &updatedSub[0])
+	}
+	return h.http.Success(c, updatedSub, i18n.MsgSubscriptionUpdated)
 }
 
 // DeleteMemberSubscription deletes a member subscription from the database.
@@ -181,19 +268,99 @@ func (h *MembersHandlers) DeleteMemberSubscription(c *fiber.Ctx) error {
 	sub_id := utils.GetUintParam(c, "sub_id")
 
 	if sub_id == 0 {
-		return h.http.BadRequest(c, "Specificare l'id dell'iscrizione da eliminare")
+		return h.http.BadRequest(c, i18n.MsgSubscriptionDeleteIDRequired)
 	}
 
 	// Get subrscription
-	_, err := h.memberServices.GetSubscriptionById(member.ID, sub_id)
+	previous, err := h.memberServices.GetSubscriptionById(c.UserContext(), member.ID, sub_id)
 	if err != nil {
-		return h.http.NotFound(c, "Iscrizione non trovata")
+		return h.http.NotFound(c, i18n.MsgSubscriptionNotFound)
 	}
 
 	// Delete subrscription
-	if err := h.memberServices.DeleteSubscription(member.ID, sub_id); err != nil {
-		return h.http.NotFound(c, "Iscrizione non trovata")
+	if err := h.memberServices.DeleteSubscription(c.UserContext(), member.ID, sub_id); err != nil {
+		return h.http.NotFound(c, i18n.MsgSubscriptionNotFound)
+	}
+
+	h.audit.LogChange(c, entities.AuditDelete, "subscriptions", sub_id, &previous[0], nil)
+	return h.http.Success(c, nil, i18n.MsgSubscriptionDeleted)
+}
+
+// GetDeletedMembers retrieves all deleted members from the database.
+func (h *MembersHandlers) GetDeletedMembers(c *fiber.Ctx) error {
+	members, err := h.memberServices.GetDeletedMembers(c.UserContext())
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgDeletedMembersFetchError)
+	}
+
+	return h.http.Success(c, members, i18n.MsgDeletedMembersFetched)
+}
+
+// RestoreMember restores a deleted member with its contacts, address and subscriptions.
+func (h *MembersHandlers) RestoreMember(c *fiber.Ctx) error {
+	id := utils.GetUintParam(c, "id")
+
+	if id == 0 {
+		return h.http.BadRequest(c, i18n.MsgMemberRestoreIDRequired)
+	}
+
+	// Restore member
+	if err := h.memberServices.RestoreMember(c.UserContext(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return h.http.NotFound(c, i18n.MsgDeletedMemberNotFound)
+		}
+		return h.http.InternalServerError(c, i18n.MsgMemberRestoreError)
+	}
+
+	member, err := h.memberServices.GetMemberById(c.UserContext(), id)
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgMemberFetchError)
+	}
+
+	h.audit.LogChange(c, entities.AuditUpdate, "members", id, fiber.Map{"deleted": true}, fiber.Map{"deleted": false})
+	return h.http.Success(c, []interface{}{member}, i18n.MsgMemberRestored)
+}
+
+// GetDeletedMemberSubscriptions retrieves the deleted subscriptions of a member.
+func (h *MembersHandlers) GetDeletedMemberSubscriptions(c *fiber.Ctx) error {
+	// Get member from fiber locals
+	member := utils.GetLocalMember(c)
+
+	subscriptions, err := h.memberServices.GetDeletedSubscriptions(c.UserContext(), member.ID)
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgDeletedSubscriptionsFetchError)
+	}
+
+	return h.http.Success(c, subscriptions, i18n.MsgDeletedSubscriptionsFetched)
+}
+
+// RestoreMemberSubscription restores a deleted subscription of a member.
+func (h *MembersHandlers) RestoreMemberSubscription(c *fiber.Ctx) error {
+	// Get member from fiber locals
+	member := utils.GetLocalMember(c)
+	sub_id := utils.GetUintParam(c, "sub_id")
+
+	if sub_id == 0 {
+		return h.http.BadRequest(c, i18n.MsgSubscriptionRestoreIDRequired)
+	}
+
+	// Restore subscription
+	if err := h.memberServices.RestoreSubscription(c.UserContext(), member.ID, sub_id); err != nil {
+		var validationErrors entities.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return h.http.ValidationFailed(c, i18n.MsgSubscriptionInvalid, validationErrors)
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return h.http.NotFound(c, i18n.MsgDeletedSubscriptionNotFound)
+		}
+		return h.http.InternalServerError(c, i18n.MsgSubscriptionRestoreError)
+	}
+
+	subscription, err := h.memberServices.GetSubscriptionById(c.UserContext(), member.ID, sub_id)
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgSubscriptionFetchError)
 	}
 
-	return h.http.Success(c, nil, "Iscrizione eliminata")
+	h.audit.LogChange(c, entities.AuditUpdate, "subscriptions", sub_id, fiber.Map{"deleted": true}, fiber.Map{"deleted": false})
+	return h.http.Success(c, subscription, i18n.MsgSubscriptionRestored)
 }
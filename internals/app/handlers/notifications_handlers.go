@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type NotificationsHandlers struct {
+	parser               ports.ParserAdapters
+	http                 ports.HttpAdapters
+	notificationServices ports.NotificationServices
+	audit                ports.AuditServices
+}
+
+func NewNotificationsHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, services ports.NotificationServices, audit ports.AuditServices) *NotificationsHandlers {
+	return &NotificationsHandlers{
+		parser:               parser,
+		http:                 http,
+		notificationServices: services,
+		audit:                audit,
+	}
+}
+
+// GetMemberNotifications retrieves the notifications sent to a member.
+func (h *NotificationsHandlers) GetMemberNotifications(c *fiber.Ctx) error {
+	// Get member from fiber locals
+	member := utils.GetLocalMember(c)
+
+	notifications, err := h.notificationServices.GetMemberNotifications(c.UserContext(), member.ID)
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgNotificationsFetchError)
+	}
+
+	return h.http.Success(c, notifications, i18n.MsgNotificationsFetched)
+}
+
+// UpdateMemberPreferences updates the channels a member accepts to be contacted on.
+func (h *NotificationsHandlers) UpdateMemberPreferences(c *fiber.Ctx) error {
+	preferences := new(entities.NotificationPreferences)
+	if err := h.parser.ParseData(c, preferences); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Validate preferences
+	if err := preferences.Validate(); err != nil {
+		return err
+	}
+
+	// Get member from fiber locals
+	member := utils.GetLocalMember(c)
+
+	// Update preferences
+	contacts, err := h.notificationServices.UpdatePreferences(c.UserContext(), member.ID, preferences)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return h.http.NotFound(c, i18n.MsgMemberContactsNotFound)
+		}
+		return h.http.InternalServerError(c, i18n.MsgPreferencesUpdateError)
+	}
+
+	h.audit.LogChange(c, entities.AuditUpdate, "contacts", member.ID, member.Contacts, contacts)
+	return h.http.Success(c, []interface{}{contacts}, i18n.MsgPreferencesUpdated)
+}
@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
 	"github.com/gofiber/fiber/v2"
 )
@@ -11,13 +14,15 @@ type PermissionsHandler struct {
 	permission ports.PermissionsServices
 	http       ports.HttpAdapters
 	parser     ports.ParserAdapters
+	audit      ports.AuditServices
 }
 
-func NewPermissionsHandler(parser ports.ParserAdapters, http ports.HttpAdapters, permissionsService ports.PermissionsServices) *PermissionsHandler {
+func NewPermissionsHandler(parser ports.ParserAdapters, http ports.HttpAdapters, permissionsService ports.PermissionsServices, audit ports.AuditServices) *PermissionsHandler {
 	return &PermissionsHandler{
 		http:       http,
 		parser:     parser,
 		permission: permissionsService,
+		audit:      audit,
 	}
 }
 
@@ -33,22 +38,27 @@ func (p *PermissionsHandler) CreatePermission(c *fiber.Ctx) error {
 		perm.RoleId = id
 	}
 
-	if err := p.permission.ValidateNewPermission(perm); err != nil {
-		return p.http.BadRequest(c, err.Error())
+	if err := p.permission.ValidateNewPermission(c.UserContext(), perm); err != nil {
+		var validationErrors entities.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return p.http.ValidationFailed(c, i18n.MsgPermissionInvalid, validationErrors)
+		}
+		return p.http.InternalServerError(c, i18n.MsgPermissionCheckError)
 	}
 
-	if err := p.permission.CreatePermission(perm); err != nil {
-		return p.http.InternalServerError(c, err.Error())
+	if err := p.permission.CreatePermission(c.UserContext(), perm); err != nil {
+		return p.http.InternalServerError(c, i18n.MsgPermissionCreateError)
 	}
 
-	return p.http.Success(c, []interface{}{perm}, "Permesso creato")
+	p.audit.LogChange(c, entities.AuditCreate, "permissions", perm.ID, nil, perm)
+	return p.http.Success(c, []interface{}{perm}, i18n.MsgPermissionCreated)
 }
 
 // UpdatePermission handles the update of an existing permission.
 func (p *PermissionsHandler) UpdatePermission(c *fiber.Ctx) error {
 	id := utils.GetUintParam(c, "perm_id")
 	if id == 0 {
-		return p.http.BadRequest(c, "Specificare l'id del permesso")
+		return p.http.BadRequest(c, i18n.MsgPermissionIDRequired)
 	}
 
 	perm := &entities.UpdatePermissions{}
@@ -57,69 +67,71 @@ func (p *PermissionsHandler) UpdatePermission(c *fiber.Ctx) error {
 	}
 
 	// Check if the permission exists
-	_, err := p.permission.GetPermission(id)
+	previous, err := p.permission.GetPermission(c.UserContext(), id)
 	if err != nil {
-		return p.http.NotFound(c, "Permesso non trovato")
+		return p.http.NotFound(c, i18n.MsgPermissionNotFound)
 	}
 
 	if err := p.permission.ValidateUpdatePermission(perm); err != nil {
-		return p.http.BadRequest(c, err.Error())
+		return p.http.ValidationFailed(c, i18n.MsgPermissionInvalid, err)
 	}
 
-	permission, err := p.permission.UpdatePermission(id, perm)
+	permission, err := p.permission.UpdatePermission(c.UserContext(), id, perm)
 	if err != nil {
-		return p.http.InternalServerError(c, err.Error())
+		return p.http.InternalServerError(c, i18n.MsgPermissionUpdateError)
 	}
 
-	return p.http.Success(c, []interface{}{permission}, "Permesso aggiornato")
+	p.audit.LogChange(c, entities.AuditUpdate, "permissions", id, previous, permission)
+	return p.http.Success(c, []interface{}{permission}, i18n.MsgPermissionUpdated)
 }
 
 // GetPermission handles the retrieval of a permission by its ID.
 func (p *PermissionsHandler) GetPermission(c *fiber.Ctx) error {
 	id := utils.GetUintParam(c, "perm_id")
 	if id == 0 {
-		return p.http.BadRequest(c, "Specificare l'id del permesso")
+		return p.http.BadRequest(c, i18n.MsgPermissionIDRequired)
 	}
 
-	permission, err := p.permission.GetPermission(id)
+	permission, err := p.permission.GetPermission(c.UserContext(), id)
 	if err != nil {
-		return p.http.NotFound(c, "Permesso non trovato")
+		return p.http.NotFound(c, i18n.MsgPermissionNotFound)
 	}
 
-	return p.http.Success(c, []interface{}{permission}, "Permesso recuperato")
+	return p.http.Success(c, []interface{}{permission}, i18n.MsgPermissionFetched)
 }
 
 // GetPermissions handles the retrieval of all permissions.
 func (p *PermissionsHandler) GetPermissions(c *fiber.Ctx) error {
-	permissions, err := p.permission.GetAllPermissions()
+	permissions, err := p.permission.GetAllPermissions(c.UserContext())
 	if err != nil {
-		return p.http.NotFound(c, "Permesso non trovato")
+		return p.http.NotFound(c, i18n.MsgPermissionNotFound)
 	}
 
 	if len(permissions) == 0 {
-		return p.http.NotFound(c, "Permessi non trovato")
+		return p.http.NotFound(c, i18n.MsgPermissionsNotFound)
 	}
 
-	return p.http.Success(c, permissions, "Permesso recuperato")
+	return p.http.Success(c, permissions, i18n.MsgPermissionFetched)
 }
 
 // DeletePermission handles the deletion of a permission by its ID.
 func (p *PermissionsHandler) DeletePermission(c *fiber.Ctx) error {
 	id := utils.GetUintParam(c, "perm_id")
 	if id == 0 {
-		return p.http.BadRequest(c, "Specificare l'id del permesso")
+		return p.http.BadRequest(c, i18n.MsgPermissionIDRequired)
 	}
 
 	// Check if the permission exists
-	_, err := p.permission.GetPermission(id)
+	previous, err := p.permission.GetPermission(c.UserContext(), id)
 	if err != nil {
-		return p.http.NotFound(c, "Permesso non trovato")
+		return p.http.NotFound(c, i18n.MsgPermissionNotFound)
 	}
 
 	// Delete the permission
-	if err := p.permission.DeletePermission(id); err != nil {
-		return p.http.NotFound(c, "Permesso non trovato")
+	if err := p.permission.DeletePermission(c.UserContext(), id); err != nil {
+		return p.http.NotFound(c, i18n.MsgPermissionNotFound)
 	}
 
-	return p.http.Success(c, nil, "Permesso eliminato")
+	p.audit.LogChange(c, entities.AuditDelete, "permissions", id, previous, nil)
+	return p.http.Success(c, nil, i18n.MsgPermissionDeleted)
 }
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type PrivacyHandlers struct {
+	parser          ports.ParserAdapters
+	http            ports.HttpAdapters
+	privacyServices ports.PrivacyServices
+	audit           ports.AuditServices
+}
+
+func NewPrivacyHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, services ports.PrivacyServices, audit ports.AuditServices) *PrivacyHandlers {
+	return &PrivacyHandlers{
+		parser:          parser,
+		http:            http,
+		privacyServices: services,
+		audit:           audit,
+	}
+}
+
+// ExportMember exports everything held about a member as JSON or as a ZIP bundle.
+func (h *PrivacyHandlers) ExportMember(c *fiber.Ctx) error {
+	id := utils.GetUintParam(c, "id")
+
+	if id == 0 {
+		return h.http.BadRequest(c, i18n.MsgMemberIDRequired)
+	}
+
+	format := c.Query("format", "json")
+	if format != "json" && format != "zip" {
+		return h.http.BadRequest(c, i18n.MsgExportFormatInvalid)
+	}
+
+	// Export member
+	export, err := h.privacyServices.ExportMember(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return h.http.NotFound(c, i18n.MsgMemberNotFound)
+		}
+		return h.http.InternalServerError(c, i18n.MsgMemberExportError)
+	}
+
+	if format == "json" {
+		return h.http.Success(c, export, i18n.MsgMemberDataExported)
+	}
+
+	archive, err := utils.ZipJSON(export.Files())
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgMemberExportError)
+	}
+
+	return h.http.Attachment(c, fmt.Sprintf("membro_%d.zip", id), archive)
+}
+
+// EraseMember anonymizes the personal data of a member.
+func (h *PrivacyHandlers) EraseMember(c *fiber.Ctx) error {
+	id := utils.GetUintParam(c, "id")
+
+	if id == 0 {
+		return h.http.BadRequest(c, i18n.MsgMemberIDRequired)
+	}
+
+	// Erase member
+	if err := h.privacyServices.EraseMember(c.UserContext(), id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return h.http.NotFound(c, i18n.MsgMemberNotFound)
+		}
+		return err
+	}
+
+	// The personal data is never recorded
+	h.audit.LogChange(c, entities.AuditUpdate, "members", id, fiber.Map{"erased": false}, fiber.Map{"erased": true})
+	return h.http.Success(c, nil, i18n.MsgMemberDataAnonymized)
+}
+
+// GetMemberConsents retrieves the consents of a member.
+func (h *PrivacyHandlers) GetMemberConsents(c *fiber.Ctx) error {
+	// Get member from fiber locals
+	member := utils.GetLocalMember(c)
+
+	consents, err := h.privacyServices.GetMemberConsents(c.UserContext(), member.ID)
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgConsentsFetchError)
+	}
+
+	return h.http.Success(c, consents, i18n.MsgConsentsFetched)
+}
+
+// GiveMemberConsent records a consent given by a member.
+func (h *PrivacyHandlers) GiveMemberConsent(c *fiber.Ctx) error {
+	consent := new(entities.Consent)
+	if err := h.parser.ParseData(c, consent); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Validate consent
+	if err := consent.Validate(); err != nil {
+		return err
+	}
+
+	// Get member and staff user from fiber locals
+	member := utils.GetLocalMember(c)
+	user := utils.GetLocalUser(c)
+
+	consent.MemberID = member.ID
+	consent.CollectedBy = user.ID
+	consent.RevokedAt = nil
+	consent.RevokedBy = nil
+
+	// Give consent
+	if err := h.privacyServices.GiveConsent(c.UserContext(), consent); err != nil {
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditCreate, "consents", consent.ID, nil, consent)
+	return h.http.Success(c, []interface{}{consent}, i18n.MsgConsentCreated)
+}
+
+// RevokeMemberConsent revokes a consent of a member.
+func (h *PrivacyHandlers) RevokeMemberConsent(c *fiber.Ctx) error {
+	consentID := utils.GetUintParam(c, "consent_id")
+
+	if consentID == 0 {
+		return h.http.BadRequest(c, i18n.MsgConsentIDRequired)
+	}
+
+	// Get member and staff user from fiber locals
+	member := utils.GetLocalMember(c)
+	user := utils.GetLocalUser(c)
+
+	// Revoke consent
+	consent, err := h.privacyServices.RevokeConsent(c.UserContext(), member.ID, consentID, user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return h.http.NotFound(c, i18n.MsgConsentNotFound)
+		}
+		return err
+	}
+
+	h.audit.LogChange(c, entities.AuditUpdate, "consents", consent.ID, fiber.Map{"revoked_at": nil}, fiber.Map{"revoked_at": consent.RevokedAt})
+	return h.http.Success(c, []interface{}{consent}, i18n.MsgConsentRevoked)
+}
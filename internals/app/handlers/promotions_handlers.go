@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PromotionsHandlers struct {
+	parser            ports.ParserAdapters
+	http              ports.HttpAdapters
+	promotionServices ports.PromotionServices
+	audit             ports.AuditServices
+}
+
+func NewPromotionsHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, services ports.PromotionServices, audit ports.AuditServices) *PromotionsHandlers {
+	return &PromotionsHandlers{
+		parser:            parser,
+		http:              http,
+		promotionServices: services,
+		audit:             audit,
+	}
+}
+
+// CreatePromotion handles the creation of a new promotion.
+func (h *PromotionsHandlers) CreatePromotion(c *fiber.Ctx) error {
+	promotion := new(entities.Promotion)
+	if err := h.parser.ParseData(c, promotion); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Validate promotion
+	if err := promotion.Validate(); err != nil {
+		return err
+	}
+
+	// Create promotion
+	if err := h.promotionServices.CreatePromotion(c.UserContext(), promotion); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgPromotionCreateError)
+	}
+
+	h.audit.LogChange(c, entities.AuditCreate, "promotions", promotion.ID, nil, promotion)
+	return h.http.Success(c, []interface{}{promotion}, i18n.MsgPromotionCreated)
+}
+
+// GetPromotions handles the retrieval of all promotions.
+func (h *PromotionsHandlers) GetPromotions(c *fiber.Ctx) error {
+	promotions, err := h.promotionServices.GetAllPromotions(c.UserContext())
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgPromotionsFetchError)
+	}
+
+	return h.http.Success(c, promotions, i18n.MsgPromotionsFetched)
+}
+
+// GetPromotion handles the retrieval of a promotion by its ID.
+func (h *PromotionsHandlers) GetPromotion(c *fiber.Ctx) error {
+	id := utils.GetUintParam(c, "id")
+
+	if id == 0 {
+		return h.http.BadRequest(c, i18n.MsgPromotionIDRequired)
+	}
+
+	// Get promotion
+	promotion, err := h.promotionServices.GetPromotion(c.UserContext(), id)
+	if err != nil {
+		return h.http.NotFound(c, i18n.MsgPromotionNotFound)
+	}
+
+	return h.http.Success(c, []interface{}{promotion}, i18n.MsgPromotionFetched)
+}
+
+// UpdatePromotion handles the update of a promotion.
+func (h *PromotionsHandlers) UpdatePromotion(c *fiber.Ctx) error {
+	id := utils.GetUintParam(c, "id")
+	promotion := new(entities.UpdatePromotion)
+	if err := h.parser.ParseData(c, promotion); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Validate promotion
+	if err := promotion.Validate(); err != nil {
+		return err
+	}
+
+	// Get promotion
+	previous, err := h.promotionServices.GetPromotion(c.UserContext(), id)
+	if err != nil {
+		return h.http.NotFound(c, i18n.MsgPromotionNotFound)
+	}
+
+	// Update promotion
+	updated, err := h.promotionServices.UpdatePromotion(c.UserContext(), id, promotion)
+	if err != nil {
+		// The update is not valid with the stored values
+		var domainError *entities.DomainError
+		if errors.As(err, &domainError) {
+			return err
+		}
+		return h.http.InternalServerError(c, i18n.MsgPromotionUpdateError)
+	}
+
+	h.audit.LogChange(c, entities.AuditUpdate, "promotions", id, previous, updated)
+	return h.http.Success(c, []interface{}{updated}, i18n.MsgPromotionUpdated)
+}
+
+// DeletePromotion handles the deletion of a promotion.
+func (h *PromotionsHandlers) DeletePromotion(c *fiber.Ctx) error {
+	id := utils.GetUintParam(c, "id")
+
+	if id == 0 {
+		return h.http.BadRequest(c, i18n.MsgPromotionIDRequired)
+	}
+
+	// Get promotion
+	previous, err := h.promotionServices.GetPromotion(c.UserContext(), id)
+	if err != nil {
+		return h.http.NotFound(c, i18n.MsgPromotionNotFound)
+	}
+
+	// Delete promotion
+	if err := h.promotionServices.DeletePromotion(c.UserContext(), id); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgPromotionDeleteError)
+	}
+
+	h.audit.LogChange(c, entities.AuditDelete, "promotions", id, previous, nil)
+	return h.http.Success(c, nil, i18n.MsgPromotionDeleted)
+}
+
+// GetPromotionRedemptions handles the retrieval of the redemptions of a promotion.
+func (h *PromotionsHandlers) GetPromotionRedemptions(c *fiber.Ctx) error {
+	id := utils.GetUintParam(c, "id")
+
+	// Get promotion
+	if _, err := h.promotionServices.GetPromotion(c.UserContext(), id); err != nil {
+		return h.http.NotFound(c, i18n.MsgPromotionNotFound)
+	}
+
+	// Get redemptions
+	redemptions, err := h.promotionServices.GetPromotionRedemptions(c.UserContext(), id)
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgRedemptionsFetchError)
+	}
+
+	return h.http.Success(c, redemptions, i18n.MsgRedemptionsFetched)
+}
+
+// GetRedemptionsReport handles the report of the redemptions per promotion.
+func (h *PromotionsHandlers) GetRedemptionsReport(c *fiber.Ctx) error {
+	report, err := h.promotionServices.GetRedemptionsReport(c.UserContext())
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgReportFetchError)
+	}
+
+	return h.http.Success(c, report, i18n.MsgReportFetched)
+}
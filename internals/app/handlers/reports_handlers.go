@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Months of the reports without a period
+const defaultReportMonths = 12
+
+type ReportsHandlers struct {
+	http           ports.HttpAdapters
+	reportServices ports.ReportServices
+}
+
+func NewReportsHandlers(http ports.HttpAdapters, services ports.ReportServices) *ReportsHandlers {
+	return &ReportsHandlers{
+		http:           http,
+		reportServices: services,
+	}
+}
+
+// GetRevenueReport retrieves the monthly recurring revenue.
+func (h *ReportsHandlers) GetRevenueReport(c *fiber.Ctx) error {
+	return h.report(c, h.reportServices.GetRevenueReport)
+}
+
+// GetGrowthReport retrieves the new and churned members of each month.
+func (h *ReportsHandlers) GetGrowthReport(c *fiber.Ctx) error {
+	return h.report(c, h.reportServices.GetGrowthReport)
+}
+
+// GetActiveMembersReport retrieves the active members of each month.
+func (h *ReportsHandlers) GetActiveMembersReport(c *fiber.Ctx) error {
+	return h.report(c, h.reportServices.GetActiveMembersReport)
+}
+
+// GetSubscriptionMixReport retrieves the share of each subscription type.
+func (h *ReportsHandlers) GetSubscriptionMixReport(c *fiber.Ctx) error {
+	return h.report(c, h.reportServices.GetSubscriptionMixReport)
+}
+
+// GetLifetimeReport retrieves the average lifetime of the members.
+func (h *ReportsHandlers) GetLifetimeReport(c *fiber.Ctx) error {
+	return h.report(c, h.reportServices.GetLifetimeReport)
+}
+
+// GetRetentionReport retrieves the retention cohorts.
+func (h *ReportsHandlers) GetRetentionReport(c *fiber.Ctx) error {
+	return h.report(c, h.reportServices.GetRetentionReport)
+}
+
+// report computes a report over the months of the query parameters, by
+// default the last 12 months.
+func (h *ReportsHandlers) report(c *fiber.Ctx, compute func(ctx context.Context, period *entities.ReportPeriod) (*entities.Report, error)) error {
+	now := time.Now()
+	period := &entities.ReportPeriod{
+		To: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local),
+	}
+	period.From = period.To.AddDate(0, 1-defaultReportMonths, 0)
+
+	// Parse the months
+	var err error
+	if to := c.Query("to"); to != "" {
+		if period.To, err = time.ParseInLocation(entities.ReportMonthLayout, to, time.Local); err != nil {
+			return h.http.BadRequest(c, i18n.MsgEndMonthFormat)
+		}
+		if c.Query("from") == "" {
+			period.From = period.To.AddDate(0, 1-defaultReportMonths, 0)
+		}
+	}
+	if from := c.Query("from"); from != "" {
+		if period.From, err = time.ParseInLocation(entities.ReportMonthLayout, from, time.Local); err != nil {
+			return h.http.BadRequest(c, i18n.MsgStartMonthFormat)
+		}
+	}
+
+	if err := period.Validate(); err != nil {
+		return err
+	}
+
+	report, err := compute(c.UserContext(), period)
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgReportComputeError)
+	}
+
+	return h.http.Success(c, report, i18n.MsgReportComputed)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
 	"github.com/gofiber/fiber/v2"
 )
@@ -11,13 +12,15 @@ type RolesHandlers struct {
 	parser        ports.ParserAdapters
 	http          ports.HttpAdapters
 	rolesServices ports.RolesServices
+	audit         ports.AuditServices
 }
 
-func NewRolesHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, rolesService ports.RolesServices) *RolesHandlers {
+func NewRolesHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, rolesService ports.RolesServices, audit ports.AuditServices) *RolesHandlers {
 	return &RolesHandlers{
 		parser:        parser,
 		http:          http,
 		rolesServices: rolesService,
+		audit:         audit,
 	}
 }
 
@@ -25,35 +28,36 @@ func NewRolesHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, role
 func (h *RolesHandlers) CreateRole(c *fiber.Ctx) error {
 	role := new(entities.Roles)
 	if err := h.parser.ParseData(c, role); err != nil {
-		return h.http.BadRequest(c, "Errore nella gestione dei dati")
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
 	}
 
 	// Validate role
 	if err := role.Validate(); err != nil {
-		return h.http.BadRequest(c, err.Error())
+		return h.http.ValidationFailed(c, i18n.MsgRoleInvalid, err)
 	}
 
 	// Create role
-	if err := h.rolesServices.CreateRole(role); err != nil {
-		return h.http.InternalServerError(c, "Errore nel creare il ruolo")
+	if err := h.rolesServices.CreateRole(c.UserContext(), role); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgRoleCreateError)
 	}
 
-	return h.http.Success(c, []interface{}{role}, "Ruolo creato!")
+	h.audit.LogChange(c, entities.AuditCreate, "roles", role.ID, nil, role)
+	return h.http.Success(c, []interface{}{role}, i18n.MsgRoleCreated)
 }
 
 // GetAllRoles handles the retrieval of all roles.
 func (h *RolesHandlers) GetAllRoles(c *fiber.Ctx) error {
-	roles, err := h.rolesServices.GetAllRoles()
+	roles, err := h.rolesServices.GetAllRoles(c.UserContext())
 	if err != nil {
-		return h.http.InternalServerError(c, "Errore nel recuperare i ruoli")
+		return h.http.InternalServerError(c, i18n.MsgRolesFetchError)
 	}
 
 	// Check if roles is empty
 	if len(roles) == 0 {
-		return h.http.NotFound(c, "Ruoli non trovati")
+		return h.http.NotFound(c, i18n.MsgRolesNotFound)
 	}
 
-	return h.http.Success(c, roles, "Ruoli recuperati")
+	return h.http.Success(c, roles, i18n.MsgRolesFetched)
 }
 
 // GetRole handles the retrieval of a role by its ID.
@@ -61,16 +65,16 @@ func (h *RolesHandlers) GetRole(c *fiber.Ctx) error {
 	id := utils.GetUintParam(c, "id")
 
 	if id == 0 {
-		return h.http.BadRequest(c, "Specificare l'id del ruolo")
+		return h.http.BadRequest(c, i18n.MsgRoleIDRequired)
 	}
 
 	// Get role
-	role, err := h.rolesServices.GetRole(id)
+	role, err := h.rolesServices.GetRole(c.UserContext(), id)
 	if err != nil {
-		return h.http.NotFound(c, "Ruolo non trovato")
+		return h.http.NotFound(c, i18n.MsgRoleNotFound)
 	}
 
-	return h.http.Success(c, []interface{}{role}, "Ruolo recuperato")
+	return h.http.Success(c, []interface{}{role}, i18n.MsgRoleFetched)
 }
 
 // GetRolePermissions handles the retrieval of the permissions of a role by its ID.
@@ -78,16 +82,16 @@ func (h *RolesHandlers) GetRolePermissions(c *fiber.Ctx) error {
 	id := utils.GetUintParam(c, "id")
 
 	if id == 0 {
-		return h.http.BadRequest(c, "Specificare l'id del ruolo")
+		return h.http.BadRequest(c, i18n.MsgRoleIDRequired)
 	}
 
 	// Get role
-	role, err := h.rolesServices.GetRolePermissions(id)
+	role, err := h.rolesServices.GetRolePermissions(c.UserContext(), id)
 	if err != nil {
-		return h.http.NotFound(c, "Ruolo non trovato")
+		return h.http.NotFound(c, i18n.MsgRoleNotFound)
 	}
 
-	return h.http.Success(c, role, "Permessi recuperati")
+	return h.http.Success(c, role, i18n.MsgPermissionsFetched)
 }
 
 // UpdateRole handles the update of a role.
@@ -95,26 +99,30 @@ func (h *RolesHandlers) UpdateRole(c *fiber.Ctx) error {
 	id := utils.GetUintParam(c, "id")
 	role := new(entities.UpdateRoles)
 	if err := h.parser.ParseData(c, role); err != nil {
-		return h.http.BadRequest(c, "Errore nella gestione dei dati")
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
 	}
 
 	// Validate role
 	if err := role.Validate(); err != nil {
-		return h.http.BadRequest(c, err.Error())
+		return h.http.ValidationFailed(c, i18n.MsgRoleInvalid, err)
 	}
 
 	// Get role
-	_, err := h.rolesServices.GetRole(id)
+	previous, err := h.rolesServices.GetRole(c.UserContext(), id)
 	if err != nil {
-		return h.http.NotFound(c, "Ruolo non trovato")
+		return h.http.NotFound(c, i18n.MsgRoleNotFound)
 	}
 
 	//Update role
-	if err := h.rolesServices.UpdateRole(id, role); err != nil {
-		return h.http.NotFound(c, "Ruolo non trovato")
+	if err := h.rolesServices.UpdateRole(c.UserContext(), id, role); err != nil {
+		return h.http.NotFound(c, i18n.MsgRoleNotFound)
 	}
 
-	return h.http.Success(c, []interface{}{role}, "Ruolo aggiornato")
+	if updated, err := h.rolesServices.GetRole(c.UserContext(), id); err == nil {
+		h.audit.LogChange(c, entities.AuditUpdate, "roles", id, previous, updated)
+	}
+
+	return h.http.Success(c, []interface{}{role}, i18n.MsgRoleUpdated)
 }
 
 // DeleteRole handles the deletion of a role.
@@ -122,19 +130,21 @@ func (h *RolesHandlers) DeleteRole(c *fiber.Ctx) error {
 	id := utils.GetUintParam(c, "id")
 
 	if id == 0 {
-		return h.http.BadRequest(c, "Specificare l'id del ruolo")
+		return h.http.BadRequest(c, i18n.MsgRoleIDRequired)
 	}
 
 	// Get role
-	_, err := h.rolesServices.GetRole(id)
+	previous, err := h.rolesServices.GetRole(c.UserContext(), id)
 	if err != nil {
-		return h.http.NotFound(c, "Ruolo non trovato")
+		return h.http.NotFound(c, i18n.MsgRoleNotFound)
 	}
 
 	// Delete role
-	if err := h.rolesServices.DeleteRole(id); err != nil {
-		return h.http.NotFound(c, "Ruolo non trovato")
+	if err := h.rolesServices.DeleteRole(c.UserContext(), id); err != nil {
+		return h.http.NotFound(c, i18n.MsgRoleNotFound)
 	}
 
-	return h.http.Success(c, nil, "Ruolo eliminato")
+	h.audit.LogChange(c, entities.AuditDelete, "roles", id, previous, nil)
+
+	return h.http.Success(c, nil, i18n.MsgRoleDeleted)
 }
@@ -3,6 +3,7 @@ package handlers
 import (
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
 	"github.com/gofiber/fiber/v2"
 )
@@ -12,15 +13,17 @@ type UserHandlers struct {
 	http   ports.HttpAdapters
 	user   ports.UserServices
 	roles  ports.RolesServices
+	audit  ports.AuditServices
 }
 
 // NewUserHandlers creates a new UserHandlers struct.
-func NewUserHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, userServices ports.UserServices, rolesServices ports.RolesServices) *UserHandlers {
+func NewUserHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, userServices ports.UserServices, rolesServices ports.RolesServices, audit ports.AuditServices) *UserHandlers {
 	return &UserHandlers{
 		parser: parser,
 		http:   http,
 		user:   userServices,
 		roles:  rolesServices,
+		audit:  audit,
 	}
 }
 
@@ -28,69 +31,72 @@ func NewUserHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, userS
 func (h *UserHandlers) CreateUser(c *fiber.Ctx) error {
 	user := new(entities.User)
 	if err := h.parser.ParseData(c, user); err != nil {
-		return h.http.BadRequest(c, "Errore nella gestione dei dati")
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
 	}
 
 	//Validate user
 	if err := user.Validate(); err != nil {
-		return h.http.BadRequest(c, err.Error())
+		return h.http.ValidationFailed(c, i18n.MsgUserInvalid, err)
 	}
 
 	// Check if role exist
-	if _, err := h.roles.GetRole(user.RoleID); err != nil {
-		return h.http.BadRequest(c, "Il ruolo selezionato non esiste")
+	if _, err := h.roles.GetRole(c.UserContext(), user.RoleID); err != nil {
+		return h.http.BadRequest(c, i18n.MsgRoleNotExists)
 	}
 
 	// Check if is system role
-	if h.roles.IsSystemRole(user.RoleID) {
-		return h.http.BadRequest(c, "Non puoi creare un utente di sistema")
+	if h.roles.IsSystemRole(c.UserContext(), user.RoleID) {
+		return entities.NewForbiddenError(i18n.MsgSystemUserForbidden)
 	}
 
 	// Hash password
 	hashedPassword, err := h.user.EcnrypPassword(user.Password)
 	if err != nil {
-		return h.http.InternalServerError(c, "Error hashing password")
+		return h.http.InternalServerError(c, i18n.MsgPasswordHashError)
 	}
 	user.Password = hashedPassword
 
 	// Create user
-	if err := h.user.CreateUser(user); err != nil {
-		return h.http.InternalServerError(c, "Error creating user")
+	if err := h.user.CreateUser(c.UserContext(), user); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgUserCreateError)
 	}
 
 	user.RemovePassword()
-	return h.http.Success(c, []interface{}{user}, "User created")
+	h.audit.LogChange(c, entities.AuditCreate, "users", user.ID, nil, user)
+	return h.http.Success(c, []interface{}{user}, i18n.MsgUserCreated)
 }
 
 // Login handles the login process for a user.
 func (h *UserHandlers) Login(c *fiber.Ctx) error {
 	credentials := new(entities.UserLogin)
 	if err := h.parser.ParseData(c, credentials); err != nil {
-		return h.http.BadRequest(c, "Errore nella gestione dei dati")
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
 	}
 
 	//Validate user
 	if err := credentials.Validate(); err != nil {
-		return h.http.BadRequest(c, err.Error())
+		return h.http.ValidationFailed(c, i18n.MsgCredentialsInvalid, err)
 	}
 
 	//Search for user
-	user, err := h.user.GetUserByEmail(credentials.Email)
+	user, err := h.user.GetUserByEmail(c.UserContext(), credentials.Email)
 	if err != nil {
-		return h.http.Unauthorized(c, "Email non trovata")
+		h.user.LoginFailed(c, credentials.Email)
+		return h.http.Unauthorized(c, i18n.MsgEmailNotFound)
 	}
 
 	//Compare Password
-	if err := h.user.ComparePassword(user.ID, credentials.Password); err != nil {
-		return h.http.Unauthorized(c, "Password errata")
+	if err := h.user.ComparePassword(c.UserContext(), user.ID, credentials.Password); err != nil {
+		h.user.LoginFailed(c, credentials.Email)
+		return h.http.Unauthorized(c, i18n.MsgWrongPassword)
 	}
 
 	//Create Session
 	if err := h.user.SetSession(c, user); err != nil {
-		return h.http.InternalServerError(c, "Error creating session")
+		return h.http.InternalServerError(c, i18n.MsgSessionCreateError)
 	}
 
-	return h.http.Success(c, []interface{}{user}, "Login successful")
+	return h.http.Success(c, []interface{}{user}, i18n.MsgLoginSuccess)
 }
 
 // Logout handles the logout process for a user.
@@ -98,22 +104,22 @@ func (h *UserHandlers) Logout(c *fiber.Ctx) error {
 	user := utils.GetLocalUser(c)
 
 	if err := h.user.DeleteSession(c, user.ID); err != nil {
-		return h.http.InternalServerError(c, "Error deleting session")
+		return h.http.InternalServerError(c, i18n.MsgSessionDeleteError)
 	}
 
 	// Clear the cookie
 	c.Cookie(user.RemoveAuthCookie())
 
-	return h.http.Success(c, nil, "Logout successful")
+	return h.http.Success(c, nil, i18n.MsgLogoutSuccess)
 }
 
 // GetUsers handles the retrieval of all users.
 func (u *UserHandlers) GetUsers(c *fiber.Ctx) error {
-	users, err := u.user.GetAllUsers()
+	users, err := u.user.GetAllUsers(c.UserContext())
 	if err != nil {
-		return u.http.InternalServerError(c, err.Error())
+		return u.http.InternalServerError(c, i18n.MsgUsersFetchError)
 	}
-	return u.http.Success(c, users, "Utenti recuperati correttamente")
+	return u.http.Success(c, users, i18n.MsgUsersFetched)
 }
 
 // UpdateUser handles the update of a user.
@@ -125,8 +131,8 @@ func (u *UserHandlers) UpdateUser(c *fiber.Ctx) error {
 	// Check if user exists
 	user := new(entities.User)
 	user.ID = utils.GetUintParam(c, "id")
-	if err := u.user.GetUserById(user); err != nil {
-		return u.http.NotFound(c, "Utente non trovato")
+	if err := u.user.GetUserById(c.UserContext(), user); err != nil {
+		return u.http.NotFound(c, i18n.MsgUserNotFound)
 	}
 
 	// Check if user can update
@@ -137,23 +143,24 @@ func (u *UserHandlers) UpdateUser(c *fiber.Ctx) error {
 	// Parse data from request
 	newUser := new(entities.UpdateUser)
 	if err := u.parser.ParseData(c, newUser); err != nil {
-		return u.http.BadRequest(c, err.Error())
+		return err
 	}
 
 	// Check if role exist
 	if newUser.RoleID != 0 {
-		if _, err := u.roles.GetRole(newUser.RoleID); err != nil {
-			return u.http.BadRequest(c, "Il ruolo selezionato non esiste")
+		if _, err := u.roles.GetRole(c.UserContext(), newUser.RoleID); err != nil {
+			return u.http.BadRequest(c, i18n.MsgRoleNotExists)
 		}
 	}
 
 	// update user
-	user, err := u.user.UpdateUser(user.ID, newUser)
+	updatedUser, err := u.user.UpdateUser(c.UserContext(), user.ID, newUser)
 	if err != nil {
-		return u.http.InternalServerError(c, err.Error())
+		return u.http.InternalServerError(c, i18n.MsgUserUpdateError)
 	}
 
-	return u.http.Success(c, []interface{}{user}, "User updated")
+	u.audit.LogChange(c, entities.AuditUpdate, "users", user.ID, user, updatedUser)
+	return u.http.Success(c, []interface{}{updatedUser}, i18n.MsgUserUpdated)
 }
 
 // DeleteUser handles the deletion of a user.
@@ -171,19 +178,15 @@ func (u *UserHandlers) DeleteUser(c *fiber.Ctx) error {
 	}
 
 	// Get user
-	if err := u.user.GetUserById(user); err != nil {
-		return u.http.NotFound(c, "Utente non trovato")
+	if err := u.user.GetUserById(c.UserContext(), user); err != nil {
+		return u.http.NotFound(c, i18n.MsgUserNotFound)
 	}
 
 	// Delete user
-	if err := u.user.DeleteUser(user); err != nil {
-		return u.http.InternalServerError(c, err.Error())
+	if err := u.user.DeleteUser(c.UserContext(), user); err != nil {
+		return u.http.InternalServerError(c, i18n.MsgUserDeleteError)
 	}
 
-	// Remove session
-	if err := u.user.DeleteAllSessions(c, user.ID); err != nil {
-		return u.http.InternalServerError(c, err.Error())
-	}
-
-	return u.http.Success(c, nil, "Utente eliminato!")
+	u.audit.LogChange(c, entities.AuditDelete, "users", user.ID, user, nil)
+	return u.http.Success(c, nil, i18n.MsgUserDeleted)
 }
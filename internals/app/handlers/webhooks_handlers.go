@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type WebhooksHandlers struct {
+	parser          ports.ParserAdapters
+	http            ports.HttpAdapters
+	webhookServices ports.WebhookServices
+	audit           ports.AuditServices
+}
+
+func NewWebhooksHandlers(parser ports.ParserAdapters, http ports.HttpAdapters, services ports.WebhookServices, audit ports.AuditServices) *WebhooksHandlers {
+	return &WebhooksHandlers{
+		parser:          parser,
+		http:            http,
+		webhookServices: services,
+		audit:           audit,
+	}
+}
+
+// CreateWebhook handles the registration of a new webhook.
+func (h *WebhooksHandlers) CreateWebhook(c *fiber.Ctx) error {
+	body := new(entities.NewWebhook)
+	if err := h.parser.ParseData(c, body); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+	webhook := &body.Webhook
+	webhook.Secret = body.Secret
+
+	// Validate webhook
+	if err := webhook.Validate(); err != nil {
+		return err
+	}
+
+	// Create webhook
+	if err := h.webhookServices.CreateWebhook(c.UserContext(), webhook); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgWebhookCreateError)
+	}
+
+	h.audit.LogChange(c, entities.AuditCreate, "webhooks", webhook.ID, nil, webhook)
+
+	// The secret is returned once, to verify the signatures
+	body.Secret = webhook.Secret
+	return h.http.Success(c, []interface{}{body}, i18n.MsgWebhookCreated)
+}
+
+// GetWebhooks retrieves all webhooks from the database.
+func (h *WebhooksHandlers) GetWebhooks(c *fiber.Ctx) error {
+	webhooks, err := h.webhookServices.GetAllWebhooks(c.UserContext())
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgWebhooksFetchError)
+	}
+
+	return h.http.Success(c, webhooks, i18n.MsgWebhooksFetched)
+}
+
+// GetWebhookById retrieves a webhook by its ID from the database.
+func (h *WebhooksHandlers) GetWebhookById(c *fiber.Ctx) error {
+	// Get webhook from fiber locals
+	webhook := utils.GetLocalWebhook(c)
+
+	return h.http.Success(c, []interface{}{webhook}, i18n.MsgWebhookFetched)
+}
+
+// UpdateWebhook updates a webhook in the database.
+func (h *WebhooksHandlers) UpdateWebhook(c *fiber.Ctx) error {
+	updatedWebhook := new(entities.UpdateWebhook)
+	if err := h.parser.ParseData(c, updatedWebhook); err != nil {
+		return h.http.BadRequest(c, i18n.MsgDataInvalid)
+	}
+
+	// Validate webhook
+	if err := updatedWebhook.Validate(); err != nil {
+		return err
+	}
+
+	// Get webhook from fiber locals
+	webhook := utils.GetLocalWebhook(c)
+
+	// Update webhook
+	updated, err := h.webhookServices.UpdateWebhook(c.UserContext(), webhook.ID, updatedWebhook)
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgWebhookUpdateError)
+	}
+
+	h.audit.LogChange(c, entities.AuditUpdate, "webhooks", webhook.ID, webhook, updated)
+	return h.http.Success(c, []interface{}{updated}, i18n.MsgWebhookUpdated)
+}
+
+// DeleteWebhook deletes a webhook from the database.
+func (h *WebhooksHandlers) DeleteWebhook(c *fiber.Ctx) error {
+	// Get webhook from fiber locals
+	webhook := utils.GetLocalWebhook(c)
+
+	// Delete webhook
+	if err := h.webhookServices.DeleteWebhook(c.UserContext(), webhook.ID); err != nil {
+		return h.http.InternalServerError(c, i18n.MsgWebhookDeleteError)
+	}
+
+	h.audit.LogChange(c, entities.AuditDelete, "webhooks", webhook.ID, webhook, nil)
+	return h.http.Success(c, nil, i18n.MsgWebhookDeleted)
+}
+
+// GetWebhookDeliveries retrieves the delivery log of a webhook.
+func (h *WebhooksHandlers) GetWebhookDeliveries(c *fiber.Ctx) error {
+	// Get webhook from fiber locals
+	webhook := utils.GetLocalWebhook(c)
+
+	deliveries, err := h.webhookServices.GetWebhookDeliveries(c.UserContext(), webhook.ID, c.Query("status"))
+	if err != nil {
+		return h.http.InternalServerError(c, i18n.MsgDeliveriesFetchError)
+	}
+
+	return h.http.Success(c, deliveries, i18n.MsgDeliveriesFetched)
+}
+
+// RetryWebhookDelivery queues a delivery of a webhook again.
+func (h *WebhooksHandlers) RetryWebhookDelivery(c *fiber.Ctx) error {
+	deliveryID := utils.GetUintParam(c, "delivery_id")
+
+	if deliveryID == 0 {
+		return h.http.BadRequest(c, i18n.MsgDeliveryIDRequired)
+	}
+
+	// Get webhook from fiber locals
+	webhook := utils.GetLocalWebhook(c)
+
+	// Retry delivery
+	delivery, err := h.webhookServices.RetryDelivery(c.UserContext(), webhook.ID, deliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return h.http.NotFound(c, i18n.MsgDeliveryNotFound)
+		}
+		return err
+	}
+
+	return h.http.Success(c, []interface{}{delivery}, i18n.MsgDeliveryScheduled)
+}
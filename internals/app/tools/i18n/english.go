@@ -0,0 +1,316 @@
+package i18n
+
+// English messages
+var english = map[string]string{
+	// Generic
+	MsgUnauthorized:        "Unauthorized, please login first",
+	MsgForbidden:           "Forbidden, you don't have permission to access this resource",
+	MsgTooManyRequests:     "Too many requests, try again later",
+	MsgBadRequest:          "Bad request",
+	MsgResourceNotFound:    "Resource not found",
+	MsgMethodNotAllowed:    "Method not allowed",
+	MsgInternalError:       "An internal error occurred, try again later",
+	MsgDataInvalid:         "Error handling the request data",
+	MsgStartDateFormat:     "The start date must be in the format YYYY-MM-DD",
+	MsgEndDateFormat:       "The end date must be in the format YYYY-MM-DD",
+	MsgEndDateBeforeStart:  "The end date must be after the start date",
+	MsgStartMonthFormat:    "The start month must be in the format YYYY-MM",
+	MsgEndMonthFormat:      "The end month must be in the format YYYY-MM",
+	MsgDaysPositive:        "The number of days must be positive",
+	MsgActiveFilterInvalid: "The active filter must be true or false",
+
+	// Users and sessions
+	MsgLoginSuccess:        "Login successful",
+	MsgLogoutSuccess:       "Logout successful",
+	MsgEmailNotFound:       "Email not found",
+	MsgWrongPassword:       "Wrong password",
+	MsgCredentialsInvalid:  "Invalid credentials",
+	MsgSessionCreateError:  "Error creating session",
+	MsgSessionDeleteError:  "Error deleting session",
+	MsgUserInvalid:         "Invalid user",
+	MsgUserNotFound:        "User not found",
+	MsgSystemUserForbidden: "You can't create a system user",
+	MsgPasswordHashError:   "Error hashing password",
+	MsgUserCreateError:     "Error creating user",
+	MsgUserCreated:         "User created!",
+	MsgUsersFetched:        "Users retrieved",
+	MsgUsersFetchError:     "Error retrieving users",
+	MsgUserUpdated:         "User updated",
+	MsgUserUpdateError:     "Error updating user",
+	MsgUserDeleted:         "User deleted!",
+	MsgUserDeleteError:     "Error deleting user",
+	MsgRoleNotExists:       "The selected role doesn't exist",
+
+	// Roles and permissions
+	MsgRoleIDRequired:        "Specify the id of the role",
+	MsgRoleInvalid:           "Invalid role",
+	MsgRoleNotFound:          "Role not found",
+	MsgRolesNotFound:         "Roles not found",
+	MsgRolesFetched:          "Roles retrieved",
+	MsgRolesFetchError:       "Error retrieving roles",
+	MsgRoleFetched:           "Role retrieved",
+	MsgRoleCreated:           "Role created!",
+	MsgRoleCreateError:       "Error creating role",
+	MsgRoleUpdated:           "Role updated",
+	MsgRoleDeleted:           "Role deleted",
+	MsgPermissionIDRequired:  "Specify the id of the permission",
+	MsgPermissionInvalid:     "Invalid permission",
+	MsgPermissionNotFound:    "Permission not found",
+	MsgPermissionsNotFound:   "Permissions not found",
+	MsgPermissionFetched:     "Permission retrieved",
+	MsgPermissionsFetched:    "Permissions retrieved",
+	MsgPermissionCheckError:  "Error checking the permission",
+	MsgPermissionCreated:     "Permission created",
+	MsgPermissionCreateError: "Error creating permission",
+	MsgPermissionUpdated:     "Permission updated",
+	MsgPermissionUpdateError: "Error updating permission",
+	MsgPermissionDeleted:     "Permission deleted",
+
+	// Members
+	MsgMemberIDRequired:         "Specify the id of the member",
+	MsgMemberDeleteIDRequired:   "Specify the id of the member to remove",
+	MsgMemberRestoreIDRequired:  "Specify the id of the member to restore",
+	MsgMemberInvalid:            "Invalid member",
+	MsgMemberNotFound:           "Member not found",
+	MsgMemberContactsNotFound:   "Contacts of the member not found",
+	MsgMemberCreated:            "Member added!",
+	MsgMemberCreateError:        "Error creating member",
+	MsgMemberFetched:            "Member retrieved",
+	MsgMemberFetchError:         "Error retrieving member",
+	MsgMembersFetched:           "Members retrieved",
+	MsgMembersFetchError:        "Error retrieving members",
+	MsgMemberUpdated:            "Member updated",
+	MsgMemberUpdateError:        "Error updating member",
+	MsgMemberDeleted:            "Member deleted",
+	MsgMemberDeleteError:        "Error deleting member",
+	MsgDeletedMembersFetched:    "Deleted members retrieved",
+	MsgDeletedMembersFetchError: "Error retrieving deleted members",
+	MsgDeletedMemberNotFound:    "Deleted member not found",
+	MsgMemberRestored:           "Member restored",
+	MsgMemberRestoreError:       "Error restoring member",
+
+	// Subscriptions
+	MsgSubscriptionInvalid:            "Invalid subscription",
+	MsgRenewalInvalid:                 "Invalid renewal",
+	MsgSubscriptionNotFound:           "Subscription not found",
+	MsgSubscriptionCreated:            "Subscription created",
+	MsgSubscriptionCreateError:        "Error creating subscription",
+	MsgSubscriptionRenewed:            "Subscription renewed",
+	MsgSubscriptionsFetched:           "Subscriptions retrieved",
+	MsgSubscriptionFetched:            "Subscription retrieved",
+	MsgSubscriptionFetchError:         "Error retrieving subscription",
+	MsgSubscriptionUpdated:            "Subscription updated",
+	MsgSubscriptionDeleteIDRequired:   "Specify the id of the subscription to delete",
+	MsgSubscriptionRestoreIDRequired:  "Specify the id of the subscription to restore",
+	MsgSubscriptionDeleted:            "Subscription deleted",
+	MsgDeletedSubscriptionsFetched:    "Deleted subscriptions retrieved",
+	MsgDeletedSubscriptionsFetchError: "Error retrieving deleted subscriptions",
+	MsgDeletedSubscriptionNotFound:    "Deleted subscription not found",
+	MsgSubscriptionRestored:           "Subscription restored",
+	MsgSubscriptionRestoreError:       "Error restoring subscription",
+
+	// Export and import
+	MsgMemberExportError:    "Error exporting the member data",
+	MsgMemberDataExported:   "Member data exported",
+	MsgMemberDataAnonymized: "Member data anonymized",
+	MsgExportFormatInvalid:  "The format must be json or zip",
+	MsgImportFileRequired:   "Upload the file to import",
+	MsgImportFileReadError:  "Error reading the file",
+	MsgImportColumnsInvalid: "The column mapping is not valid",
+	MsgImportChecked:        "File check completed",
+	MsgImportRejected:       "Import cancelled, fix the rows with errors",
+	MsgMembersImported:      "Members imported!",
+
+	// Consents
+	MsgConsentIDRequired:  "Specify the id of the consent",
+	MsgConsentNotFound:    "Consent not found",
+	MsgConsentsFetched:    "Consents retrieved",
+	MsgConsentsFetchError: "Error retrieving consents",
+	MsgConsentCreated:     "Consent recorded",
+	MsgConsentRevoked:     "Consent revoked",
+
+	// Reports and audits
+	MsgReportFetched:      "Report retrieved",
+	MsgReportFetchError:   "Error retrieving report",
+	MsgReportComputed:     "Report computed",
+	MsgReportComputeError: "Error computing report",
+	MsgAuditsFetched:      "Changes retrieved",
+	MsgAuditsFetchError:   "Error retrieving changes",
+
+	// Promotions
+	MsgPromotionIDRequired:   "Specify the id of the promotion",
+	MsgPromotionNotFound:     "Promotion not found",
+	MsgPromotionsFetched:     "Promotions retrieved",
+	MsgPromotionsFetchError:  "Error retrieving promotions",
+	MsgPromotionFetched:      "Promotion retrieved",
+	MsgPromotionCreated:      "Promotion created!",
+	MsgPromotionCreateError:  "Error creating promotion",
+	MsgPromotionUpdated:      "Promotion updated",
+	MsgPromotionUpdateError:  "Error updating promotion",
+	MsgPromotionDeleted:      "Promotion deleted",
+	MsgPromotionDeleteError:  "Error deleting promotion",
+	MsgRedemptionsFetched:    "Redemptions retrieved",
+	MsgRedemptionsFetchError: "Error retrieving redemptions",
+
+	// Guests
+	MsgGuestNotFound:    "Guest not found",
+	MsgGuestCreated:     "Guest added!",
+	MsgGuestsFetched:    "Guests retrieved",
+	MsgGuestsFetchError: "Error retrieving guests",
+	MsgGuestFetched:     "Guest retrieved",
+	MsgGuestUpdated:     "Guest updated",
+	MsgGuestDeleted:     "Guest deleted",
+	MsgGuestDeleteError: "Error deleting guest",
+	MsgGuestEnrolled:    "Guest enrolled!",
+	MsgPassIDRequired:   "Specify the id of the pass",
+	MsgPassCreated:      "Pass created",
+	MsgPassCreateError:  "Error creating pass",
+	MsgPassesFetched:    "Passes retrieved",
+	MsgPassesFetchError: "Error retrieving passes",
+	MsgVisitRecorded:    "Visit recorded",
+
+	// Households
+	MsgHouseholdNotFound:      "Household not found",
+	MsgHouseholdCreated:       "Household created!",
+	MsgHouseholdsFetched:      "Households retrieved",
+	MsgHouseholdsFetchError:   "Error retrieving households",
+	MsgHouseholdFetched:       "Household retrieved",
+	MsgHouseholdUpdated:       "Household updated",
+	MsgHouseholdDeleted:       "Household deleted",
+	MsgHouseholdDeleteError:   "Error deleting household",
+	MsgHouseholdMemberAdded:   "Member added to the household",
+	MsgHouseholdMemberRemoved: "Member removed from the household",
+
+	// Notifications
+	MsgNotificationsFetched:    "Notifications retrieved",
+	MsgNotificationsFetchError: "Error retrieving notifications",
+	MsgPreferencesUpdated:      "Preferences updated",
+	MsgPreferencesUpdateError:  "Error updating preferences",
+
+	// Webhooks
+	MsgWebhookNotFound:      "Webhook not found",
+	MsgWebhookCreated:       "Webhook created!",
+	MsgWebhookCreateError:   "Error creating webhook",
+	MsgWebhooksFetched:      "Webhooks retrieved",
+	MsgWebhooksFetchError:   "Error retrieving webhooks",
+	MsgWebhookFetched:       "Webhook retrieved",
+	MsgWebhookUpdated:       "Webhook updated",
+	MsgWebhookUpdateError:   "Error updating webhook",
+	MsgWebhookDeleted:       "Webhook deleted",
+	MsgWebhookDeleteError:   "Error deleting webhook",
+	MsgDeliveryIDRequired:   "Specify the id of the delivery",
+	MsgDeliveryNotFound:     "Delivery not found",
+	MsgDeliveriesFetched:    "Deliveries retrieved",
+	MsgDeliveriesFetchError: "Error retrieving deliveries",
+	MsgDeliveryScheduled:    "Delivery scheduled",
+
+	// Validation of the fields
+	MsgNameRequired:               "the name is required",
+	MsgSurnameRequired:            "the surname is required",
+	MsgGenderRequired:             "the gender is required",
+	MsgDateOfBirthRequired:        "the date of birth is required",
+	MsgContactsRequired:           "the contacts are required",
+	MsgAddressRequired:            "the address is required",
+	MsgSubscriptionRequired:       "the subscription is required",
+	MsgPhoneRequired:              "the phone number is required",
+	MsgCountryRequired:            "the country is required",
+	MsgCityRequired:               "the city is required",
+	MsgStreetRequired:             "the street is required",
+	MsgOverlapPolicyInvalid:       "the overlap policy must be reject or queue",
+	MsgSubscriptionTypeRequired:   "the subscription type is required",
+	MsgSubscriptionTypeInvalid:    "the subscription type must be mensile, trimestrale, semestrale, annuale or custom",
+	MsgSubscriptionStartRequired:  "the subscription start date is required",
+	MsgSubscriptionPriceRequired:  "the subscription price is required",
+	MsgSubscriptionPriceNegative:  "the subscription price can't be negative",
+	MsgSubscriptionActiveRequired: "specify whether the subscription is active",
+	MsgSubscriptionEndRequired:    "the subscription end date is required",
+	MsgSubscriptionEndBeforeStart: "the subscription end date must be after the start date",
+	MsgSubscriptionOverlaps:       "the subscription overlaps subscription %d",
+	MsgSubscriptionOverlapsPeriod: "the subscription overlaps subscription %d (from %s to %s)",
+	MsgSubscriptionExpired:        "an active subscription can't be already expired",
+	MsgEmailRequired:              "the email is required",
+	MsgEmailInvalid:               "the email is not valid",
+	MsgPasswordRequired:           "the password is required",
+	MsgRoleRequired:               "the role is required",
+	MsgRoleNameRequired:           "the role name is required",
+	MsgTableRequired:              "the table is required",
+	MsgCreateLevelInvalid:         "the create permission must be 0 or 1",
+	MsgReadLevelInvalid:           "the read permission must be 0, 1 or 2",
+	MsgUpdateLevelInvalid:         "the update permission must be 0, 1 or 2",
+	MsgDeleteLevelInvalid:         "the delete permission must be 0, 1 or 2",
+	MsgPermissionDuplicate:        "the permissions for this table and role already exist",
+	MsgPermissionRoleNotExists:    "the role doesn't exist",
+	MsgPermissionTableNotExists:   "the table doesn't exist",
+
+	// Promotions
+	MsgPromotionCodeNotExists:           "the promotion code doesn't exist",
+	MsgPromotionCodeExpired:             "the promotion code is not valid on this date",
+	MsgPromotionCodeExhausted:           "the promotion code reached its usage limit",
+	MsgPromotionCodeNotEligible:         "the promotion code is not valid for this subscription type",
+	MsgPromotionCodeFirstTimeOnly:       "the promotion code is reserved to new members",
+	MsgRequiredFields:                   "fill in the required fields",
+	MsgPromotionTypeInvalid:             "the promotion type must be percentage or fixed",
+	MsgPromotionValueInvalid:            "the promotion value is not valid",
+	MsgPromotionEndBeforeStart:          "the promotion end date must be after the start date",
+	MsgPromotionSubscriptionTypeInvalid: "the subscription type %s is not valid",
+
+	// Households
+	MsgHouseholdDiscountInvalid: "the discount must be between 0 and 100",
+	MsgHouseholdMemberRequired:  "specify the member to add",
+	MsgPayerInvalid:             "the payer is not valid",
+	MsgPayerInHousehold:         "the payer already belongs to a household",
+	MsgPayerMinor:               "the payer must be an adult",
+	MsgPayerNotInHousehold:      "the payer must belong to the household",
+	MsgHouseholdMemberInvalid:   "the member is not valid",
+	MsgMemberInHousehold:        "the member already belongs to a household",
+	MsgGuardianConsentRequired:  "the guardian consent is required for minors",
+	MsgPayerRemoval:             "the payer can't be removed from the household",
+	MsgHouseholdInvalid:         "the household is not valid",
+	MsgGuardianNotInHousehold:   "the guardian must belong to the household",
+	MsgGuardianMinor:            "the guardian must be an adult",
+
+	// Guests
+	MsgGuestContactRequired:  "enter at least one contact",
+	MsgPassFieldsInvalid:     "fill in the pass fields correctly",
+	MsgPassPunchCardRequired: "specify the number of visits and the expiry date",
+	MsgPassExpiryBeforeStart: "the expiry date must be after the start date",
+	MsgPassTypeInvalid:       "the pass type must be giornaliero or carnet",
+	MsgPassUnusable:          "the pass is not valid or has no visits left",
+	MsgGuestAlreadyEnrolled:  "the guest has already been enrolled",
+	MsgReferrerInvalid:       "the referring member is not valid",
+
+	// Privacy
+	MsgMemberAlreadyAnonymized: "the member data has already been anonymized",
+	MsgConsentAlreadyGiven:     "the %s consent has already been given for version %s",
+	MsgConsentAlreadyRevoked:   "the consent has already been revoked",
+	MsgConsentTypeInvalid:      "the consent type must be privacy, marketing or foto",
+	MsgPolicyVersionRequired:   "specify the policy version",
+	MsgConsentDateFuture:       "the consent date can't be in the future",
+
+	// Subscriptions, reports and exports
+	MsgSubscriptionAlreadyRenewed: "the subscription has already been renewed",
+	MsgReportMonthsOrder:          "the end month must be after the start month",
+	MsgReportPeriodTooLong:        "the period can't exceed %d months",
+	MsgExportFileFormatInvalid:    "the format must be csv, xlsx or pdf",
+
+	// Webhooks and notifications
+	MsgWebhookURLInvalid:        "the webhook address must be an http or https url",
+	MsgWebhookEventsRequired:    "specify at least one event",
+	MsgWebhookEventUnknown:      "the event %s doesn't exist",
+	MsgDeliveryAlreadyDelivered: "the event has already been delivered",
+	MsgPreferencesRequired:      "specify at least one preference",
+
+	// Import
+	MsgImportEmpty:          "the file contains no members",
+	MsgImportFieldInvalid:   "the field %s can't be imported",
+	MsgImportColumnMissing:  "the column %s is not in the file",
+	MsgImportDateInvalid:    "the date %s is not valid",
+	MsgImportPriceInvalid:   "the price %s is not valid",
+	MsgImportPhoneArchived:  "phone already in the archive",
+	MsgImportPhoneDuplicate: "phone already in row %d",
+	MsgImportEmailArchived:  "email already in the archive",
+	MsgImportEmailDuplicate: "email already in row %d",
+	MsgTableFormatInvalid:   "the file must be csv or xlsx",
+	MsgTableCSVInvalid:      "the csv file is not valid",
+	MsgTableXLSXInvalid:     "the xlsx file is not valid",
+}
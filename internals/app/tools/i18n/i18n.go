@@ -0,0 +1,92 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Languages of the messages
+const (
+	Italian = "it"
+	English = "en"
+
+	// DefaultLanguage is used when the client doesn't ask for a supported language
+	DefaultLanguage = Italian
+)
+
+// Languages lists the supported languages, in order of preference.
+var Languages = []string{Italian, English}
+
+// Localizable is implemented by the data and errors holding translatable messages.
+type Localizable interface {
+	// Localize translates the messages in the given language.
+	Localize(lang string)
+}
+
+// Translate returns the message of a code in the given language, formatted with args.
+//
+// Messages missing in the language fall back to the default language, unknown
+// codes are returned as they are.
+func Translate(lang string, code string, args ...interface{}) string {
+	message, ok := catalog[lang][code]
+	if !ok {
+		message, ok = catalog[DefaultLanguage][code]
+	}
+	if !ok {
+		return code
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Negotiate returns the supported language preferred by an Accept-Language
+// header, e.g. "en-US,en;q=0.9,it;q=0.8".
+func Negotiate(acceptLanguage string) string {
+	type preference struct {
+		lang    string
+		quality float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !supported(lang) {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			preferences = append(preferences, preference{lang, quality})
+		}
+	}
+
+	if len(preferences) == 0 {
+		return DefaultLanguage
+	}
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+	return preferences[0].lang
+}
+
+func supported(lang string) bool {
+	for _, l := range Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
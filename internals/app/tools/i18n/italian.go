@@ -0,0 +1,316 @@
+package i18n
+
+// Italian messages
+var italian = map[string]string{
+	// Generic
+	MsgUnauthorized:        "Non autorizzato, effettuare l'accesso",
+	MsgForbidden:           "Non hai i permessi per accedere a questa risorsa",
+	MsgTooManyRequests:     "Troppe richieste, riprovare più tardi",
+	MsgBadRequest:          "Richiesta non valida",
+	MsgResourceNotFound:    "Risorsa non trovata",
+	MsgMethodNotAllowed:    "Metodo non consentito",
+	MsgInternalError:       "Si è verificato un errore interno, riprovare più tardi",
+	MsgDataInvalid:         "Errore nella gestione dei dati",
+	MsgStartDateFormat:     "La data di inizio deve essere nel formato AAAA-MM-GG",
+	MsgEndDateFormat:       "La data di fine deve essere nel formato AAAA-MM-GG",
+	MsgEndDateBeforeStart:  "La data di fine deve essere successiva alla data di inizio",
+	MsgStartMonthFormat:    "Il mese di inizio deve essere nel formato AAAA-MM",
+	MsgEndMonthFormat:      "Il mese di fine deve essere nel formato AAAA-MM",
+	MsgDaysPositive:        "Il numero di giorni deve essere positivo",
+	MsgActiveFilterInvalid: "Il filtro active deve essere true o false",
+
+	// Users and sessions
+	MsgLoginSuccess:        "Accesso effettuato",
+	MsgLogoutSuccess:       "Disconnessione effettuata",
+	MsgEmailNotFound:       "Email non trovata",
+	MsgWrongPassword:       "Password errata",
+	MsgCredentialsInvalid:  "Credenziali non valide",
+	MsgSessionCreateError:  "Errore nel creare la sessione",
+	MsgSessionDeleteError:  "Errore nell'eliminare la sessione",
+	MsgUserInvalid:         "Utente non valido",
+	MsgUserNotFound:        "Utente non trovato",
+	MsgSystemUserForbidden: "Non puoi creare un utente di sistema",
+	MsgPasswordHashError:   "Errore nel cifrare la password",
+	MsgUserCreateError:     "Errore nel creare l'utente",
+	MsgUserCreated:         "Utente creato!",
+	MsgUsersFetched:        "Utenti recuperati correttamente",
+	MsgUsersFetchError:     "Errore nel recuperare gli utenti",
+	MsgUserUpdated:         "Utente aggiornato",
+	MsgUserUpdateError:     "Errore nell'aggiornare l'utente",
+	MsgUserDeleted:         "Utente eliminato!",
+	MsgUserDeleteError:     "Errore nell'eliminare l'utente",
+	MsgRoleNotExists:       "Il ruolo selezionato non esiste",
+
+	// Roles and permissions
+	MsgRoleIDRequired:        "Specificare l'id del ruolo",
+	MsgRoleInvalid:           "Ruolo non valido",
+	MsgRoleNotFound:          "Ruolo non trovato",
+	MsgRolesNotFound:         "Ruoli non trovati",
+	MsgRolesFetched:          "Ruoli recuperati",
+	MsgRolesFetchError:       "Errore nel recuperare i ruoli",
+	MsgRoleFetched:           "Ruolo recuperato",
+	MsgRoleCreated:           "Ruolo creato!",
+	MsgRoleCreateError:       "Errore nel creare il ruolo",
+	MsgRoleUpdated:           "Ruolo aggiornato",
+	MsgRoleDeleted:           "Ruolo eliminato",
+	MsgPermissionIDRequired:  "Specificare l'id del permesso",
+	MsgPermissionInvalid:     "Permesso non valido",
+	MsgPermissionNotFound:    "Permesso non trovato",
+	MsgPermissionsNotFound:   "Permessi non trovati",
+	MsgPermissionFetched:     "Permesso recuperato",
+	MsgPermissionsFetched:    "Permessi recuperati",
+	MsgPermissionCheckError:  "Errore nel controllo del permesso",
+	MsgPermissionCreated:     "Permesso creato",
+	MsgPermissionCreateError: "Errore nel creare il permesso",
+	MsgPermissionUpdated:     "Permesso aggiornato",
+	MsgPermissionUpdateError: "Errore nell'aggiornare il permesso",
+	MsgPermissionDeleted:     "Permesso eliminato",
+
+	// Members
+	MsgMemberIDRequired:         "Specificare l'id del membro",
+	MsgMemberDeleteIDRequired:   "Specificare l'id del membro da rimuovere",
+	MsgMemberRestoreIDRequired:  "Specificare l'id del membro da ripristinare",
+	MsgMemberInvalid:            "Membro non valido",
+	MsgMemberNotFound:           "Membro non trovato",
+	MsgMemberContactsNotFound:   "Contatti del membro non trovati",
+	MsgMemberCreated:            "Membro aggiunto!",
+	MsgMemberCreateError:        "Errore nel creare il membro",
+	MsgMemberFetched:            "Membro recuperato",
+	MsgMemberFetchError:         "Errore nel recuperare il membro",
+	MsgMembersFetched:           "Membri recuperati",
+	MsgMembersFetchError:        "Errore nel recuperare i membri",
+	MsgMemberUpdated:            "Membro aggiornato",
+	MsgMemberUpdateError:        "Errore nell'aggiornare il membro",
+	MsgMemberDeleted:            "Membro eliminato",
+	MsgMemberDeleteError:        "Errore nell'eliminare il membro",
+	MsgDeletedMembersFetched:    "Membri eliminati recuperati",
+	MsgDeletedMembersFetchError: "Errore nel recuperare i membri eliminati",
+	MsgDeletedMemberNotFound:    "Membro eliminato non trovato",
+	MsgMemberRestored:           "Membro ripristinato",
+	MsgMemberRestoreError:       "Errore nel ripristinare il membro",
+
+	// Subscriptions
+	MsgSubscriptionInvalid:            "Abbonamento non valido",
+	MsgRenewalInvalid:                 "Rinnovo non valido",
+	MsgSubscriptionNotFound:           "Iscrizione non trovata",
+	MsgSubscriptionCreated:            "Iscrizione creata",
+	MsgSubscriptionCreateError:        "Errore nel creare l'iscrizione",
+	MsgSubscriptionRenewed:            "Iscrizione rinnovata",
+	MsgSubscriptionsFetched:           "Iscrizioni recuperate",
+	MsgSubscriptionFetched:            "Iscrizione recuperata",
+	MsgSubscriptionFetchError:         "Errore nel recuperare l'iscrizione",
+	MsgSubscriptionUpdated:            "Iscrizione aggiornata",
+	MsgSubscriptionDeleteIDRequired:   "Specificare l'id dell'iscrizione da eliminare",
+	MsgSubscriptionRestoreIDRequired:  "Specificare l'id dell'iscrizione da ripristinare",
+	MsgSubscriptionDeleted:            "Iscrizione eliminata",
+	MsgDeletedSubscriptionsFetched:    "Iscrizioni eliminate recuperate",
+	MsgDeletedSubscriptionsFetchError: "Errore nel recuperare le iscrizioni eliminate",
+	MsgDeletedSubscriptionNotFound:    "Iscrizione eliminata non trovata",
+	MsgSubscriptionRestored:           "Iscrizione ripristinata",
+	MsgSubscriptionRestoreError:       "Errore nel ripristinare l'iscrizione",
+
+	// Export and import
+	MsgMemberExportError:    "Errore nell'esportare i dati del membro",
+	MsgMemberDataExported:   "Dati del membro esportati",
+	MsgMemberDataAnonymized: "Dati del membro anonimizzati",
+	MsgExportFormatInvalid:  "Il formato deve essere json o zip",
+	MsgImportFileRequired:   "Caricare il file da importare",
+	MsgImportFileReadError:  "Errore nella lettura del file",
+	MsgImportColumnsInvalid: "La mappatura delle colonne non è valida",
+	MsgImportChecked:        "Verifica del file completata",
+	MsgImportRejected:       "Importazione annullata, correggere le righe con errori",
+	MsgMembersImported:      "Membri importati!",
+
+	// Consents
+	MsgConsentIDRequired:  "Specificare l'id del consenso",
+	MsgConsentNotFound:    "Consenso non trovato",
+	MsgConsentsFetched:    "Consensi recuperati",
+	MsgConsentsFetchError: "Errore nel recuperare i consensi",
+	MsgConsentCreated:     "Consenso registrato",
+	MsgConsentRevoked:     "Consenso revocato",
+
+	// Reports and audits
+	MsgReportFetched:      "Report recuperato",
+	MsgReportFetchError:   "Errore nel recuperare il report",
+	MsgReportComputed:     "Report calcolato",
+	MsgReportComputeError: "Errore nel calcolare il report",
+	MsgAuditsFetched:      "Modifiche recuperate",
+	MsgAuditsFetchError:   "Errore nel recuperare le modifiche",
+
+	// Promotions
+	MsgPromotionIDRequired:   "Specificare l'id della promozione",
+	MsgPromotionNotFound:     "Promozione non trovata",
+	MsgPromotionsFetched:     "Promozioni recuperate",
+	MsgPromotionsFetchError:  "Errore nel recuperare le promozioni",
+	MsgPromotionFetched:      "Promozione recuperata",
+	MsgPromotionCreated:      "Promozione creata!",
+	MsgPromotionCreateError:  "Errore nel creare la promozione",
+	MsgPromotionUpdated:      "Promozione aggiornata",
+	MsgPromotionUpdateError:  "Errore nell'aggiornare la promozione",
+	MsgPromotionDeleted:      "Promozione eliminata",
+	MsgPromotionDeleteError:  "Errore nell'eliminare la promozione",
+	MsgRedemptionsFetched:    "Utilizzi recuperati",
+	MsgRedemptionsFetchError: "Errore nel recuperare gli utilizzi",
+
+	// Guests
+	MsgGuestNotFound:    "Ospite non trovato",
+	MsgGuestCreated:     "Ospite aggiunto!",
+	MsgGuestsFetched:    "Ospiti recuperati",
+	MsgGuestsFetchError: "Errore nel recuperare gli ospiti",
+	MsgGuestFetched:     "Ospite recuperato",
+	MsgGuestUpdated:     "Ospite aggiornato",
+	MsgGuestDeleted:     "Ospite eliminato",
+	MsgGuestDeleteError: "Errore nell'eliminare l'ospite",
+	MsgGuestEnrolled:    "Ospite iscritto!",
+	MsgPassIDRequired:   "Specificare l'id dell'ingresso",
+	MsgPassCreated:      "Ingresso creato",
+	MsgPassCreateError:  "Errore nel creare l'ingresso",
+	MsgPassesFetched:    "Ingressi recuperati",
+	MsgPassesFetchError: "Errore nel recuperare gli ingressi",
+	MsgVisitRecorded:    "Ingresso registrato",
+
+	// Households
+	MsgHouseholdNotFound:      "Nucleo familiare non trovato",
+	MsgHouseholdCreated:       "Nucleo familiare creato!",
+	MsgHouseholdsFetched:      "Nuclei familiari recuperati",
+	MsgHouseholdsFetchError:   "Errore nel recuperare i nuclei familiari",
+	MsgHouseholdFetched:       "Nucleo familiare recuperato",
+	MsgHouseholdUpdated:       "Nucleo familiare aggiornato",
+	MsgHouseholdDeleted:       "Nucleo familiare eliminato",
+	MsgHouseholdDeleteError:   "Errore nell'eliminare il nucleo familiare",
+	MsgHouseholdMemberAdded:   "Membro aggiunto al nucleo familiare",
+	MsgHouseholdMemberRemoved: "Membro rimosso dal nucleo familiare",
+
+	// Notifications
+	MsgNotificationsFetched:    "Notifiche recuperate",
+	MsgNotificationsFetchError: "Errore nel recuperare le notifiche",
+	MsgPreferencesUpdated:      "Preferenze aggiornate",
+	MsgPreferencesUpdateError:  "Errore nell'aggiornare le preferenze",
+
+	// Webhooks
+	MsgWebhookNotFound:      "Webhook non trovato",
+	MsgWebhookCreated:       "Webhook creato!",
+	MsgWebhookCreateError:   "Errore nel creare il webhook",
+	MsgWebhooksFetched:      "Webhook recuperati",
+	MsgWebhooksFetchError:   "Errore nel recuperare i webhook",
+	MsgWebhookFetched:       "Webhook recuperato",
+	MsgWebhookUpdated:       "Webhook aggiornato",
+	MsgWebhookUpdateError:   "Errore nell'aggiornare il webhook",
+	MsgWebhookDeleted:       "Webhook eliminato",
+	MsgWebhookDeleteError:   "Errore nell'eliminare il webhook",
+	MsgDeliveryIDRequired:   "Specificare l'id della consegna",
+	MsgDeliveryNotFound:     "Consegna non trovata",
+	MsgDeliveriesFetched:    "Consegne recuperate",
+	MsgDeliveriesFetchError: "Errore nel recuperare le consegne",
+	MsgDeliveryScheduled:    "Consegna programmata",
+
+	// Validation of the fields
+	MsgNameRequired:               "il nome è obbligatorio",
+	MsgSurnameRequired:            "il cognome è obbligatorio",
+	MsgGenderRequired:             "il sesso è obbligatorio",
+	MsgDateOfBirthRequired:        "la data di nascita è obbligatoria",
+	MsgContactsRequired:           "i contatti sono obbligatori",
+	MsgAddressRequired:            "l'indirizzo è obbligatorio",
+	MsgSubscriptionRequired:       "l'abbonamento è obbligatorio",
+	MsgPhoneRequired:              "il numero di telefono è obbligatorio",
+	MsgCountryRequired:            "il paese è obbligatorio",
+	MsgCityRequired:               "la città è obbligatoria",
+	MsgStreetRequired:             "la via è obbligatoria",
+	MsgOverlapPolicyInvalid:       "la politica di sovrapposizione deve essere reject o queue",
+	MsgSubscriptionTypeRequired:   "il tipo di abbonamento è obbligatorio",
+	MsgSubscriptionTypeInvalid:    "il tipo di abbonamento deve essere mensile, trimestrale, semestrale, annuale o custom",
+	MsgSubscriptionStartRequired:  "la data di inizio abbonamento è obbligatoria",
+	MsgSubscriptionPriceRequired:  "il prezzo dell'abbonamento è obbligatorio",
+	MsgSubscriptionPriceNegative:  "il prezzo dell'abbonamento non può essere negativo",
+	MsgSubscriptionActiveRequired: "indicare se l'abbonamento è attivo",
+	MsgSubscriptionEndRequired:    "la data di fine abbonamento è obbligatoria",
+	MsgSubscriptionEndBeforeStart: "la data di fine abbonamento deve essere successiva alla data di inizio",
+	MsgSubscriptionOverlaps:       "l'abbonamento si sovrappone all'abbonamento %d",
+	MsgSubscriptionOverlapsPeriod: "l'abbonamento si sovrappone all'abbonamento %d (dal %s al %s)",
+	MsgSubscriptionExpired:        "un abbonamento attivo non può essere già scaduto",
+	MsgEmailRequired:              "l'email è obbligatoria",
+	MsgEmailInvalid:               "l'email non è valida",
+	MsgPasswordRequired:           "la password è obbligatoria",
+	MsgRoleRequired:               "il ruolo è obbligatorio",
+	MsgRoleNameRequired:           "il nome del ruolo è obbligatorio",
+	MsgTableRequired:              "la tabella è obbligatoria",
+	MsgCreateLevelInvalid:         "il permesso di creazione deve essere 0 o 1",
+	MsgReadLevelInvalid:           "il permesso di lettura deve essere 0, 1 o 2",
+	MsgUpdateLevelInvalid:         "il permesso di modifica deve essere 0, 1 o 2",
+	MsgDeleteLevelInvalid:         "il permesso di eliminazione deve essere 0, 1 o 2",
+	MsgPermissionDuplicate:        "i permessi per questa tabella e ruolo sono già presenti",
+	MsgPermissionRoleNotExists:    "il ruolo non esiste",
+	MsgPermissionTableNotExists:   "la tabella non esiste",
+
+	// Promotions
+	MsgPromotionCodeNotExists:           "il codice promozionale non esiste",
+	MsgPromotionCodeExpired:             "il codice promozionale non è valido in questa data",
+	MsgPromotionCodeExhausted:           "il codice promozionale ha raggiunto il limite di utilizzi",
+	MsgPromotionCodeNotEligible:         "il codice promozionale non è valido per questo tipo d'abbonamento",
+	MsgPromotionCodeFirstTimeOnly:       "il codice promozionale è riservato ai nuovi iscritti",
+	MsgRequiredFields:                   "compilare i campi obbligatori",
+	MsgPromotionTypeInvalid:             "il tipo di promozione deve essere percentage o fixed",
+	MsgPromotionValueInvalid:            "il valore della promozione non è valido",
+	MsgPromotionEndBeforeStart:          "la data di fine promozione deve essere successiva alla data di inizio",
+	MsgPromotionSubscriptionTypeInvalid: "il tipo d'abbonamento %s non è valido",
+
+	// Households
+	MsgHouseholdDiscountInvalid: "lo sconto deve essere compreso tra 0 e 100",
+	MsgHouseholdMemberRequired:  "specificare il membro da aggiungere",
+	MsgPayerInvalid:             "il pagante non è valido",
+	MsgPayerInHousehold:         "il pagante fa già parte di un nucleo familiare",
+	MsgPayerMinor:               "il pagante deve essere maggiorenne",
+	MsgPayerNotInHousehold:      "il pagante deve far parte del nucleo familiare",
+	MsgHouseholdMemberInvalid:   "il membro non è valido",
+	MsgMemberInHousehold:        "il membro fa già parte di un nucleo familiare",
+	MsgGuardianConsentRequired:  "il consenso del tutore è obbligatorio per i minorenni",
+	MsgPayerRemoval:             "non è possibile rimuovere il pagante dal nucleo familiare",
+	MsgHouseholdInvalid:         "il nucleo familiare non è valido",
+	MsgGuardianNotInHousehold:   "il tutore deve far parte del nucleo familiare",
+	MsgGuardianMinor:            "il tutore deve essere maggiorenne",
+
+	// Guests
+	MsgGuestContactRequired:  "inserire almeno un contatto",
+	MsgPassFieldsInvalid:     "compilare i campi dell'ingresso correttamente",
+	MsgPassPunchCardRequired: "specificare il numero di ingressi e la data di scadenza",
+	MsgPassExpiryBeforeStart: "la data di scadenza deve essere successiva alla data di inizio",
+	MsgPassTypeInvalid:       "il tipo di ingresso deve essere giornaliero o carnet",
+	MsgPassUnusable:          "l'ingresso non è valido o è esaurito",
+	MsgGuestAlreadyEnrolled:  "l'ospite è già stato iscritto",
+	MsgReferrerInvalid:       "il membro presentatore non è valido",
+
+	// Privacy
+	MsgMemberAlreadyAnonymized: "i dati del membro sono già stati anonimizzati",
+	MsgConsentAlreadyGiven:     "il consenso %s è già stato dato per la versione %s",
+	MsgConsentAlreadyRevoked:   "il consenso è già stato revocato",
+	MsgConsentTypeInvalid:      "il tipo di consenso deve essere privacy, marketing o foto",
+	MsgPolicyVersionRequired:   "specificare la versione dell'informativa",
+	MsgConsentDateFuture:       "la data del consenso non può essere futura",
+
+	// Subscriptions, reports and exports
+	MsgSubscriptionAlreadyRenewed: "l'abbonamento è già stato rinnovato",
+	MsgReportMonthsOrder:          "il mese di fine deve essere successivo al mese di inizio",
+	MsgReportPeriodTooLong:        "il periodo non può superare %d mesi",
+	MsgExportFileFormatInvalid:    "il formato deve essere csv, xlsx o pdf",
+
+	// Webhooks and notifications
+	MsgWebhookURLInvalid:        "l'indirizzo del webhook deve essere un url http o https",
+	MsgWebhookEventsRequired:    "specificare almeno un evento",
+	MsgWebhookEventUnknown:      "l'evento %s non esiste",
+	MsgDeliveryAlreadyDelivered: "l'evento è già stato consegnato",
+	MsgPreferencesRequired:      "specificare almeno una preferenza",
+
+	// Import
+	MsgImportEmpty:          "il file non contiene membri",
+	MsgImportFieldInvalid:   "il campo %s non può essere importato",
+	MsgImportColumnMissing:  "la colonna %s non è presente nel file",
+	MsgImportDateInvalid:    "la data %s non è valida",
+	MsgImportPriceInvalid:   "il prezzo %s non è valido",
+	MsgImportPhoneArchived:  "telefono già presente in archivio",
+	MsgImportPhoneDuplicate: "telefono già presente alla riga %d",
+	MsgImportEmailArchived:  "email già presente in archivio",
+	MsgImportEmailDuplicate: "email già presente alla riga %d",
+	MsgTableFormatInvalid:   "il file deve essere in formato csv o xlsx",
+	MsgTableCSVInvalid:      "il file csv non è valido",
+	MsgTableXLSXInvalid:     "il file xlsx non è valido",
+}
@@ -0,0 +1,322 @@
+package i18n
+
+// Codes of the messages, the texts are in the catalog of each language.
+const (
+	// Generic
+	MsgUnauthorized        = "auth.unauthorized"
+	MsgForbidden           = "auth.forbidden"
+	MsgTooManyRequests     = "request.too_many"
+	MsgBadRequest          = "request.bad_request"
+	MsgResourceNotFound    = "request.not_found"
+	MsgMethodNotAllowed    = "request.method_not_allowed"
+	MsgInternalError       = "request.internal_error"
+	MsgDataInvalid         = "request.data_invalid"
+	MsgStartDateFormat     = "request.start_date_format"
+	MsgEndDateFormat       = "request.end_date_format"
+	MsgEndDateBeforeStart  = "request.end_date_before_start"
+	MsgStartMonthFormat    = "request.start_month_format"
+	MsgEndMonthFormat      = "request.end_month_format"
+	MsgDaysPositive        = "request.days_positive"
+	MsgActiveFilterInvalid = "request.active_filter_invalid"
+
+	// Users and sessions
+	MsgLoginSuccess        = "user.login"
+	MsgLogoutSuccess       = "user.logout"
+	MsgEmailNotFound       = "user.email_not_found"
+	MsgWrongPassword       = "user.wrong_password"
+	MsgCredentialsInvalid  = "user.credentials_invalid"
+	MsgSessionCreateError  = "session.create_error"
+	MsgSessionDeleteError  = "session.delete_error"
+	MsgUserInvalid         = "user.invalid"
+	MsgUserNotFound        = "user.not_found"
+	MsgSystemUserForbidden = "user.system_forbidden"
+	MsgPasswordHashError   = "user.password_hash_error"
+	MsgUserCreateError     = "user.create_error"
+	MsgUserCreated         = "user.created"
+	MsgUsersFetched        = "user.list"
+	MsgUsersFetchError     = "user.list_error"
+	MsgUserUpdated         = "user.updated"
+	MsgUserUpdateError     = "user.update_error"
+	MsgUserDeleted         = "user.deleted"
+	MsgUserDeleteError     = "user.delete_error"
+	MsgRoleNotExists       = "user.role_not_exists"
+
+	// Roles and permissions
+	MsgRoleIDRequired        = "role.id_required"
+	MsgRoleInvalid           = "role.invalid"
+	MsgRoleNotFound          = "role.not_found"
+	MsgRolesNotFound         = "role.list_not_found"
+	MsgRolesFetched          = "role.list"
+	MsgRolesFetchError       = "role.list_error"
+	MsgRoleFetched           = "role.get"
+	MsgRoleCreated           = "role.created"
+	MsgRoleCreateError       = "role.create_error"
+	MsgRoleUpdated           = "role.updated"
+	MsgRoleDeleted           = "role.deleted"
+	MsgPermissionIDRequired  = "permission.id_required"
+	MsgPermissionInvalid     = "permission.invalid"
+	MsgPermissionNotFound    = "permission.not_found"
+	MsgPermissionsNotFound   = "permission.list_not_found"
+	MsgPermissionFetched     = "permission.get"
+	MsgPermissionsFetched    = "permission.list"
+	MsgPermissionCheckError  = "permission.check_error"
+	MsgPermissionCreated     = "permission.created"
+	MsgPermissionCreateError = "permission.create_error"
+	MsgPermissionUpdated     = "permission.updated"
+	MsgPermissionUpdateError = "permission.update_error"
+	MsgPermissionDeleted     = "permission.deleted"
+
+	// Members
+	MsgMemberIDRequired         = "member.id_required"
+	MsgMemberDeleteIDRequired   = "member.delete_id_required"
+	MsgMemberRestoreIDRequired  = "member.restore_id_required"
+	MsgMemberInvalid            = "member.invalid"
+	MsgMemberNotFound           = "member.not_found"
+	MsgMemberContactsNotFound   = "member.contacts_not_found"
+	MsgMemberCreated            = "member.created"
+	MsgMemberCreateError        = "member.create_error"
+	MsgMemberFetched            = "member.get"
+	MsgMemberFetchError         = "member.get_error"
+	MsgMembersFetched           = "member.list"
+	MsgMembersFetchError        = "member.list_error"
+	MsgMemberUpdated            = "member.updated"
+	MsgMemberUpdateError        = "member.update_error"
+	MsgMemberDeleted            = "member.deleted"
+	MsgMemberDeleteError        = "member.delete_error"
+	MsgDeletedMembersFetched    = "member.deleted_list"
+	MsgDeletedMembersFetchError = "member.deleted_list_error"
+	MsgDeletedMemberNotFound    = "member.deleted_not_found"
+	MsgMemberRestored           = "member.restored"
+	MsgMemberRestoreError       = "member.restore_error"
+
+	// Subscriptions
+	MsgSubscriptionInvalid            = "subscription.invalid"
+	MsgRenewalInvalid                 = "subscription.renewal_invalid"
+	MsgSubscriptionNotFound           = "subscription.not_found"
+	MsgSubscriptionCreated            = "subscription.created"
+	MsgSubscriptionCreateError        = "subscription.create_error"
+	MsgSubscriptionRenewed            = "subscription.renewed"
+	MsgSubscriptionsFetched           = "subscription.list"
+	MsgSubscriptionFetched            = "subscription.get"
+	MsgSubscriptionFetchError         = "subscription.get_error"
+	MsgSubscriptionUpdated            = "subscription.updated"
+	MsgSubscriptionDeleteIDRequired   = "subscription.delete_id_required"
+	MsgSubscriptionRestoreIDRequired  = "subscription.restore_id_required"
+	MsgSubscriptionDeleted            = "subscription.deleted"
+	MsgDeletedSubscriptionsFetched    = "subscription.deleted_list"
+	MsgDeletedSubscriptionsFetchError = "subscription.deleted_list_error"
+	MsgDeletedSubscriptionNotFound    = "subscription.deleted_not_found"
+	MsgSubscriptionRestored           = "subscription.restored"
+	MsgSubscriptionRestoreError       = "subscription.restore_error"
+
+	// Export and import
+	MsgMemberExportError    = "export.member_error"
+	MsgMemberDataExported   = "privacy.exported"
+	MsgMemberDataAnonymized = "privacy.anonymized"
+	MsgExportFormatInvalid  = "privacy.format_invalid"
+	MsgImportFileRequired   = "import.file_required"
+	MsgImportFileReadError  = "import.file_read_error"
+	MsgImportColumnsInvalid = "import.columns_invalid"
+	MsgImportChecked        = "import.checked"
+	MsgImportRejected       = "import.rejected"
+	MsgMembersImported      = "import.imported"
+
+	// Consents
+	MsgConsentIDRequired  = "consent.id_required"
+	MsgConsentNotFound    = "consent.not_found"
+	MsgConsentsFetched    = "consent.list"
+	MsgConsentsFetchError = "consent.list_error"
+	MsgConsentCreated     = "consent.created"
+	MsgConsentRevoked     = "consent.revoked"
+
+	// Reports and audits
+	MsgReportFetched      = "report.get"
+	MsgReportFetchError   = "report.get_error"
+	MsgReportComputed     = "report.computed"
+	MsgReportComputeError = "report.compute_error"
+	MsgAuditsFetched      = "audit.list"
+	MsgAuditsFetchError   = "audit.list_error"
+
+	// Promotions
+	MsgPromotionIDRequired   = "promotion.id_required"
+	MsgPromotionNotFound     = "promotion.not_found"
+	MsgPromotionsFetched     = "promotion.list"
+	MsgPromotionsFetchError  = "promotion.list_error"
+	MsgPromotionFetched      = "promotion.get"
+	MsgPromotionCreated      = "promotion.created"
+	MsgPromotionCreateError  = "promotion.create_error"
+	MsgPromotionUpdated      = "promotion.updated"
+	MsgPromotionUpdateError  = "promotion.update_error"
+	MsgPromotionDeleted      = "promotion.deleted"
+	MsgPromotionDeleteError  = "promotion.delete_error"
+	MsgRedemptionsFetched    = "promotion.redemptions"
+	MsgRedemptionsFetchError = "promotion.redemptions_error"
+
+	// Guests
+	MsgGuestNotFound    = "guest.not_found"
+	MsgGuestCreated     = "guest.created"
+	MsgGuestsFetched    = "guest.list"
+	MsgGuestsFetchError = "guest.list_error"
+	MsgGuestFetched     = "guest.get"
+	MsgGuestUpdated     = "guest.updated"
+	MsgGuestDeleted     = "guest.deleted"
+	MsgGuestDeleteError = "guest.delete_error"
+	MsgGuestEnrolled    = "guest.enrolled"
+	MsgPassIDRequired   = "pass.id_required"
+	MsgPassCreated      = "pass.created"
+	MsgPassCreateError  = "pass.create_error"
+	MsgPassesFetched    = "pass.list"
+	MsgPassesFetchError = "pass.list_error"
+	MsgVisitRecorded    = "pass.visit"
+
+	// Households
+	MsgHouseholdNotFound      = "household.not_found"
+	MsgHouseholdCreated       = "household.created"
+	MsgHouseholdsFetched      = "household.list"
+	MsgHouseholdsFetchError   = "household.list_error"
+	MsgHouseholdFetched       = "household.get"
+	MsgHouseholdUpdated       = "household.updated"
+	MsgHouseholdDeleted       = "household.deleted"
+	MsgHouseholdDeleteError   = "household.delete_error"
+	MsgHouseholdMemberAdded   = "household.member_added"
+	MsgHouseholdMemberRemoved = "household.member_removed"
+
+	// Notifications
+	MsgNotificationsFetched    = "notification.list"
+	MsgNotificationsFetchError = "notification.list_error"
+	MsgPreferencesUpdated      = "notification.preferences_updated"
+	MsgPreferencesUpdateError  = "notification.preferences_error"
+
+	// Webhooks
+	MsgWebhookNotFound      = "webhook.not_found"
+	MsgWebhookCreated       = "webhook.created"
+	MsgWebhookCreateError   = "webhook.create_error"
+	MsgWebhooksFetched      = "webhook.list"
+	MsgWebhooksFetchError   = "webhook.list_error"
+	MsgWebhookFetched       = "webhook.get"
+	MsgWebhookUpdated       = "webhook.updated"
+	MsgWebhookUpdateError   = "webhook.update_error"
+	MsgWebhookDeleted       = "webhook.deleted"
+	MsgWebhookDeleteError   = "webhook.delete_error"
+	MsgDeliveryIDRequired   = "webhook.delivery_id_required"
+	MsgDeliveryNotFound     = "webhook.delivery_not_found"
+	MsgDeliveriesFetched    = "webhook.deliveries"
+	MsgDeliveriesFetchError = "webhook.deliveries_error"
+	MsgDeliveryScheduled    = "webhook.delivery_scheduled"
+
+	// Validation of the fields
+	MsgNameRequired               = "validation.name_required"
+	MsgSurnameRequired            = "validation.surname_required"
+	MsgGenderRequired             = "validation.gender_required"
+	MsgDateOfBirthRequired        = "validation.date_of_birth_required"
+	MsgContactsRequired           = "validation.contacts_required"
+	MsgAddressRequired            = "validation.address_required"
+	MsgSubscriptionRequired       = "validation.subscription_required"
+	MsgPhoneRequired              = "validation.phone_required"
+	MsgCountryRequired            = "validation.country_required"
+	MsgCityRequired               = "validation.city_required"
+	MsgStreetRequired             = "validation.street_required"
+	MsgOverlapPolicyInvalid       = "validation.overlap_policy_invalid"
+	MsgSubscriptionTypeRequired   = "validation.subscription_type_required"
+	MsgSubscriptionTypeInvalid    = "validation.subscription_type_invalid"
+	MsgSubscriptionStartRequired  = "validation.subscription_start_required"
+	MsgSubscriptionPriceRequired  = "validation.subscription_price_required"
+	MsgSubscriptionPriceNegative  = "validation.subscription_price_negative"
+	MsgSubscriptionActiveRequired = "validation.subscription_active_required"
+	MsgSubscriptionEndRequired    = "validation.subscription_end_required"
+	MsgSubscriptionEndBeforeStart = "validation.subscription_end_before_start"
+	MsgSubscriptionOverlaps       = "validation.subscription_overlaps"
+	MsgSubscriptionOverlapsPeriod = "validation.subscription_overlaps_period"
+	MsgSubscriptionExpired        = "validation.subscription_expired"
+	MsgEmailRequired              = "validation.email_required"
+	MsgEmailInvalid               = "validation.email_invalid"
+	MsgPasswordRequired           = "validation.password_required"
+	MsgRoleRequired               = "validation.role_required"
+	MsgRoleNameRequired           = "validation.role_name_required"
+	MsgTableRequired              = "validation.table_required"
+	MsgCreateLevelInvalid         = "validation.create_level_invalid"
+	MsgReadLevelInvalid           = "validation.read_level_invalid"
+	MsgUpdateLevelInvalid         = "validation.update_level_invalid"
+	MsgDeleteLevelInvalid         = "validation.delete_level_invalid"
+	MsgPermissionDuplicate        = "validation.permission_duplicate"
+	MsgPermissionRoleNotExists    = "validation.permission_role_not_exists"
+	MsgPermissionTableNotExists   = "validation.permission_table_not_exists"
+
+	// Promotions
+	MsgPromotionCodeNotExists           = "promotion.code_not_exists"
+	MsgPromotionCodeExpired             = "promotion.code_expired"
+	MsgPromotionCodeExhausted           = "promotion.code_exhausted"
+	MsgPromotionCodeNotEligible         = "promotion.code_not_eligible"
+	MsgPromotionCodeFirstTimeOnly       = "promotion.code_first_time_only"
+	MsgRequiredFields                   = "validation.required_fields"
+	MsgPromotionTypeInvalid             = "promotion.type_invalid"
+	MsgPromotionValueInvalid            = "promotion.value_invalid"
+	MsgPromotionEndBeforeStart          = "promotion.end_before_start"
+	MsgPromotionSubscriptionTypeInvalid = "promotion.subscription_type_invalid"
+
+	// Households
+	MsgHouseholdDiscountInvalid = "household.discount_invalid"
+	MsgHouseholdMemberRequired  = "household.member_required"
+	MsgPayerInvalid             = "household.payer_invalid"
+	MsgPayerInHousehold         = "household.payer_in_household"
+	MsgPayerMinor               = "household.payer_minor"
+	MsgPayerNotInHousehold      = "household.payer_not_in_household"
+	MsgHouseholdMemberInvalid   = "household.member_invalid"
+	MsgMemberInHousehold        = "household.member_in_household"
+	MsgGuardianConsentRequired  = "household.guardian_consent_required"
+	MsgPayerRemoval             = "household.payer_removal"
+	MsgHouseholdInvalid         = "household.invalid"
+	MsgGuardianNotInHousehold   = "household.guardian_not_in_household"
+	MsgGuardianMinor            = "household.guardian_minor"
+
+	// Guests
+	MsgGuestContactRequired  = "guest.contact_required"
+	MsgPassFieldsInvalid     = "pass.fields_invalid"
+	MsgPassPunchCardRequired = "pass.punch_card_required"
+	MsgPassExpiryBeforeStart = "pass.expiry_before_start"
+	MsgPassTypeInvalid       = "pass.type_invalid"
+	MsgPassUnusable          = "pass.unusable"
+	MsgGuestAlreadyEnrolled  = "guest.already_enrolled"
+	MsgReferrerInvalid       = "guest.referrer_invalid"
+
+	// Privacy
+	MsgMemberAlreadyAnonymized = "privacy.already_anonymized"
+	MsgConsentAlreadyGiven     = "consent.already_given"
+	MsgConsentAlreadyRevoked   = "consent.already_revoked"
+	MsgConsentTypeInvalid      = "consent.type_invalid"
+	MsgPolicyVersionRequired   = "consent.policy_version_required"
+	MsgConsentDateFuture       = "consent.date_future"
+
+	// Subscriptions, reports and exports
+	MsgSubscriptionAlreadyRenewed = "subscription.already_renewed"
+	MsgReportMonthsOrder          = "report.months_order"
+	MsgReportPeriodTooLong        = "report.period_too_long"
+	MsgExportFileFormatInvalid    = "export.format_invalid"
+
+	// Webhooks and notifications
+	MsgWebhookURLInvalid        = "webhook.url_invalid"
+	MsgWebhookEventsRequired    = "webhook.events_required"
+	MsgWebhookEventUnknown      = "webhook.event_unknown"
+	MsgDeliveryAlreadyDelivered = "webhook.already_delivered"
+	MsgPreferencesRequired      = "notification.preferences_required"
+
+	// Import
+	MsgImportEmpty          = "import.empty"
+	MsgImportFieldInvalid   = "import.field_invalid"
+	MsgImportColumnMissing  = "import.column_missing"
+	MsgImportDateInvalid    = "import.date_invalid"
+	MsgImportPriceInvalid   = "import.price_invalid"
+	MsgImportPhoneArchived  = "import.phone_archived"
+	MsgImportPhoneDuplicate = "import.phone_duplicate"
+	MsgImportEmailArchived  = "import.email_archived"
+	MsgImportEmailDuplicate = "import.email_duplicate"
+	MsgTableFormatInvalid   = "import.format_invalid"
+	MsgTableCSVInvalid      = "import.csv_invalid"
+	MsgTableXLSXInvalid     = "import.xlsx_invalid"
+)
+
+// catalog holds the messages of each language by code.
+var catalog = map[string]map[string]string{
+	Italian: italian,
+	English: english,
+}
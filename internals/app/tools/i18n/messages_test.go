@@ -0,0 +1,119 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// codes returns the message codes declared in messages.go.
+func codes(t *testing.T) map[string]string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "messages.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parsing messages.go: %v", err)
+	}
+
+	codes := map[string]string{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				if !strings.HasPrefix(name.Name, "Msg") {
+					continue
+				}
+				code, err := strconv.Unquote(value.Values[i].(*ast.BasicLit).Value)
+				if err != nil {
+					t.Fatalf("code of %s: %v", name.Name, err)
+				}
+				codes[code] = name.Name
+			}
+		}
+	}
+	return codes
+}
+
+func TestCatalogHasEveryTranslation(t *testing.T) {
+	codes := codes(t)
+	if len(codes) == 0 {
+		t.Fatal("no message codes found in messages.go")
+	}
+
+	for _, lang := range Languages {
+		messages, ok := catalog[lang]
+		if !ok {
+			t.Errorf("language %s has no catalog", lang)
+			continue
+		}
+
+		for code, name := range codes {
+			if messages[code] == "" {
+				t.Errorf("%s (%s) has no %s translation", name, code, lang)
+			}
+		}
+		for code := range messages {
+			if _, ok := codes[code]; !ok {
+				t.Errorf("%s catalog has the unknown code %s", lang, code)
+			}
+		}
+	}
+}
+
+var verbs = regexp.MustCompile(`%[a-z]`)
+
+func TestCatalogFormatVerbsMatch(t *testing.T) {
+	for code := range codes(t) {
+		expected := verbs.FindAllString(catalog[DefaultLanguage][code], -1)
+		for _, lang := range Languages {
+			found := verbs.FindAllString(catalog[lang][code], -1)
+			if strings.Join(found, " ") != strings.Join(expected, " ") {
+				t.Errorf("%s: %s has verbs %v, %s has %v", code, lang, found, DefaultLanguage, expected)
+			}
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]string{
+		"":                         DefaultLanguage,
+		"en":                       English,
+		"en-US,en;q=0.9":           English,
+		"IT-it":                    Italian,
+		"fr-FR,en;q=0.5,it;q=0.8":  Italian,
+		"de,fr":                    DefaultLanguage,
+		"en;q=0,it;q=0.1":          Italian,
+		"*":                        DefaultLanguage,
+		"en;q=invalid,it;q=0.2":    Italian,
+		"it;q=0.4, en-GB;q=0.7, *": English,
+	}
+
+	for header, expected := range tests {
+		if lang := Negotiate(header); lang != expected {
+			t.Errorf("Negotiate(%q) = %s, expected %s", header, lang, expected)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	if message := Translate(English, MsgMemberCreated); message != "Member added!" {
+		t.Errorf("unexpected english message %q", message)
+	}
+	if message := Translate("fr", MsgMemberCreated); message != "Membro aggiunto!" {
+		t.Errorf("unsupported languages should fall back to italian, got %q", message)
+	}
+	if message := Translate(English, MsgReportPeriodTooLong, 24); message != "the period can't exceed 24 months" {
+		t.Errorf("unexpected formatted message %q", message)
+	}
+	if message := Translate(English, "not a code"); message != "not a code" {
+		t.Errorf("unknown codes should be returned as they are, got %q", message)
+	}
+}
@@ -0,0 +1,115 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Time given to each component to stop once the drain timeout is over, to
+// release its resources
+const stopGrace = time.Second
+
+// Component is a part of the application started and stopped by the Lifecycle.
+type Component struct {
+	Name string
+	// Start runs the component, it may block until the component is stopped.
+	// An error stops the application, nil if the component is only stopped.
+	Start func() error
+	// Stop stops the component, giving up at the end of the context.
+	Stop func(ctx context.Context) error
+}
+
+type Lifecycle struct {
+	components   []Component
+	drainTimeout time.Duration
+}
+
+// NewLifecycle creates a new Lifecycle without components.
+//
+// Parameters:
+//   - drainTimeout: the time given to the components to stop.
+func NewLifecycle(drainTimeout time.Duration) *Lifecycle {
+	return &Lifecycle{
+		drainTimeout: drainTimeout,
+	}
+}
+
+// Append registers a component. The components are started in the order they
+// are appended and stopped in the reverse one, so a component can use the
+// ones appended before it until it's stopped.
+func (l *Lifecycle) Append(component Component) {
+	l.components = append(l.components, component)
+}
+
+// Run starts the components and stops them once the context is done or a
+// component fails to start.
+//
+// Returns:
+//   - error: the error of the component that failed and the errors of the
+//     components that could not be stopped in time.
+func (l *Lifecycle) Run(ctx context.Context) error {
+	failed := make(chan error, len(l.components))
+	for _, component := range l.components {
+		if component.Start == nil {
+			continue
+		}
+		go func(component Component) {
+			if err := component.Start(); err != nil {
+				failed <- fmt.Errorf("%s: %w", component.Name, err)
+			}
+		}(component)
+		slog.Info("Component started", "component", component.Name)
+	}
+
+	var errs []error
+	select {
+	case <-ctx.Done():
+		slog.Info("Shutting down...")
+	case err := <-failed:
+		slog.Error("Shutting down after an error", "error", err)
+		errs = append(errs, err)
+	}
+
+	// Every component shares the same deadline
+	stopCtx, cancel := context.WithTimeout(context.Background(), l.drainTimeout)
+	defer cancel()
+
+	for i := len(l.components) - 1; i >= 0; i-- {
+		component := l.components[i]
+		if component.Stop == nil {
+			continue
+		}
+		if err := stop(stopCtx, component); err != nil {
+			slog.Error("Error stopping component", "component", component.Name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", component.Name, err))
+			continue
+		}
+		slog.Info("Component stopped", "component", component.Name)
+	}
+
+	return errors.Join(errs...)
+}
+
+// stop stops a component, without waiting for it past the end of the context.
+func stop(ctx context.Context, component Component) error {
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), stopGrace)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- component.Stop(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	userIDKey
+)
+
+// WithRequestID returns a copy of the context with the ID of the request, added
+// to the records logged with the context.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// WithUserID returns a copy of the context with the ID of the authenticated
+// user, added to the records logged with the context.
+func WithUserID(ctx context.Context, userID uint) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// ContextHandler adds the request and user IDs and the trace of the context to
+// the records before passing them to the wrapped handler.
+type ContextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler wraps a handler in a ContextHandler.
+func NewContextHandler(handler slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: handler}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID, ok := ctx.Value(requestIDKey).(string); ok && requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if userID, ok := ctx.Value(userIDKey).(uint); ok && userID != 0 {
+		record.AddAttrs(slog.Uint64("user_id", uint64(userID)))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
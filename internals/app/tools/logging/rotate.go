@@ -0,0 +1,169 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Layout of the time added to the name of the rotated files, sorted by name
+// from the oldest to the newest
+const backupTimeLayout = "2006-01-02T15-04-05.000"
+
+// RotatingFile is a log file renamed once it reaches its maximum size. The
+// renamed files are removed once older than the maximum age or beyond the
+// maximum number of backups.
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRotatingFile opens, or creates with its directory, the log file.
+//
+// Parameters:
+//   - path: the path of the log file.
+//   - maxSize: the size in bytes after which the file is rotated.
+//   - maxAge: the age after which the rotated files are removed, 0 keeps them.
+//   - maxBackups: the number of rotated files kept, 0 keeps them all.
+func NewRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*RotatingFile, error) {
+	f := &RotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write writes to the log file, rotating it first if p doesn't fit in it. A
+// record longer than the maximum size is written in a file of its own. The
+// records written once the file is closed are dropped, so that the other
+// outputs of the logs still get them.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return len(p), nil
+	}
+
+	if f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate renames the log file with the current time and opens a new one.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+
+	prefix, ext := f.backupPrefix()
+	backup := prefix + time.Now().Format(backupTimeLayout) + ext
+	if err := os.Rename(f.path, backup); err != nil {
+		// Keep writing to the current file rather than losing the records
+		if err := f.open(); err != nil {
+			return err
+		}
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.removeBackups()
+	return nil
+}
+
+// removeBackups removes the rotated files too old or too many, newest first.
+// It can't log its errors to the file it manages, so it writes them to stderr.
+func (f *RotatingFile) removeBackups() {
+	if f.maxAge <= 0 && f.maxBackups <= 0 {
+		return
+	}
+
+	prefix, ext := f.backupPrefix()
+	backups, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+
+	kept := 0
+	for _, backup := range backups {
+		created, err := time.ParseInLocation(backupTimeLayout, strings.TrimSuffix(strings.TrimPrefix(backup, prefix), ext), time.Local)
+		if err != nil {
+			// Not a rotated file
+			continue
+		}
+
+		tooMany := f.maxBackups > 0 && kept >= f.maxBackups
+		tooOld := f.maxAge > 0 && time.Since(created) > f.maxAge
+		if !tooMany && !tooOld {
+			kept++
+			continue
+		}
+		if err := os.Remove(backup); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to remove log file %s: %v\n", backup, err)
+		}
+	}
+}
+
+// backupPrefix returns the path of the rotated files before and after their
+// time, e.g. logs/app- and .log for logs/app.log.
+func (f *RotatingFile) backupPrefix() (string, string) {
+	ext := filepath.Ext(f.path)
+	return strings.TrimSuffix(f.path, ext) + "-", ext
+}
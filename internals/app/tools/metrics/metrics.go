@@ -0,0 +1,268 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Content type of the Prometheus text format written by the Registry
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Buckets of the request durations, in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type metric interface {
+	write(w io.Writer) error
+}
+
+// Registry holds the metrics exposed in the Prometheus text format.
+type Registry struct {
+	namespace string
+	mu        sync.Mutex
+	metrics   []metric
+}
+
+// NewRegistry creates a new Registry without metrics. The names of its
+// metrics are prefixed with the namespace, e.g. gym_http_requests_total.
+func NewRegistry(namespace string) *Registry {
+	return &Registry{namespace: namespace}
+}
+
+// Counter registers a counter with the given labels.
+func (r *Registry) Counter(name string, help string, labels ...string) *Counter {
+	counter := &Counter{desc: desc{name: r.name(name), help: help, labels: labels}, values: map[string]float64{}}
+	r.register(counter)
+	return counter
+}
+
+// Histogram registers a histogram with the given buckets and labels.
+func (r *Registry) Histogram(name string, help string, buckets []float64, labels ...string) *Histogram {
+	histogram := &Histogram{desc: desc{name: r.name(name), help: help, labels: labels}, buckets: buckets, series: map[string]*series{}}
+	r.register(histogram)
+	return histogram
+}
+
+// GaugeFunc registers a gauge whose value is read on every write. The gauge
+// is left out while value returns an error.
+func (r *Registry) GaugeFunc(name string, help string, value func() (float64, error)) {
+	r.register(&gaugeFunc{desc: desc{name: r.name(name), help: help}, value: value})
+}
+
+// Write writes every metric in the Prometheus text format.
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	metrics := r.metrics
+	r.mu.Unlock()
+
+	for _, metric := range metrics {
+		if err := metric.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// name prefixes the name of a metric with the namespace.
+func (r *Registry) name(name string) string {
+	if r.namespace == "" {
+		return name
+	}
+	return r.namespace + "_" + name
+}
+
+func (r *Registry) register(metric metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, metric)
+}
+
+// desc describes a metric and its labels.
+type desc struct {
+	name   string
+	help   string
+	labels []string
+}
+
+func (d *desc) header(w io.Writer, kind string) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, d.help, d.name, kind)
+	return err
+}
+
+// key joins the values of the labels, in the order of the labels.
+func (d *desc) key(values []string) string {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s has %d labels, got %d values", d.name, len(d.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// pairs formats the labels of a key, with the extra pairs appended.
+func (d *desc) pairs(key string, extra ...string) string {
+	var values []string
+	if len(d.labels) > 0 {
+		values = strings.Split(key, "\xff")
+	}
+
+	var pairs []string
+	for i, label := range d.labels {
+		pairs = append(pairs, label+`="`+escape(values[i])+`"`)
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+`="`+escape(extra[i+1])+`"`)
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Counter is a value that only goes up, per values of its labels.
+type Counter struct {
+	desc
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// Inc adds one to the counter of the label values.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds a positive value to the counter of the label values.
+func (c *Counter) Add(value float64, labelValues ...string) {
+	key := c.key(labelValues)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] += value
+}
+
+func (c *Counter) write(w io.Writer) error {
+	if err := c.header(w, "counter"); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// A counter without labels is always exposed
+	if len(c.labels) == 0 && len(c.values) == 0 {
+		_, err := fmt.Fprintf(w, "%s 0\n", c.name)
+		return err
+	}
+
+	for _, key := range sortedKeys(c.values) {
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", c.name, c.pairs(key), format(c.values[key])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Histogram counts the observed values in buckets, per values of its labels.
+type Histogram struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*series
+}
+
+type series struct {
+	counts []uint64 // Per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// Observe adds a value to the histogram of the label values.
+func (h *Histogram) Observe(value float64, labelValues ...string) {
+	key := h.key(labelValues)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &series{counts: make([]uint64, len(h.buckets))}
+		h.series[key] = s
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			s.counts[i]++
+			break
+		}
+	}
+	s.count++
+	s.sum += value
+}
+
+func (h *Histogram) write(w io.Writer) error {
+	if err := h.header(w, "histogram"); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, key := range sortedKeys(h.series) {
+		s := h.series[key]
+
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += s.counts[i]
+			if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.pairs(key, "le", format(bound)), cumulative); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			h.name, h.pairs(key, "le", "+Inf"), s.count,
+			h.name, h.pairs(key), format(s.sum),
+			h.name, h.pairs(key), s.count,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type gaugeFunc struct {
+	desc
+	value func() (float64, error)
+}
+
+func (g *gaugeFunc) write(w io.Writer) error {
+	value, err := g.value()
+	if err != nil {
+		return nil
+	}
+
+	if err := g.header(w, "gauge"); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s %s\n", g.name, format(value))
+	return err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func format(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
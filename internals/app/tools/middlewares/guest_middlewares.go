@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"errors"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type GuestMiddlewares struct {
+	Http     ports.HttpAdapters
+	Services ports.GuestServices
+}
+
+func NewGuestMiddlewares(http ports.HttpAdapters, services ports.GuestServices) *GuestMiddlewares {
+	return &GuestMiddlewares{
+		Http:     http,
+		Services: services,
+	}
+}
+
+func (m *GuestMiddlewares) GetGuest(c *fiber.Ctx) error {
+	// Get the guest ID from the API local params
+	id := utils.GetUintParam(c, "guest_id")
+
+	// Retrieve the guest from the database
+	guest, err := m.Services.GetGuestById(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.NewNotFoundError(i18n.MsgGuestNotFound).Wrap(err)
+		}
+		return err
+	}
+
+	utils.SetLocals(c, "guest", guest)
+	return c.Next()
+}
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"errors"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type HouseholdMiddlewares struct {
+	Http     ports.HttpAdapters
+	Services ports.HouseholdServices
+}
+
+func NewHouseholdMiddlewares(http ports.HttpAdapters, services ports.HouseholdServices) *HouseholdMiddlewares {
+	return &HouseholdMiddlewares{
+		Http:     http,
+		Services: services,
+	}
+}
+
+func (m *HouseholdMiddlewares) GetHousehold(c *fiber.Ctx) error {
+	// Get the household ID from the API local params
+	id := utils.GetUintParam(c, "household_id")
+
+	// Retrieve the household from the database
+	household, err := m.Services.GetHouseholdById(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.NewNotFoundError(i18n.MsgHouseholdNotFound).Wrap(err)
+		}
+		return err
+	}
+
+	utils.SetLocals(c, "household", household)
+	return c.Next()
+}
@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/logging"
+	"github.com/gofiber/fiber/v2"
+)
+
+type LogsMiddlewares struct {
+	logger *slog.Logger
+}
+
+func NewLogsMiddlewares(logger *slog.Logger) *LogsMiddlewares {
+	return &LogsMiddlewares{
+		logger: logger,
+	}
+}
+
+// LogRequest adds the request ID to the context of the request, so that the
+// records logged with it can be correlated, and writes an access log once the
+// request is answered.
+func (m *LogsMiddlewares) LogRequest(c *fiber.Ctx) error {
+	start := time.Now()
+	c.SetUserContext(logging.WithRequestID(c.UserContext(), c.GetRespHeader(fiber.HeaderXRequestID)))
+
+	route := respond(c)
+
+	status := c.Response().StatusCode()
+	level := slog.LevelInfo
+	switch {
+	case status >= fiber.StatusInternalServerError:
+		level = slog.LevelError
+	case status >= fiber.StatusBadRequest:
+		level = slog.LevelWarn
+	}
+
+	// The user context holds the user ID once authorized
+	m.logger.LogAttrs(c.UserContext(), level, "Request",
+		slog.String("method", c.Method()),
+		slog.String("path", c.Path()),
+		slog.String("route", route),
+		slog.Int("status", status),
+		slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+		slog.Int("bytes", len(c.Response().Body())),
+		slog.String("ip", c.IP()),
+		slog.String("user_agent", c.Get(fiber.HeaderUserAgent)),
+	)
+	return nil
+}
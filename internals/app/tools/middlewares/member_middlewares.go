@@ -1,7 +1,11 @@
 package middlewares
 
 import (
+	"errors"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
 	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -24,10 +28,10 @@ func (m *MemberMiddlewares) GetMember(c *fiber.Ctx) error {
 	id := utils.GetUintParam(c, "id")
 
 	// Retrieve the user from the database
-	member, err := m.Services.GetMemberById(id)
+	member, err := m.Services.GetMemberById(c.UserContext(), id)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return m.Http.NotFound(c, "Member not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.NewNotFoundError(i18n.MsgMemberNotFound).Wrap(err)
 		}
 		return err
 	}
@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/metrics"
+	"github.com/gofiber/fiber/v2"
+)
+
+type MetricsMiddlewares struct {
+	requests *metrics.Counter
+	duration *metrics.Histogram
+}
+
+func NewMetricsMiddlewares(registry *metrics.Registry) *MetricsMiddlewares {
+	return &MetricsMiddlewares{
+		requests: registry.Counter("http_requests_total", "Number of HTTP requests by route and status.", "method", "route", "status"),
+		duration: registry.Histogram("http_request_duration_seconds", "Duration of the HTTP requests by route.", metrics.DefaultBuckets, "method", "route"),
+	}
+}
+
+// RecordRequest counts the requests and measures their duration.
+func (m *MetricsMiddlewares) RecordRequest(c *fiber.Ctx) error {
+	start := time.Now()
+
+	route := respond(c)
+	status := c.Response().StatusCode()
+
+	m.requests.Inc(c.Method(), route, strconv.Itoa(status))
+	m.duration.Observe(time.Since(start).Seconds(), c.Method(), route)
+	return nil
+}
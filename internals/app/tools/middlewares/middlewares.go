@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// Route of the requests not matching any route, so that unknown paths
+	// don't create new series or log entries
+	unmatchedRoute = "unmatched"
+	// Local holding the route of the requests not matching any route
+	routeLocal = "route"
+)
+
+// respond calls the next handlers and the error handler on their error, so
+// that the status of the response is final when the middleware reads it.
+//
+// Returns:
+//   - string: the path of the matched route, or unmatchedRoute.
+func respond(c *fiber.Ctx) string {
+	if err := c.Next(); err != nil {
+		// Fiber answers the paths and methods not registered with these
+		// errors, they are only seen by the first middleware to respond
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) && (fiberErr.Code == fiber.StatusNotFound || fiberErr.Code == fiber.StatusMethodNotAllowed) {
+			c.Locals(routeLocal, unmatchedRoute)
+		}
+
+		if err := c.App().ErrorHandler(c, err); err != nil {
+			_ = c.SendStatus(fiber.StatusInternalServerError)
+		}
+	}
+
+	if route, ok := c.Locals(routeLocal).(string); ok {
+		return route
+	}
+	return c.Route().Path
+}
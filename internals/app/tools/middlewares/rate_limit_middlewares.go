@@ -0,0 +1,146 @@
+package middlewares
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Headers of the rate limit, as in the IETF draft
+const (
+	headerRateLimitLimit     = "RateLimit-Limit"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+	headerRateLimitPolicy    = "RateLimit-Policy"
+)
+
+// RateLimitHeaders are the headers sent with the responses of the limited
+// routes, exposed to the browsers.
+var RateLimitHeaders = []string{
+	headerRateLimitLimit,
+	headerRateLimitRemaining,
+	headerRateLimitReset,
+	headerRateLimitPolicy,
+	fiber.HeaderRetryAfter,
+}
+
+type RateLimitMiddlewares struct {
+	cache  ports.CacheAdapters
+	config entities.RateLimitConfig
+}
+
+func NewRateLimitMiddlewares(cache ports.CacheAdapters, config entities.RateLimitConfig) *RateLimitMiddlewares {
+	return &RateLimitMiddlewares{
+		cache:  cache,
+		config: config,
+	}
+}
+
+// LimitLogin limits the login attempts of each email from an IP address, so
+// that the users behind the same address don't share the attempts, and of
+// each IP address with a looser limit.
+func (m *RateLimitMiddlewares) LimitLogin(c *fiber.Ctx) error {
+	credentials := new(entities.UserLogin)
+	// A body not valid is refused by the login
+	_ = json.Unmarshal(c.Body(), credentials)
+
+	// The emails are hashed to keep them out of the cache
+	email := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(credentials.Email))))
+	return m.limit(c,
+		counter{m.config.LoginIP(), "ip:" + c.IP()},
+		counter{m.config.Login(), "ip:" + c.IP() + ":email:" + hex.EncodeToString(email[:])},
+	)
+}
+
+// LimitGuest limits the requests without session of each IP address.
+func (m *RateLimitMiddlewares) LimitGuest(c *fiber.Ctx) error {
+	return m.limit(c, counter{m.config.Public(), "ip:" + c.IP()})
+}
+
+// LimitSession limits the requests of each IP address checked for a session,
+// so that the requests refused by AuthorizeUser are limited too. It precedes
+// AuthorizeUser.
+func (m *RateLimitMiddlewares) LimitSession(c *fiber.Ctx) error {
+	return m.limit(c, counter{m.config.Session(), "ip:" + c.IP()})
+}
+
+// LimitUser limits the requests of the authorized user, wherever they come
+// from, with a policy for the reads and one for the writes. It follows
+// AuthorizeUser.
+func (m *RateLimitMiddlewares) LimitUser(c *fiber.Ctx) error {
+	policy := m.config.Write()
+	if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+		policy = m.config.Read()
+	}
+
+	client := "ip:" + c.IP()
+	if user, ok := c.Locals("user").(*entities.User); ok {
+		client = "user:" + strconv.FormatUint(uint64(user.ID), 10)
+	}
+	return m.limit(c, counter{policy, client})
+}
+
+// counter is the count of the requests of a client with a policy.
+type counter struct {
+	policy entities.RateLimitPolicy
+	client string
+}
+
+// limit counts the request of the clients in the windows of their policies,
+// shared by the instances through the cache, and refuses it once a max is
+// reached. The headers are the ones of the policy with the fewest requests
+// remaining.
+func (m *RateLimitMiddlewares) limit(c *fiber.Ctx, counters ...counter) error {
+	headers := map[string]string{}
+	remaining := int64(-1)
+	for _, counter := range counters {
+		policy := counter.policy
+		if policy.Max <= 0 {
+			continue
+		}
+
+		count, ttl, err := m.cache.WithContext(c.UserContext()).Increment(policy.CacheKey(counter.client), policy.Window)
+		if err != nil {
+			// The requests are not refused because the cache is down
+			slog.WarnContext(c.UserContext(), "Error counting request", "policy", policy.Name, "error", err)
+			continue
+		}
+
+		left := max(int64(policy.Max)-count, 0)
+		if remaining >= 0 && left >= remaining && count <= int64(policy.Max) {
+			continue
+		}
+		remaining = left
+
+		// Seconds rounded up, so that the client doesn't retry too early
+		reset := strconv.FormatInt(int64((ttl+time.Second-1)/time.Second), 10)
+		headers = map[string]string{
+			headerRateLimitLimit:     strconv.Itoa(policy.Max),
+			headerRateLimitRemaining: strconv.FormatInt(left, 10),
+			headerRateLimitReset:     reset,
+			headerRateLimitPolicy:    fmt.Sprintf("%d;w=%d", policy.Max, int(policy.Window.Seconds())),
+		}
+
+		if count > int64(policy.Max) {
+			headers[fiber.HeaderRetryAfter] = reset
+			for key, value := range headers {
+				c.Set(key, value)
+			}
+			return fiber.ErrTooManyRequests
+		}
+	}
+
+	for key, value := range headers {
+		c.Set(key, value)
+	}
+	return c.Next()
+}
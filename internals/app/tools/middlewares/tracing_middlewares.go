@@ -0,0 +1,69 @@
+package middlewares
+
+import (
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingMiddlewares struct {
+	propagator propagation.TextMapPropagator
+}
+
+func NewTracingMiddlewares() *TracingMiddlewares {
+	return &TracingMiddlewares{
+		propagator: otel.GetTextMapPropagator(),
+	}
+}
+
+// TraceRequest starts the span of the request, child of the trace context of
+// the caller if any, and adds it to the context of the request so that the
+// spans of the handlers are its children.
+func (m *TracingMiddlewares) TraceRequest(c *fiber.Ctx) error {
+	ctx := m.propagator.Extract(c.UserContext(), headerCarrier{c})
+	ctx, span := tracing.Start(ctx, c.Method(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(c.Method()),
+			semconv.URLPath(c.Path()),
+			semconv.ClientAddress(c.IP()),
+		),
+	)
+	defer span.End()
+	c.SetUserContext(ctx)
+
+	route := respond(c)
+	status := c.Response().StatusCode()
+
+	// Named by route, the paths would make too many names
+	span.SetName(c.Method() + " " + route)
+	span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+	if status >= fiber.StatusInternalServerError {
+		tracing.RecordError(span, fiber.NewError(status))
+	}
+	return nil
+}
+
+// headerCarrier reads and writes the trace context in the request headers.
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h headerCarrier) Set(key string, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	var keys []string
+	h.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
@@ -1,12 +1,16 @@
 package middlewares
 
 import (
-	"log"
+	"log/slog"
 	"slices"
 
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/logging"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
 	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
 	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type UserMiddlewares struct {
@@ -24,80 +28,111 @@ func NewUserMiddlewares(http ports.HttpAdapters, userService ports.UserServices,
 }
 
 func (m *UserMiddlewares) AuthorizeUser(c *fiber.Ctx) error {
+	if !traceCheck(c, "UserMiddlewares.AuthorizeUser", m.authorizeUser) {
+		return m.http.Forbidden(c)
+	}
+	return c.Next()
+}
+
+func (m *UserMiddlewares) authorizeUser(c *fiber.Ctx) bool {
 	authorization := c.Cookies("Authorization")
 	if authorization == "" {
 		// Send Unauthorized response
-		log.Printf("@AuthorizeUser: Authorization cookie not found")
-		return m.http.Forbidden(c)
+		slog.DebugContext(c.UserContext(), "Authorization cookie not found")
+		return false
 	}
 
 	// Get session from Redis
-	session, err := m.userService.GetSessionByToken(authorization)
+	session, err := m.userService.GetSessionByToken(c.UserContext(), authorization)
 	if err != nil {
-		log.Printf("@AuthorizeUser: Error getting session: %v", err)
-		return m.http.Forbidden(c)
+		slog.WarnContext(c.UserContext(), "Error getting session", "error", err)
+		return false
 	}
 
 	// Check if it is the same IP and user agent
 	if session.IPAddress != c.IP() || session.UserAgent != c.Get("User-Agent") {
-		log.Printf("@AuthorizeUser: Session IP/User-Agent mismatch: %v", err)
-		return m.http.Forbidden(c)
+		slog.WarnContext(c.UserContext(), "Session IP or User-Agent mismatch", "user_id", session.UserID)
+		return false
 	}
 
 	// Get user
-	user, err := m.userService.GetUserForLogin(session.UserID)
+	user, err := m.userService.GetUserForLogin(c.UserContext(), session.UserID)
 	if err != nil {
-		log.Printf("@AuthorizeUser: Error getting user: %v", err)
-		return m.http.Forbidden(c)
+		slog.WarnContext(c.UserContext(), "Error getting user", "user_id", session.UserID, "error", err)
+		return false
 	}
 
-	// Set session
+	// Set session, the user ID is added to the records logged with the request
+	c.SetUserContext(logging.WithUserID(c.UserContext(), user.ID))
 	utils.SetLocals(c, "session", session)
 	utils.SetLocals(c, "user", user)
 	utils.SetLocals(c, "role", user.Role)
-	return c.Next()
+	return true
 }
 
 func (m *UserMiddlewares) CheckPermissions(c *fiber.Ctx) error {
+	if !traceCheck(c, "UserMiddlewares.CheckPermissions", m.checkPermissions) {
+		return m.http.Forbidden(c)
+	}
+	return c.Next()
+}
+
+func (m *UserMiddlewares) checkPermissions(c *fiber.Ctx) bool {
 	// get role
 	role := utils.GetLocalRole(c)
 	roleId := role.ID
 
 	if roleId == 0 {
-		return m.http.Forbidden(c)
+		return false
 	}
 
 	// get requested action
 	requestedAction, requestedTable := m.permissionsServices.GetRequestedActionAndTable(c)
 	if requestedAction == "" || requestedTable == "" {
-		log.Printf("@CheckPermissions: Requested action or table not found")
-		return m.http.Forbidden(c)
+		slog.WarnContext(c.UserContext(), "Requested action or table not found")
+		return false
 	}
 
 	// get tables
-	tables, err := m.permissionsServices.GetTableList()
+	tables, err := m.permissionsServices.GetTableList(c.UserContext())
 	if err != nil {
-		log.Printf("@CheckPermissions: Error getting table list: %v", err)
-		return m.http.Forbidden(c)
+		slog.ErrorContext(c.UserContext(), "Error getting table list", "error", err)
+		return false
 	}
 
 	// Check if the requested table is in the list of tables
 	if !slices.Contains(tables, requestedTable) {
-		return m.http.Forbidden(c)
+		return false
 	}
 
-	permission, err := m.permissionsServices.HasPermission(requestedTable, roleId, requestedAction)
+	permission, err := m.permissionsServices.HasPermission(c.UserContext(), requestedTable, roleId, requestedAction)
 	if err != nil {
-		log.Printf("@CheckPermissions: Error getting permission: %v", err)
-		return m.http.Forbidden(c)
+		slog.ErrorContext(c.UserContext(), "Error getting permission", "error", err)
+		return false
 	}
 
 	if permission == 0 {
-		log.Printf("@CheckPermissions: User doesn't have permission to access this resource: %v, %v", requestedTable, requestedAction)
-		return m.http.Forbidden(c)
+		slog.WarnContext(c.UserContext(), "Permission denied", "table", requestedTable, "action", requestedAction)
+		return false
 	}
 
 	// Set permission
 	utils.SetLocals(c, "permission", permission)
-	return c.Next()
+	return true
+}
+
+// traceCheck runs the check of a middleware in its own span, the handlers
+// after it are children of the span of the request and not of the check.
+func traceCheck(c *fiber.Ctx, name string, check func(c *fiber.Ctx) bool) bool {
+	parent := trace.SpanFromContext(c.UserContext())
+	ctx, span := tracing.Start(c.UserContext(), name)
+	defer span.End()
+
+	c.SetUserContext(ctx)
+	allowed := check(c)
+	span.SetAttributes(attribute.Bool("allowed", allowed))
+
+	// Keep the values set by the check, like the user ID
+	c.SetUserContext(trace.ContextWithSpan(c.UserContext(), parent))
+	return allowed
 }
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"errors"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/utils"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type WebhookMiddlewares struct {
+	Http     ports.HttpAdapters
+	Services ports.WebhookServices
+}
+
+func NewWebhookMiddlewares(http ports.HttpAdapters, services ports.WebhookServices) *WebhookMiddlewares {
+	return &WebhookMiddlewares{
+		Http:     http,
+		Services: services,
+	}
+}
+
+func (m *WebhookMiddlewares) GetWebhook(c *fiber.Ctx) error {
+	// Get the webhook ID from the API local params
+	id := utils.GetUintParam(c, "webhook_id")
+
+	// Retrieve the webhook from the database
+	webhook, err := m.Services.GetWebhookById(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.NewNotFoundError(i18n.MsgWebhookNotFound).Wrap(err)
+		}
+		return err
+	}
+
+	utils.SetLocals(c, "webhook", webhook)
+	return c.Next()
+}
@@ -0,0 +1,169 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Name of the security scheme of the protected routes
+const sessionAuth = "sessionAuth"
+
+// Route documents an endpoint registered in Fiber.
+type Route struct {
+	Method   string
+	Path     string // As registered, e.g. /protected/members/:id
+	Tag      string
+	Summary  string
+	Query    []string    // Names of the query parameters
+	Form     []string    // Fields of a multipart body, file is the uploaded file
+	Request  interface{} // Value of the JSON body, nil without body
+	Response interface{} // Value of the data of the response, nil without data
+	File     bool        // The response is a file download
+	Public   bool        // No session is required
+}
+
+// New creates a document served under server. The envelope is the value of
+// the body of every JSON response, whose data field holds the route response.
+func New(title string, version string, server string, envelope interface{}) *Document {
+	d := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Version: version},
+		Servers: []Server{{URL: server}},
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]SecurityScheme{
+				sessionAuth: {Type: "apiKey", In: "cookie", Name: "Authorization"},
+			},
+		},
+	}
+	d.envelope = d.schema(reflect.TypeOf(envelope)).Ref
+	return d
+}
+
+// Add documents a route.
+func (d *Document) Add(route Route) {
+	path, params := Path(route.Path)
+
+	operation := &Operation{
+		Summary:     route.Summary,
+		OperationID: strings.ToLower(route.Method) + strings.NewReplacer("/", "_", ":", "", "-", "_").Replace(route.Path),
+		Responses:   map[string]Response{},
+	}
+	if route.Tag != "" {
+		operation.Tags = []string{route.Tag}
+		d.addTag(route.Tag)
+	}
+	if !route.Public {
+		operation.Security = []map[string][]string{{sessionAuth: {}}}
+	}
+
+	for _, param := range params {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:     param,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "integer", Format: "int32"},
+		})
+	}
+	for _, query := range route.Query {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:   query,
+			In:     "query",
+			Schema: &Schema{Type: "string"},
+		})
+	}
+
+	switch {
+	case route.Request != nil:
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: d.schema(reflect.TypeOf(route.Request))}},
+		}
+	case len(route.Form) > 0:
+		form := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		for _, field := range route.Form {
+			form.Properties[field] = &Schema{Type: "string"}
+			if field == "file" {
+				form.Properties[field].Format = "binary"
+			}
+		}
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"multipart/form-data": {Schema: form}},
+		}
+	}
+
+	// Success
+	if route.File {
+		operation.Responses["200"] = Response{
+			Description: "File",
+			Content:     map[string]MediaType{"application/octet-stream": {Schema: &Schema{Type: "string", Format: "binary"}}},
+		}
+	} else {
+		envelope := &Schema{Ref: d.envelope}
+		if route.Response != nil {
+			envelope = &Schema{AllOf: []*Schema{envelope, {
+				Type:       "object",
+				Properties: map[string]*Schema{"data": d.schema(reflect.TypeOf(route.Response))},
+			}}}
+		}
+		operation.Responses["200"] = Response{
+			Description: "OK",
+			Content:     map[string]MediaType{"application/json": {Schema: envelope}},
+		}
+	}
+
+	// Errors, with the code and the ID of the request
+	failure := func(description string) Response {
+		return Response{
+			Description: description,
+			Content:     map[string]MediaType{"application/json": {Schema: &Schema{Ref: d.envelope}}},
+		}
+	}
+	operation.Responses["400"] = failure("Bad request")
+	if !route.Public {
+		operation.Responses["401"] = failure("Unauthorized")
+		operation.Responses["403"] = failure("Forbidden")
+	}
+	if len(params) > 0 {
+		operation.Responses["404"] = failure("Not found")
+	}
+	operation.Responses["429"] = failure("Too many requests")
+	operation.Responses["500"] = failure("Internal error")
+
+	if d.Paths[path] == nil {
+		d.Paths[path] = PathItem{}
+	}
+	d.Paths[path][strings.ToLower(route.Method)] = operation
+}
+
+// Has reports whether a route, with the path as registered, is documented.
+func (d *Document) Has(method string, path string) bool {
+	openapiPath, _ := Path(path)
+	_, ok := d.Paths[openapiPath][strings.ToLower(method)]
+	return ok
+}
+
+// Path converts a Fiber path to an OpenAPI one, e.g. /members/:id to
+// /members/{id}, and returns the names of its parameters.
+func Path(path string) (string, []string) {
+	var params []string
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func (d *Document) addTag(name string) {
+	for _, tag := range d.Tags {
+		if tag.Name == name {
+			return
+		}
+	}
+	d.Tags = append(d.Tags, Tag{Name: name})
+}
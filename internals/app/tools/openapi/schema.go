@@ -0,0 +1,99 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+)
+
+// schema returns the schema of a Go type as it's encoded in JSON. Named
+// structs are added to the components and referenced.
+func (d *Document) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case deletedAtType:
+		return &Schema{Type: "string", Format: "date-time", Nullable: true}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := d.schema(t.Elem())
+		if schema.Ref != "" {
+			return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: d.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.object(t)
+		}
+		if _, ok := d.Components.Schemas[t.Name()]; !ok {
+			// Registered before the fields, for the types referencing themselves
+			d.Components.Schemas[t.Name()] = &Schema{}
+			*d.Components.Schemas[t.Name()] = *d.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	}
+
+	// Interfaces can hold any value
+	return &Schema{}
+}
+
+// object returns the schema of the fields of a struct.
+func (d *Document) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	d.fields(t, schema.Properties)
+	return schema
+}
+
+func (d *Document) fields(t reflect.Type, properties map[string]*Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// Fields of embedded structs, e.g. gorm.Model, are promoted
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			d.fields(field.Type, properties)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = d.schema(field.Type)
+	}
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/tracing"
+)
+
+// Job is a task run periodically by the Scheduler.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+type Scheduler struct {
+	jobs []Job
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler without jobs.
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		stop: make(chan struct{}),
+	}
+}
+
+// Every registers a job run at every interval.
+//
+// Parameters:
+//   - name: the name of the job, used in logs.
+//   - interval: the time between two runs.
+//   - run: the function to run, with the context of the span of the run.
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, Job{
+		Name:     name,
+		Interval: interval,
+		Run:      run,
+	})
+}
+
+// Start runs every registered job once and then at its interval.
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+}
+
+// Stop stops the jobs and waits for the running ones to end.
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(job)
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run runs a job in its own trace, the runs are not part of a request.
+func (s *Scheduler) run(job Job) {
+	ctx, span := tracing.Start(context.Background(), "job "+job.Name)
+	defer span.End()
+
+	if err := job.Run(ctx); err != nil {
+		tracing.RecordError(span, err)
+		slog.ErrorContext(ctx, "Error running job", "job", job.Name, "error", err)
+	}
+}
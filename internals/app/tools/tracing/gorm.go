@@ -0,0 +1,80 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// Key of the context of the statement before its span started
+const gormParentKey = "tracing:parent"
+
+// GormPlugin traces the queries, as children of the span of the context given
+// to db.WithContext. The SQL is recorded without its values.
+type GormPlugin struct{}
+
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", p.before("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", p.after),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", p.before("query")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", p.after),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", p.before("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", p.after),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", p.before("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", p.after),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", p.before("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", p.after),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", p.before("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", p.after),
+	)
+}
+
+// before starts the span of the statement and keeps the context it replaces.
+func (p *GormPlugin) before(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		parent := db.Statement.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+
+		ctx, _ := Start(parent, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemSqlite, semconv.DBOperationName(operation)),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(gormParentKey, parent)
+	}
+}
+
+// after ends the span of the statement and restores the context, so that the
+// statements chained to it aren't children of the ended span.
+func (p *GormPlugin) after(db *gorm.DB) {
+	span := trace.SpanFromContext(db.Statement.Context)
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		semconv.DBCollectionName(db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		RecordError(span, db.Error)
+	}
+	span.End()
+
+	if parent, ok := db.InstanceGet(gormParentKey); ok {
+		db.Statement.Context = parent.(context.Context)
+	}
+}
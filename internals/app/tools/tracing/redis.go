@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook traces the commands, as children of the span of the context given
+// to the client. The arguments are not recorded, the keys hold the session
+// tokens.
+type RedisHook struct{}
+
+func NewRedisHook() *RedisHook {
+	return &RedisHook{}
+}
+
+func (h *RedisHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, _ = Start(ctx, "redis."+cmd.Name(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemRedis, semconv.DBOperationName(cmd.Name())),
+	)
+	return ctx, nil
+}
+
+func (h *RedisHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	end(trace.SpanFromContext(ctx), cmd.Err())
+	return nil
+}
+
+func (h *RedisHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	ctx, _ = Start(ctx, "redis.pipeline",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemRedis, attribute.Int("db.redis.commands", len(cmds))),
+	)
+	return ctx, nil
+}
+
+func (h *RedisHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if err = cmd.Err(); err != nil && !errors.Is(err, redis.Nil) {
+			break
+		}
+	}
+	end(trace.SpanFromContext(ctx), err)
+	return nil
+}
+
+// end ends the span of a command, a missing key is not an error.
+func end(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, redis.Nil) {
+		RecordError(span, err)
+	}
+	span.End()
+}
@@ -0,0 +1,32 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name of the tracer of the application
+const tracerName = "github.com/Erodot0/gym-memeber-management"
+
+// Start starts a span, child of the span of the context if any. The spans
+// are dropped until a tracer provider is set.
+//
+// Parameters:
+//   - ctx: the context of the parent span.
+//   - name: the name of the span, e.g. MemberServices.GetAllMembers.
+//
+// Returns:
+//   - context.Context: the context of the new span, for its children.
+//   - trace.Span: the span, ended by the caller.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// RecordError records the error in the span and marks it as failed.
+func RecordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"log/slog"
+	"sort"
+
+	"github.com/goccy/go-json"
+)
+
+// ZipJSON creates a zip archive with a JSON file for each entry.
+//
+// Parameters:
+//   - files: the data of each file, keyed by file name.
+//
+// Returns:
+//   - []byte: the zip archive.
+//   - error: an error if the archive can't be created.
+func ZipJSON(files map[string]interface{}) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buffer := new(bytes.Buffer)
+	archive := zip.NewWriter(buffer)
+	for _, name := range names {
+		data, err := json.MarshalIndent(files[name], "", "  ")
+		if err != nil {
+			slog.Error("Error marshaling archive file", "file", name, "error", err)
+			return nil, err
+		}
+
+		file, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := file.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
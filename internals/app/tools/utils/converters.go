@@ -1,7 +1,7 @@
 package utils
 
 import (
-	"log"
+	"log/slog"
 	"strconv"
 )
 
@@ -11,7 +11,7 @@ import (
 func StringToUint(str string) (uint, error) {
 	idUint, err := strconv.ParseUint(str, 10, 32)
 	if err != nil {
-		log.Printf("@ConvertStringToUint: Error converting string to uint: %v", err)
+		slog.Debug("Error converting string to uint", "value", str, "error", err)
 		return 0, err
 	}
 	return uint(idUint), nil
@@ -40,6 +40,30 @@ func GetLocalMember(c *fiber.Ctx) *entities.Member {
 	return c.Locals("member").(*entities.Member)
 }
 
+// GetLocalHousehold retrieves the local household from the fiber context.
+//
+// Parameter: c *fiber.Ctx
+// Return type: *entities.Household
+func GetLocalHousehold(c *fiber.Ctx) *entities.Household {
+	return c.Locals("household").(*entities.Household)
+}
+
+// GetLocalGuest retrieves the local guest from the fiber context.
+//
+// Parameter: c *fiber.Ctx
+// Return type: *entities.Guest
+func GetLocalGuest(c *fiber.Ctx) *entities.Guest {
+	return c.Locals("guest").(*entities.Guest)
+}
+
+// GetLocalWebhook retrieves the local webhook from the fiber context.
+//
+// Parameter: c *fiber.Ctx
+// Return type: *entities.Webhook
+func GetLocalWebhook(c *fiber.Ctx) *entities.Webhook {
+	return c.Locals("webhook").(*entities.Webhook)
+}
+
 // GetLocalRole retrieves the local role from the fiber context.
 //
 // Parameter: c *fiber.Ctx
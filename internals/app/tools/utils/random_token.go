@@ -3,7 +3,7 @@ package utils
 import (
 	"crypto/rand"
 	"encoding/base64"
-	"log"
+	"log/slog"
 )
 
 // GenerateRandomToken generates a random token.
@@ -13,7 +13,7 @@ func  GenerateRandomToken(lenght int) (string, error){
 	bytes := make([]byte, lenght)
 
 	if _, err := rand.Read(bytes); err != nil {
-		log.Printf("@GenerateRandomToken Error generating random token: %v", err)
+		slog.Error("Error generating random token", "error", err)
 		return "", err
 	}
 
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/i18n"
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadTable reads the rows of a CSV or XLSX file, the format is chosen from
+// the file extension.
+//
+// Parameters:
+//   - filename: the name of the file.
+//   - r: the content of the file.
+//
+// Returns:
+//   - [][]string: the rows of the file, header included.
+//   - error: an error if the format is not supported or the file can't be read.
+func ReadTable(filename string, r io.Reader) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return readCSV(r)
+	case ".xlsx":
+		return readXLSX(r)
+	default:
+		return nil, entities.NewValidationError(i18n.MsgTableFormatInvalid)
+	}
+}
+
+// readCSV reads a CSV file separated by commas or, as exported by the
+// Italian versions of Excel, by semicolons.
+func readCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	header, _ := bufio.NewReader(bytes.NewReader(data)).ReadString('\n')
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	if strings.Count(header, ";") > strings.Count(header, ",") {
+		reader.Comma = ';'
+	}
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		slog.Warn("Error reading csv", "error", err)
+		return nil, entities.NewValidationError(i18n.MsgTableCSVInvalid)
+	}
+	return rows, nil
+}
+
+// readXLSX reads the first sheet of an XLSX file.
+func readXLSX(r io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		slog.Warn("Error opening xlsx", "error", err)
+		return nil, entities.NewValidationError(i18n.MsgTableXLSXInvalid)
+	}
+	defer file.Close()
+
+	rows, err := file.GetRows(file.GetSheetName(0))
+	if err != nil {
+		slog.Warn("Error reading xlsx", "error", err)
+		return nil, entities.NewValidationError(i18n.MsgTableXLSXInvalid)
+	}
+	return rows, nil
+}
@@ -0,0 +1,5 @@
+package routes
+
+func (r *Routes) RegisterAuditRoutes() {
+	r.protectedRoutes.Get("/audits", r.auditHandlers.GetAuditLogs)
+}
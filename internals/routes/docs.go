@@ -0,0 +1,150 @@
+package routes
+
+import (
+	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Query parameters shared by the routes
+var (
+	periodQuery      = []string{"from", "to"}
+	memberQuery      = []string{"search", "subscription_type", "household_id", "active", "format"}
+	expiringQuery    = []string{"days", "format"}
+	revenueQuery     = []string{"from", "to", "format"}
+	auditQuery       = []string{"user_id", "action", "entity", "entity_id", "from", "to", "limit", "offset"}
+	importForm       = []string{"file", "columns", "dry_run"}
+	memberDataFormat = []string{"format"}
+)
+
+// apiRoutes documents every route registered by the Register functions, with
+// the paths relative to /api/v1. A route registered but missing here fails
+// the tests.
+var apiRoutes = []openapi.Route{
+	// Auth
+	{Method: fiber.MethodPost, Path: "/auth/login", Tag: "Auth", Summary: "Log in", Request: entities.UserLogin{}, Response: []entities.User{}, Public: true},
+	{Method: fiber.MethodPost, Path: "/auth/logout", Tag: "Auth", Summary: "Log out"},
+
+	// Docs
+	{Method: fiber.MethodGet, Path: "/public/docs", Tag: "Docs", Summary: "Swagger UI", File: true, Public: true},
+	{Method: fiber.MethodGet, Path: "/public/docs/openapi.json", Tag: "Docs", Summary: "OpenAPI specification", File: true, Public: true},
+
+	// Exports
+	{Method: fiber.MethodGet, Path: "/protected/members/export", Tag: "Exports", Summary: "Export the members", Query: memberQuery, File: true},
+	{Method: fiber.MethodGet, Path: "/protected/subscriptions/expiring/export", Tag: "Exports", Summary: "Export the expiring subscriptions", Query: expiringQuery, File: true},
+	{Method: fiber.MethodGet, Path: "/protected/subscriptions/revenue/export", Tag: "Exports", Summary: "Export the revenue", Query: revenueQuery, File: true},
+
+	// Members
+	{Method: fiber.MethodPost, Path: "/protected/members", Tag: "Members", Summary: "Create a member", Request: entities.Member{}, Response: []entities.Member{}},
+	{Method: fiber.MethodGet, Path: "/protected/members", Tag: "Members", Summary: "List the members", Response: []entities.Member{}},
+	{Method: fiber.MethodGet, Path: "/protected/members/trash", Tag: "Members", Summary: "List the deleted members", Response: []entities.Member{}},
+	{Method: fiber.MethodPost, Path: "/protected/members/import", Tag: "Members", Summary: "Import members from a file", Form: importForm, Response: entities.ImportReport{}},
+	{Method: fiber.MethodPost, Path: "/protected/members/:id/restore", Tag: "Members", Summary: "Restore a deleted member", Response: []entities.Member{}},
+	{Method: fiber.MethodGet, Path: "/protected/members/:id", Tag: "Members", Summary: "Get a member", Response: []entities.Member{}},
+	{Method: fiber.MethodPut, Path: "/protected/members/:id", Tag: "Members", Summary: "Update a member", Request: entities.UpdateMember{}, Response: []entities.Member{}},
+	{Method: fiber.MethodDelete, Path: "/protected/members/:id", Tag: "Members", Summary: "Delete a member"},
+
+	// Personal data
+	{Method: fiber.MethodGet, Path: "/protected/members/:id/export", Tag: "Privacy", Summary: "Export the personal data of a member", Query: memberDataFormat, Response: entities.MemberExport{}},
+	{Method: fiber.MethodDelete, Path: "/protected/members/:id/personal-data", Tag: "Privacy", Summary: "Anonymize the personal data of a member"},
+	{Method: fiber.MethodGet, Path: "/protected/members/:id/consents", Tag: "Privacy", Summary: "List the consents of a member", Response: []entities.Consent{}},
+	{Method: fiber.MethodPost, Path: "/protected/members/:id/consents", Tag: "Privacy", Summary: "Give a consent", Request: entities.Consent{}, Response: []entities.Consent{}},
+	{Method: fiber.MethodDelete, Path: "/protected/members/:id/consents/:consent_id", Tag: "Privacy", Summary: "Revoke a consent", Response: []entities.Consent{}},
+
+	// Notifications
+	{Method: fiber.MethodGet, Path: "/protected/members/:id/notifications", Tag: "Notifications", Summary: "List the notifications sent to a member", Response: []entities.Notification{}},
+	{Method: fiber.MethodPut, Path: "/protected/members/:id/preferences", Tag: "Notifications", Summary: "Update the contact preferences of a member", Request: entities.NotificationPreferences{}, Response: []entities.Contacts{}},
+
+	// Subscriptions
+	{Method: fiber.MethodPost, Path: "/protected/members/:id/subscriptions", Tag: "Subscriptions", Summary: "Create a subscription", Query: []string{"on_overlap"}, Request: entities.Subscription{}, Response: entities.Subscription{}},
+	{Method: fiber.MethodGet, Path: "/protected/members/:id/subscriptions", Tag: "Subscriptions", Summary: "List the subscriptions of a member", Response: []entities.Subscription{}},
+	{Method: fiber.MethodGet, Path: "/protected/members/:id/subscriptions/trash", Tag: "Subscriptions", Summary: "List the deleted subscriptions of a member", Response: []entities.Subscription{}},
+	{Method: fiber.MethodGet, Path: "/protected/members/:id/subscriptions/:sub_id", Tag: "Subscriptions", Summary: "Get a subscription", Response: []entities.Subscription{}},
+	{Method: fiber.MethodPut, Path: "/protected/members/:id/subscriptions/:sub_id", Tag: "Subscriptions", Summary: "Update a subscription", Request: entities.UpdateSubscription{}, Response: []entities.Subscription{}},
+	{Method: fiber.MethodDelete, Path: "/protected/members/:id/subscriptions/:sub_id", Tag: "Subscriptions", Summary: "Delete a subscription"},
+	{Method: fiber.MethodPost, Path: "/protected/members/:id/subscriptions/:sub_id/renew", Tag: "Subscriptions", Summary: "Renew a subscription", Request: entities.RenewSubscription{}, Response: entities.Subscription{}},
+	{Method: fiber.MethodPost, Path: "/protected/members/:id/subscriptions/:sub_id/restore", Tag: "Subscriptions", Summary: "Restore a deleted subscription", Response: []entities.Subscription{}},
+
+	// Users
+	{Method: fiber.MethodPost, Path: "/protected/users", Tag: "Users", Summary: "Create a user", Request: entities.User{}, Response: []entities.User{}},
+	{Method: fiber.MethodGet, Path: "/protected/users", Tag: "Users", Summary: "List the users", Response: []entities.User{}},
+	{Method: fiber.MethodPut, Path: "/protected/users/:id", Tag: "Users", Summary: "Update a user", Request: entities.UpdateUser{}, Response: []entities.User{}},
+	{Method: fiber.MethodDelete, Path: "/protected/users/:id", Tag: "Users", Summary: "Delete a user"},
+
+	// Roles
+	{Method: fiber.MethodPost, Path: "/protected/roles", Tag: "Roles", Summary: "Create a role", Request: entities.Roles{}, Response: []entities.Roles{}},
+	{Method: fiber.MethodGet, Path: "/protected/roles", Tag: "Roles", Summary: "List the roles", Response: []entities.Roles{}},
+	{Method: fiber.MethodGet, Path: "/protected/roles/:id", Tag: "Roles", Summary: "Get a role", Response: []entities.Roles{}},
+	{Method: fiber.MethodPut, Path: "/protected/roles/:id", Tag: "Roles", Summary: "Update a role", Request: entities.UpdateRoles{}, Response: []entities.Roles{}},
+	{Method: fiber.MethodDelete, Path: "/protected/roles/:id", Tag: "Roles", Summary: "Delete a role"},
+	{Method: fiber.MethodPost, Path: "/protected/roles/:id/permissions", Tag: "Roles", Summary: "Create a permission of a role", Request: entities.Permissions{}, Response: []entities.Permissions{}},
+	{Method: fiber.MethodGet, Path: "/protected/roles/:id/permissions", Tag: "Roles", Summary: "List the permissions of a role", Response: []entities.Permissions{}},
+	{Method: fiber.MethodPut, Path: "/protected/roles/permissions/:perm_id", Tag: "Roles", Summary: "Update a permission of a role", Request: entities.UpdatePermissions{}, Response: []entities.Permissions{}},
+	{Method: fiber.MethodDelete, Path: "/protected/roles/permissions/:perm_id", Tag: "Roles", Summary: "Delete a permission of a role"},
+
+	// Permissions
+	{Method: fiber.MethodPost, Path: "/protected/permissions", Tag: "Permissions", Summary: "Create a permission", Request: entities.Permissions{}, Response: []entities.Permissions{}},
+	{Method: fiber.MethodGet, Path: "/protected/permissions", Tag: "Permissions", Summary: "List the permissions", Response: []entities.Permissions{}},
+	{Method: fiber.MethodGet, Path: "/protected/permissions/:perm_id", Tag: "Permissions", Summary: "Get a permission", Response: []entities.Permissions{}},
+	{Method: fiber.MethodPut, Path: "/protected/permissions/:perm_id", Tag: "Permissions", Summary: "Update a permission", Request: entities.UpdatePermissions{}, Response: []entities.Permissions{}},
+	{Method: fiber.MethodDelete, Path: "/protected/permissions/:perm_id", Tag: "Permissions", Summary: "Delete a permission"},
+
+	// Households
+	{Method: fiber.MethodPost, Path: "/protected/households", Tag: "Households", Summary: "Create a household", Request: entities.Household{}, Response: []entities.Household{}},
+	{Method: fiber.MethodGet, Path: "/protected/households", Tag: "Households", Summary: "List the households", Response: []entities.Household{}},
+	{Method: fiber.MethodGet, Path: "/protected/households/:household_id", Tag: "Households", Summary: "Get a household", Response: []entities.Household{}},
+	{Method: fiber.MethodPut, Path: "/protected/households/:household_id", Tag: "Households", Summary: "Update a household", Request: entities.UpdateHousehold{}, Response: []entities.Household{}},
+	{Method: fiber.MethodDelete, Path: "/protected/households/:household_id", Tag: "Households", Summary: "Delete a household"},
+	{Method: fiber.MethodPost, Path: "/protected/households/:household_id/members", Tag: "Households", Summary: "Add a member to a household", Request: entities.HouseholdMember{}, Response: []entities.Member{}},
+	{Method: fiber.MethodDelete, Path: "/protected/households/:household_id/members/:member_id", Tag: "Households", Summary: "Remove a member from a household"},
+
+	// Promotions
+	{Method: fiber.MethodPost, Path: "/protected/promotions", Tag: "Promotions", Summary: "Create a promotion", Request: entities.Promotion{}, Response: []entities.Promotion{}},
+	{Method: fiber.MethodGet, Path: "/protected/promotions", Tag: "Promotions", Summary: "List the promotions", Response: []entities.Promotion{}},
+	{Method: fiber.MethodGet, Path: "/protected/promotions/report", Tag: "Promotions", Summary: "Sum up the redemptions", Response: []entities.PromotionReport{}},
+	{Method: fiber.MethodGet, Path: "/protected/promotions/:id", Tag: "Promotions", Summary: "Get a promotion", Response: []entities.Promotion{}},
+	{Method: fiber.MethodPut, Path: "/protected/promotions/:id", Tag: "Promotions", Summary: "Update a promotion", Request: entities.UpdatePromotion{}, Response: []entities.Promotion{}},
+	{Method: fiber.MethodDelete, Path: "/protected/promotions/:id", Tag: "Promotions", Summary: "Delete a promotion"},
+	{Method: fiber.MethodGet, Path: "/protected/promotions/:id/redemptions", Tag: "Promotions", Summary: "List the redemptions of a promotion", Response: []entities.PromotionRedemption{}},
+
+	// Guests
+	{Method: fiber.MethodPost, Path: "/protected/guests", Tag: "Guests", Summary: "Create a guest", Request: entities.Guest{}, Response: []entities.Guest{}},
+	{Method: fiber.MethodGet, Path: "/protected/guests", Tag: "Guests", Summary: "List the guests", Response: []entities.Guest{}},
+	{Method: fiber.MethodGet, Path: "/protected/guests/:guest_id", Tag: "Guests", Summary: "Get a guest", Response: []entities.Guest{}},
+	{Method: fiber.MethodPut, Path: "/protected/guests/:guest_id", Tag: "Guests", Summary: "Update a guest", Request: entities.UpdateGuest{}, Response: []entities.Guest{}},
+	{Method: fiber.MethodDelete, Path: "/protected/guests/:guest_id", Tag: "Guests", Summary: "Delete a guest"},
+	{Method: fiber.MethodPost, Path: "/protected/guests/:guest_id/convert", Tag: "Guests", Summary: "Enroll a guest as a member", Request: entities.Member{}, Response: []entities.Member{}},
+	{Method: fiber.MethodPost, Path: "/protected/guests/:guest_id/passes", Tag: "Guests", Summary: "Create a guest pass", Request: entities.GuestPass{}, Response: []entities.GuestPass{}},
+	{Method: fiber.MethodGet, Path: "/protected/guests/:guest_id/passes", Tag: "Guests", Summary: "List the passes of a guest", Response: []entities.GuestPass{}},
+	{Method: fiber.MethodPost, Path: "/protected/guests/:guest_id/passes/:pass_id/visits", Tag: "Guests", Summary: "Record a visit", Response: []entities.GuestPass{}},
+
+	// Audits
+	{Method: fiber.MethodGet, Path: "/protected/audits", Tag: "Audits", Summary: "List the audit logs", Query: auditQuery, Response: entities.AuditLogs{}},
+
+	// Reports
+	{Method: fiber.MethodGet, Path: "/protected/subscriptions/reports/revenue", Tag: "Reports", Summary: "Monthly revenue", Query: periodQuery, Response: entities.Report{}},
+	{Method: fiber.MethodGet, Path: "/protected/subscriptions/reports/growth", Tag: "Reports", Summary: "Member growth", Query: periodQuery, Response: entities.Report{}},
+	{Method: fiber.MethodGet, Path: "/protected/subscriptions/reports/active", Tag: "Reports", Summary: "Active members", Query: periodQuery, Response: entities.Report{}},
+	{Method: fiber.MethodGet, Path: "/protected/subscriptions/reports/mix", Tag: "Reports", Summary: "Subscription mix", Query: periodQuery, Response: entities.Report{}},
+	{Method: fiber.MethodGet, Path: "/protected/subscriptions/reports/lifetime", Tag: "Reports", Summary: "Member lifetime", Query: periodQuery, Response: entities.Report{}},
+	{Method: fiber.MethodGet, Path: "/protected/subscriptions/reports/retention", Tag: "Reports", Summary: "Retention by cohort", Query: periodQuery, Response: entities.Report{}},
+
+	// Webhooks
+	{Method: fiber.MethodPost, Path: "/protected/webhooks", Tag: "Webhooks", Summary: "Create a webhook", Request: entities.NewWebhook{}, Response: []entities.NewWebhook{}},
+	{Method: fiber.MethodGet, Path: "/protected/webhooks", Tag: "Webhooks", Summary: "List the webhooks", Response: []entities.Webhook{}},
+	{Method: fiber.MethodGet, Path: "/protected/webhooks/:webhook_id", Tag: "Webhooks", Summary: "Get a webhook", Response: []entities.Webhook{}},
+	{Method: fiber.MethodPut, Path: "/protected/webhooks/:webhook_id", Tag: "Webhooks", Summary: "Update a webhook", Request: entities.UpdateWebhook{}, Response: []entities.Webhook{}},
+	{Method: fiber.MethodDelete, Path: "/protected/webhooks/:webhook_id", Tag: "Webhooks", Summary: "Delete a webhook"},
+	{Method: fiber.MethodGet, Path: "/protected/webhooks/:webhook_id/deliveries", Tag: "Webhooks", Summary: "List the deliveries of a webhook", Query: []string{"status"}, Response: []entities.WebhookDelivery{}},
+	{Method: fiber.MethodPost, Path: "/protected/webhooks/:webhook_id/deliveries/:delivery_id/retry", Tag: "Webhooks", Summary: "Retry a delivery", Response: []entities.WebhookDelivery{}},
+}
+
+// NewSpec generates the OpenAPI document of the API.
+func NewSpec() *openapi.Document {
+	spec := openapi.New("Gym Member Management API", "1.0.0", "/api/v1", secondary.Response{})
+	for _, route := range apiRoutes {
+		spec.Add(route)
+	}
+	return spec
+}
@@ -0,0 +1,6 @@
+package routes
+
+func (r *Routes) RegisterDocsRoutes() {
+	r.publicRoutes.Get("/docs", r.docsHandlers.GetSwaggerUI)
+	r.publicRoutes.Get("/docs/openapi.json", r.docsHandlers.GetSpec)
+}
@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// registeredRoutes registers every route, without services, and returns them
+// with the paths relative to /api/v1.
+func registeredRoutes() []fiber.Route {
+	app := fiber.New()
+	v1 := app.Group("/api/v1")
+	r := &Routes{
+		app:             app,
+		authRoutes:      v1.Group("/auth"),
+		publicRoutes:    v1.Group("/public"),
+		protectedRoutes: v1.Group("/protected"),
+	}
+	r.RegisterRoutes()
+
+	var routes []fiber.Route
+	for _, route := range app.GetRoutes(true) {
+		// Fiber registers a HEAD route for every GET one
+		if route.Method == fiber.MethodHead {
+			continue
+		}
+		route.Path = strings.TrimPrefix(route.Path, "/api/v1")
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+func TestSpecDocumentsEveryRoute(t *testing.T) {
+	spec := NewSpec()
+	routes := registeredRoutes()
+	if len(routes) == 0 {
+		t.Fatal("no route registered")
+	}
+
+	for _, route := range routes {
+		if !spec.Has(route.Method, route.Path) {
+			t.Errorf("%s %s is registered but missing from the OpenAPI specification", route.Method, route.Path)
+		}
+	}
+}
+
+func TestSpecDocumentsOnlyRegisteredRoutes(t *testing.T) {
+	registered := map[string]bool{}
+	for _, route := range registeredRoutes() {
+		registered[route.Method+" "+route.Path] = true
+	}
+
+	for _, route := range apiRoutes {
+		if !registered[route.Method+" "+route.Path] {
+			t.Errorf("%s %s is in the OpenAPI specification but is not registered", route.Method, route.Path)
+		}
+	}
+
+	if got, want := len(NewSpec().Paths), countPaths(apiRoutes); got != want {
+		t.Errorf("the specification has %d paths, want %d", got, want)
+	}
+}
+
+func countPaths(routes []openapi.Route) int {
+	paths := map[string]bool{}
+	for _, route := range routes {
+		path, _ := openapi.Path(route.Path)
+		paths[path] = true
+	}
+	return len(paths)
+}
@@ -0,0 +1,7 @@
+package routes
+
+func (r *Routes) RegisterExportRoutes() {
+	r.protectedRoutes.Get("/members/export", r.exportHandlers.ExportMembers)
+	r.protectedRoutes.Get("/subscriptions/expiring/export", r.exportHandlers.ExportExpiringSubscriptions)
+	r.protectedRoutes.Get("/subscriptions/revenue/export", r.exportHandlers.ExportRevenue)
+}
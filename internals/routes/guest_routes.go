@@ -0,0 +1,16 @@
+package routes
+
+func (r *Routes) RegisterGuestRoutes() {
+	r.protectedRoutes.Post("/guests", r.guestHandlers.CreateGuest)
+	r.protectedRoutes.Get("/guests", r.guestHandlers.GetGuests)
+
+	r.protectedRoutes.Get("/guests/:guest_id", r.guestMiddlewares.GetGuest, r.guestHandlers.GetGuestById)
+	r.protectedRoutes.Put("/guests/:guest_id", r.guestMiddlewares.GetGuest, r.guestHandlers.UpdateGuest)
+	r.protectedRoutes.Delete("/guests/:guest_id", r.guestMiddlewares.GetGuest, r.guestHandlers.DeleteGuest)
+	r.protectedRoutes.Post("/guests/:guest_id/convert", r.guestMiddlewares.GetGuest, r.guestHandlers.ConvertGuest)
+
+	// Passes
+	r.protectedRoutes.Post("/guests/:guest_id/passes", r.guestMiddlewares.GetGuest, r.guestHandlers.CreateGuestPass)
+	r.protectedRoutes.Get("/guests/:guest_id/passes", r.guestMiddlewares.GetGuest, r.guestHandlers.GetGuestPasses)
+	r.protectedRoutes.Post("/guests/:guest_id/passes/:pass_id/visits", r.guestMiddlewares.GetGuest, r.guestHandlers.RegisterGuestVisit)
+}
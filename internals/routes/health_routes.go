@@ -0,0 +1,11 @@
+package routes
+
+import "github.com/gofiber/fiber/v2"
+
+// RegisterAdminRoutes registers the health checks and the metrics, outside of
+// the API, on the router of the admin server.
+func (r *Routes) RegisterAdminRoutes(admin fiber.Router) {
+	admin.Get("/healthz", r.healthHandlers.GetLiveness)
+	admin.Get("/readyz", r.healthHandlers.GetReadiness)
+	admin.Get("/metrics", r.healthHandlers.GetMetrics)
+}
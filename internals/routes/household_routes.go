@@ -0,0 +1,14 @@
+package routes
+
+func (r *Routes) RegisterHouseholdRoutes() {
+	r.protectedRoutes.Post("/households", r.householdHandlers.CreateHousehold)
+	r.protectedRoutes.Get("/households", r.householdHandlers.GetHouseholds)
+
+	r.protectedRoutes.Get("/households/:household_id", r.householdMiddlewares.GetHousehold, r.householdHandlers.GetHouseholdById)
+	r.protectedRoutes.Put("/households/:household_id", r.householdMiddlewares.GetHousehold, r.householdHandlers.UpdateHousehold)
+	r.protectedRoutes.Delete("/households/:household_id", r.householdMiddlewares.GetHousehold, r.householdHandlers.DeleteHousehold)
+
+	// Members
+	r.protectedRoutes.Post("/households/:household_id/members", r.householdMiddlewares.GetHousehold, r.householdHandlers.AddHouseholdMember)
+	r.protectedRoutes.Delete("/households/:household_id/members/:member_id", r.householdMiddlewares.GetHousehold, r.householdHandlers.RemoveHouseholdMember)
+}
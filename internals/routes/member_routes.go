@@ -3,6 +3,20 @@ package routes
 func (r *Routes) RegisterMemberRoutes() {
 	r.protectedRoutes.Post("/members", r.memberHandlers.CreateMember)
 	r.protectedRoutes.Get("/members", r.memberHandlers.GetMembers)
+	r.protectedRoutes.Get("/members/trash", r.memberHandlers.GetDeletedMembers)
+	r.protectedRoutes.Post("/members/import", r.importHandlers.ImportMembers)
+	r.protectedRoutes.Post("/members/:id/restore", r.memberHandlers.RestoreMember)
+
+	// Personal data
+	r.protectedRoutes.Get("/members/:id/export", r.privacyHandlers.ExportMember)
+	r.protectedRoutes.Delete("/members/:id/personal-data", r.privacyHandlers.EraseMember)
+	r.protectedRoutes.Get("/members/:id/consents", r.memberMiddlewares.GetMember, r.privacyHandlers.GetMemberConsents)
+	r.protectedRoutes.Post("/members/:id/consents", r.memberMiddlewares.GetMember, r.privacyHandlers.GiveMemberConsent)
+	r.protectedRoutes.Delete("/members/:id/consents/:consent_id", r.memberMiddlewares.GetMember, r.privacyHandlers.RevokeMemberConsent)
+
+	// Notifications
+	r.protectedRoutes.Get("/members/:id/notifications", r.memberMiddlewares.GetMember, r.notificationHandlers.GetMemberNotifications)
+	r.protectedRoutes.Put("/members/:id/preferences", r.memberMiddlewares.GetMember, r.notificationHandlers.UpdateMemberPreferences)
 
 	r.protectedRoutes.Get("/members/:id", r.memberMiddlewares.GetMember, r.memberHandlers.GetMemberById)
 	r.protectedRoutes.Put("/members/:id", r.memberMiddlewares.GetMember, r.memberHandlers.UpdateMember)
@@ -11,7 +25,10 @@ func (r *Routes) RegisterMemberRoutes() {
 	//Subscription
 	r.protectedRoutes.Post("/members/:id/subscriptions", r.memberMiddlewares.GetMember, r.memberHandlers.CreateMemberSubscription)
 	r.protectedRoutes.Get("/members/:id/subscriptions", r.memberMiddlewares.GetMember, r.memberHandlers.GetMemberSubscriptions)
+	r.protectedRoutes.Get("/members/:id/subscriptions/trash", r.memberMiddlewares.GetMember, r.memberHandlers.GetDeletedMemberSubscriptions)
 	r.protectedRoutes.Get("/members/:id/subscriptions/:sub_id", r.memberMiddlewares.GetMember, r.memberHandlers.GetMemberSubscriptionById)
 	r.protectedRoutes.Put("/members/:id/subscriptions/:sub_id", r.memberMiddlewares.GetMember, r.memberHandlers.UpdateMemberSubscription)
 	r.protectedRoutes.Delete("/members/:id/subscriptions/:sub_id", r.memberMiddlewares.GetMember, r.memberHandlers.DeleteMemberSubscription)
+	r.protectedRoutes.Post("/members/:id/subscriptions/:sub_id/renew", r.memberMiddlewares.GetMember, r.memberHandlers.RenewMemberSubscription)
+	r.protectedRoutes.Post("/members/:id/subscriptions/:sub_id/restore", r.memberMiddlewares.GetMember, r.memberHandlers.RestoreMemberSubscription)
 }
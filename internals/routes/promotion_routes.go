@@ -0,0 +1,11 @@
+package routes
+
+func (r *Routes) RegisterPromotionRoutes() {
+	r.protectedRoutes.Post("/promotions", r.promotionHandlers.CreatePromotion)
+	r.protectedRoutes.Get("/promotions", r.promotionHandlers.GetPromotions)
+	r.protectedRoutes.Get("/promotions/report", r.promotionHandlers.GetRedemptionsReport)
+	r.protectedRoutes.Get("/promotions/:id", r.promotionHandlers.GetPromotion)
+	r.protectedRoutes.Put("/promotions/:id", r.promotionHandlers.UpdatePromotion)
+	r.protectedRoutes.Delete("/promotions/:id", r.promotionHandlers.DeletePromotion)
+	r.protectedRoutes.Get("/promotions/:id/redemptions", r.promotionHandlers.GetPromotionRedemptions)
+}
@@ -0,0 +1,10 @@
+package routes
+
+func (r *Routes) RegisterReportRoutes() {
+	r.protectedRoutes.Get("/subscriptions/reports/revenue", r.reportHandlers.GetRevenueReport)
+	r.protectedRoutes.Get("/subscriptions/reports/growth", r.reportHandlers.GetGrowthReport)
+	r.protectedRoutes.Get("/subscriptions/reports/active", r.reportHandlers.GetActiveMembersReport)
+	r.protectedRoutes.Get("/subscriptions/reports/mix", r.reportHandlers.GetSubscriptionMixReport)
+	r.protectedRoutes.Get("/subscriptions/reports/lifetime", r.reportHandlers.GetLifetimeReport)
+	r.protectedRoutes.Get("/subscriptions/reports/retention", r.reportHandlers.GetRetentionReport)
+}
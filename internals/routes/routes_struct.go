@@ -5,8 +5,11 @@ import (
 
 	primary "github.com/Erodot0/gym-memeber-management/internals/adapters/primary"
 	secondary "github.com/Erodot0/gym-memeber-management/internals/adapters/secondary"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/entities"
+	"github.com/Erodot0/gym-memeber-management/internals/app/domains/ports"
 	"github.com/Erodot0/gym-memeber-management/internals/app/domains/services"
 	"github.com/Erodot0/gym-memeber-management/internals/app/handlers"
+	"github.com/Erodot0/gym-memeber-management/internals/app/tools/metrics"
 	"github.com/Erodot0/gym-memeber-management/internals/app/tools/middlewares"
 	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
@@ -19,14 +22,30 @@ type Routes struct {
 	cache *redis.Client
 
 	// Middlewares
-	userMiddlewares   *middlewares.UserMiddlewares
-	memberMiddlewares *middlewares.MemberMiddlewares
+	userMiddlewares      *middlewares.UserMiddlewares
+	memberMiddlewares    *middlewares.MemberMiddlewares
+	householdMiddlewares *middlewares.HouseholdMiddlewares
+	guestMiddlewares     *middlewares.GuestMiddlewares
+	webhookMiddlewares   *middlewares.WebhookMiddlewares
+	rateLimitMiddlewares *middlewares.RateLimitMiddlewares
 
 	// Handlers
-	permissionHandlers *handlers.PermissionsHandler
-	memberHandlers     *handlers.MembersHandlers
-	userHandlers       *handlers.UserHandlers
-	roleHandlers       *handlers.RolesHandlers
+	permissionHandlers   *handlers.PermissionsHandler
+	memberHandlers       *handlers.MembersHandlers
+	userHandlers         *handlers.UserHandlers
+	roleHandlers         *handlers.RolesHandlers
+	householdHandlers    *handlers.HouseholdsHandlers
+	promotionHandlers    *handlers.PromotionsHandlers
+	guestHandlers        *handlers.GuestsHandlers
+	auditHandlers        *handlers.AuditsHandlers
+	privacyHandlers      *handlers.PrivacyHandlers
+	importHandlers       *handlers.ImportHandlers
+	exportHandlers       *handlers.ExportHandlers
+	reportHandlers       *handlers.ReportsHandlers
+	notificationHandlers *handlers.NotificationsHandlers
+	webhookHandlers      *handlers.WebhooksHandlers
+	docsHandlers         *handlers.DocsHandlers
+	healthHandlers       *handlers.HealthHandlers
 
 	// Routes
 	authRoutes      fiber.Router
@@ -35,28 +54,57 @@ type Routes struct {
 }
 
 // NewRoutes creates a new Routes struct.
-func NewRoutes(app *fiber.App, db *gorm.DB, cache *redis.Client) *Routes {
+func NewRoutes(app *fiber.App, db *gorm.DB, cache *redis.Client, events ports.EventBus, config *entities.Config, registry *metrics.Registry) *Routes {
 
 	// Adapters
 	httpAdapters := secondary.NewHttpServices()
 	cacheAdapters := secondary.NewCacheServices(cache)
 	parserAdapters := primary.NewErrorHandler()
+	tableAdapters := secondary.NewTableServices()
+	webhookAdapters := secondary.NewWebhookClient()
 
 	// Services
-	memberServices := services.NewMemberServices(db)
-	rolesServices := services.NewRolesServices(db)
-	userServices := services.NewUserServices(db, cacheAdapters, rolesServices)
-	permissionsServices := services.NewPermissionsService(db, rolesServices)
+	auditServices := services.NewAuditServices(db)
+	memberServices := services.NewMemberServices(db, events)
+	householdServices := services.NewHouseholdServices(db)
+	promotionServices := services.NewPromotionServices(db)
+	guestServices := services.NewGuestServices(db, memberServices)
+	privacyServices := services.NewPrivacyServices(db)
+	importServices := services.NewImportServices(db, events)
+	exportServices := services.NewExportServices(db)
+	reportServices := services.NewReportServices(db, cacheAdapters)
+	notificationServices := services.NewNotificationServices(db, privacyServices)
+	webhookServices := services.NewWebhookServices(db, webhookAdapters)
+	rolesServices := services.NewRolesServices(db, events, config.System)
+	userServices := services.NewUserServices(db, cacheAdapters, events, config.System)
+	permissionsServices := services.NewPermissionsService(db, events, config.System)
+	healthServices := services.NewHealthServices(db, cacheAdapters)
 
 	// Middlewares
 	userMiddlewares := middlewares.NewUserMiddlewares(httpAdapters, userServices, permissionsServices)
 	memberMiddlewares := middlewares.NewMemberMiddlewares(httpAdapters, memberServices)
+	householdMiddlewares := middlewares.NewHouseholdMiddlewares(httpAdapters, householdServices)
+	guestMiddlewares := middlewares.NewGuestMiddlewares(httpAdapters, guestServices)
+	webhookMiddlewares := middlewares.NewWebhookMiddlewares(httpAdapters, webhookServices)
+	rateLimitMiddlewares := middlewares.NewRateLimitMiddlewares(cacheAdapters, config.RateLimit)
 
 	// Handlers
-	userHandlers := handlers.NewUserHandlers(parserAdapters, httpAdapters, userServices, rolesServices)
-	memberHandlers := handlers.NewMembersHandlers(parserAdapters, httpAdapters, memberServices)
-	rolesHandlers := handlers.NewRolesHandlers(parserAdapters, httpAdapters, rolesServices)
-	permissionsHandlers := handlers.NewPermissionsHandler(parserAdapters, httpAdapters, permissionsServices)
+	userHandlers := handlers.NewUserHandlers(parserAdapters, httpAdapters, userServices, rolesServices, auditServices)
+	memberHandlers := handlers.NewMembersHandlers(parserAdapters, httpAdapters, memberServices, householdServices, auditServices)
+	rolesHandlers := handlers.NewRolesHandlers(parserAdapters, httpAdapters, rolesServices, auditServices)
+	permissionsHandlers := handlers.NewPermissionsHandler(parserAdapters, httpAdapters, permissionsServices, auditServices)
+	householdHandlers := handlers.NewHouseholdsHandlers(parserAdapters, httpAdapters, householdServices, auditServices)
+	promotionHandlers := handlers.NewPromotionsHandlers(parserAdapters, httpAdapters, promotionServices, auditServices)
+	guestHandlers := handlers.NewGuestsHandlers(parserAdapters, httpAdapters, guestServices, householdServices, auditServices)
+	auditHandlers := handlers.NewAuditsHandlers(httpAdapters, auditServices)
+	privacyHandlers := handlers.NewPrivacyHandlers(parserAdapters, httpAdapters, privacyServices, auditServices)
+	importHandlers := handlers.NewImportHandlers(httpAdapters, importServices, auditServices)
+	exportHandlers := handlers.NewExportHandlers(httpAdapters, tableAdapters, exportServices)
+	reportHandlers := handlers.NewReportsHandlers(httpAdapters, reportServices)
+	notificationHandlers := handlers.NewNotificationsHandlers(parserAdapters, httpAdapters, notificationServices, auditServices)
+	webhookHandlers := handlers.NewWebhooksHandlers(parserAdapters, httpAdapters, webhookServices, auditServices)
+	docsHandlers := handlers.NewDocsHandlers(NewSpec())
+	healthHandlers := handlers.NewHealthHandlers(healthServices, registry)
 
 	// Create system roles
 	if err := rolesServices.CreateSystemRole(); err != nil {
@@ -79,10 +127,11 @@ func NewRoutes(app *fiber.App, db *gorm.DB, cache *redis.Client) *Routes {
 	// v1
 	v1 := api.Group("/v1")
 
-	// Public routes group
+	// Public routes group, limited by IP address
 	authRoutes := v1.Group("/auth")
-	publicApi := v1.Group("/public")
-	protectedApi := v1.Group("/protected", userMiddlewares.AuthorizeUser, userMiddlewares.CheckPermissions)
+	publicApi := v1.Group("/public", rateLimitMiddlewares.LimitGuest)
+	// Protected routes group, limited by IP address until authorized, then by user
+	protectedApi := v1.Group("/protected", rateLimitMiddlewares.LimitSession, userMiddlewares.AuthorizeUser, rateLimitMiddlewares.LimitUser, userMiddlewares.CheckPermissions)
 
 	return &Routes{
 		app:   app,
@@ -90,14 +139,30 @@ func NewRoutes(app *fiber.App, db *gorm.DB, cache *redis.Client) *Routes {
 		cache: cache,
 
 		// Middlewares
-		userMiddlewares:   userMiddlewares,
-		memberMiddlewares: memberMiddlewares,
+		userMiddlewares:      userMiddlewares,
+		memberMiddlewares:    memberMiddlewares,
+		householdMiddlewares: householdMiddlewares,
+		guestMiddlewares:     guestMiddlewares,
+		webhookMiddlewares:   webhookMiddlewares,
+		rateLimitMiddlewares: rateLimitMiddlewares,
 
 		// Handlers
-		userHandlers:       userHandlers,
-		memberHandlers:     memberHandlers,
-		roleHandlers:       rolesHandlers,
-		permissionHandlers: permissionsHandlers,
+		userHandlers:         userHandlers,
+		memberHandlers:       memberHandlers,
+		roleHandlers:         rolesHandlers,
+		permissionHandlers:   permissionsHandlers,
+		householdHandlers:    householdHandlers,
+		promotionHandlers:    promotionHandlers,
+		guestHandlers:        guestHandlers,
+		auditHandlers:        auditHandlers,
+		privacyHandlers:      privacyHandlers,
+		importHandlers:       importHandlers,
+		exportHandlers:       exportHandlers,
+		reportHandlers:       reportHandlers,
+		notificationHandlers: notificationHandlers,
+		webhookHandlers:      webhookHandlers,
+		docsHandlers:         docsHandlers,
+		healthHandlers:       healthHandlers,
 
 		// Routes
 		authRoutes:      authRoutes,
@@ -105,3 +170,19 @@ func NewRoutes(app *fiber.App, db *gorm.DB, cache *redis.Client) *Routes {
 		protectedRoutes: protectedApi,
 	}
 }
+
+// RegisterRoutes registers the routes of every domain.
+func (r *Routes) RegisterRoutes() {
+	r.RegisterExportRoutes() // before /members/:id
+	r.RegisterMemberRoutes()
+	r.RegisterUserRoutes()
+	r.RegisterRolesRoutes()
+	r.RegisterPermissionsRoutes()
+	r.RegisterHouseholdRoutes()
+	r.RegisterPromotionRoutes()
+	r.RegisterGuestRoutes()
+	r.RegisterAuditRoutes()
+	r.RegisterReportRoutes()
+	r.RegisterWebhookRoutes()
+	r.RegisterDocsRoutes()
+}
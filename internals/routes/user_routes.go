@@ -2,8 +2,8 @@ package routes
 
 func (r *Routes) RegisterUserRoutes() {
 	// Auth routes
-	r.authRoutes.Post("/login", r.userHandlers.Login)
-	r.authRoutes.Post("/logout", r.userMiddlewares.AuthorizeUser, r.userHandlers.Logout)
+	r.authRoutes.Post("/login", r.rateLimitMiddlewares.LimitLogin, r.userHandlers.Login)
+	r.authRoutes.Post("/logout", r.rateLimitMiddlewares.LimitSession, r.userMiddlewares.AuthorizeUser, r.rateLimitMiddlewares.LimitUser, r.userHandlers.Logout)
 
 	r.protectedRoutes.Post("/users", r.userHandlers.CreateUser)
 	r.protectedRoutes.Get("/users", r.userHandlers.GetUsers)
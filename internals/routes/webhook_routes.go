@@ -0,0 +1,14 @@
+package routes
+
+func (r *Routes) RegisterWebhookRoutes() {
+	r.protectedRoutes.Post("/webhooks", r.webhookHandlers.CreateWebhook)
+	r.protectedRoutes.Get("/webhooks", r.webhookHandlers.GetWebhooks)
+
+	r.protectedRoutes.Get("/webhooks/:webhook_id", r.webhookMiddlewares.GetWebhook, r.webhookHandlers.GetWebhookById)
+	r.protectedRoutes.Put("/webhooks/:webhook_id", r.webhookMiddlewares.GetWebhook, r.webhookHandlers.UpdateWebhook)
+	r.protectedRoutes.Delete("/webhooks/:webhook_id", r.webhookMiddlewares.GetWebhook, r.webhookHandlers.DeleteWebhook)
+
+	// Deliveries
+	r.protectedRoutes.Get("/webhooks/:webhook_id/deliveries", r.webhookMiddlewares.GetWebhook, r.webhookHandlers.GetWebhookDeliveries)
+	r.protectedRoutes.Post("/webhooks/:webhook_id/deliveries/:delivery_id/retry", r.webhookMiddlewares.GetWebhook, r.webhookHandlers.RetryWebhookDelivery)
+}